@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+)
+
+// exportCatalog writes every matching product as one JSON object per line.
+func exportCatalog(ctx context.Context, client productv1.ProductServiceClient, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	out := fs.String("out", "", "Output file (default stdout)")
+	cat := fs.String("cat", "", "Filter by category")
+	statuses := fs.String("status", "active", "Comma-separated statuses to include (e.g. active,inactive)")
+	pageSize := fs.Int("page-size", 100, "Products fetched per ListProducts call")
+	fs.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("failed to create %s: %v", *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	req := &productv1.ListProductsRequest{Category: *cat}
+	for _, s := range strings.Split(*statuses, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			req.Statuses = append(req.Statuses, s)
+		}
+	}
+
+	n, err := writeCatalog(ctx, client, req, int32(*pageSize), w)
+	if err != nil {
+		log.Fatalf("export failed after %d products: %v", n, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d products\n", n)
+}
+
+// writeCatalog pages through ListProducts until an empty page is returned and
+// writes each product to w as a single JSON line. It returns the number written.
+func writeCatalog(ctx context.Context, client productv1.ProductServiceClient, req *productv1.ListProductsRequest, pageSize int32, w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	written := 0
+	for offset := int32(0); ; {
+		page := &productv1.ListProductsRequest{
+			Category: req.Category,
+			Statuses: req.Statuses,
+			Limit:    pageSize,
+			Offset:   offset,
+		}
+		resp, err := client.ListProducts(ctx, page)
+		if err != nil {
+			return written, err
+		}
+		if len(resp.Products) == 0 {
+			return written, bw.Flush()
+		}
+
+		for _, p := range resp.Products {
+			b, err := protojson.Marshal(p)
+			if err != nil {
+				return written, err
+			}
+			bw.Write(b)
+			bw.WriteByte('\n')
+			written++
+		}
+		offset += int32(len(resp.Products))
+	}
+}
+
+// importCatalog reads JSONL produced by export and creates one product per line.
+// Products are created fresh at their exported base price, so IDs, status and
+// discounts are not carried over.
+func importCatalog(ctx context.Context, client productv1.ProductServiceClient, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	in := fs.String("in", "", "Input file (default stdin)")
+	fs.Parse(args)
+
+	var r io.Reader = os.Stdin
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatalf("failed to open %s: %v", *in, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	created := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var p productv1.Product
+		if err := protojson.Unmarshal(scanner.Bytes(), &p); err != nil {
+			log.Fatalf("line %d: invalid product: %v", line, err)
+		}
+		resp, err := client.CreateProduct(ctx, importRequest(&p))
+		if err != nil {
+			log.Fatalf("line %d: CreateProduct failed: %v", line, err)
+		}
+		fmt.Fprintf(os.Stderr, "line %d: %s -> %s\n", line, p.Id, resp.Id)
+		created++
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("failed to read input: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d products\n", created)
+}
+
+// importRequest recreates an exported product, keeping its base price and
+// currency rather than falling back to the category's default.
+func importRequest(p *productv1.Product) *productv1.CreateProductRequest {
+	req := &productv1.CreateProductRequest{
+		Name:        p.Name,
+		Description: p.Description,
+		Category:    p.Category,
+	}
+	if bp := p.BasePrice; bp != nil {
+		req.BasePrice = &productv1.Money{Amount: bp.Amount, Currency: bp.Currency}
+	}
+	return req
+}
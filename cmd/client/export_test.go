@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+)
+
+// fakeListClient serves ListProducts from an in-memory slice and records each request.
+type fakeListClient struct {
+	productv1.ProductServiceClient
+	products []*productv1.Product
+	requests []*productv1.ListProductsRequest
+}
+
+func (f *fakeListClient) ListProducts(_ context.Context, req *productv1.ListProductsRequest, _ ...grpc.CallOption) (*productv1.ListProductsReply, error) {
+	f.requests = append(f.requests, req)
+	start := min(int(req.Offset), len(f.products))
+	end := min(start+int(req.Limit), len(f.products))
+	return &productv1.ListProductsReply{Products: f.products[start:end], TotalCount: int32(end - start)}, nil
+}
+
+func TestWriteCatalog_PagesUntilExhausted(t *testing.T) {
+	client := &fakeListClient{}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		client.products = append(client.products, &productv1.Product{Id: id, Name: "product-" + id})
+	}
+
+	var buf bytes.Buffer
+	req := &productv1.ListProductsRequest{Category: "misc", Statuses: []string{"active", "inactive"}}
+	n, err := writeCatalog(context.Background(), client, req, 2, &buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 5 {
+		t.Fatalf("expected 5 products written, got %d", n)
+	}
+	// Pages of 2, 2, 1 followed by the empty page that ends the loop.
+	if len(client.requests) != 4 {
+		t.Fatalf("expected 4 ListProducts calls, got %d", len(client.requests))
+	}
+	for i, r := range client.requests {
+		wantOffset := min(int32(i*2), 5)
+		if r.Offset != wantOffset || r.Limit != 2 || r.Category != "misc" || len(r.Statuses) != 2 {
+			t.Fatalf("unexpected request %d: %+v", i, r)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 JSONL lines, got %d", len(lines))
+	}
+	var last productv1.Product
+	if err := protojson.Unmarshal([]byte(lines[4]), &last); err != nil {
+		t.Fatalf("line is not a valid product: %v", err)
+	}
+	if last.Id != "e" {
+		t.Fatalf("expected last product e, got %q", last.Id)
+	}
+}
+
+func TestImportRequest_KeepsBasePrice(t *testing.T) {
+	line := `{"id":"p-1","name":"Pho","category":"food","basePrice":{"amount":"45000","currency":"VND"}}`
+	var p productv1.Product
+	if err := protojson.Unmarshal([]byte(line), &p); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	req := importRequest(&p)
+	if req.Name != "Pho" || req.Category != "food" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if bp := req.BasePrice; bp == nil || bp.Amount != 45000 || bp.Currency != "VND" {
+		t.Fatalf("expected 45000 VND, got %+v", bp)
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
@@ -30,9 +34,27 @@ func printJSON(v proto.Message) {
 }
 
 var (
-	addr = flag.String("addr", ":50051", "gRPC server address")
+	addr    = flag.String("addr", ":50051", "gRPC server address")
+	timeout = flag.Duration("timeout", 5*time.Second, "Deadline for unary commands")
+	output  = flag.String("output", "text", "Output format for command results: text or json")
 )
 
+// longRunningCommands run until their work is exhausted or the user interrupts
+// them, so they are not bound by the unary -timeout.
+var longRunningCommands = map[string]bool{
+	"export": true,
+	"import": true,
+}
+
+// commandContext derives the context for cmd from parent: long-running commands
+// only inherit parent's cancellation, unary commands also get the timeout.
+func commandContext(parent context.Context, cmd string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if longRunningCommands[cmd] {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
 func main() {
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [global-flags] <command> [command-flags]\n", os.Args[0])
@@ -45,7 +67,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  list       List products\n")
 		fmt.Fprintf(os.Stderr, "  activate   Activate a product\n")
 		fmt.Fprintf(os.Stderr, "  deactivate Deactivate a product\n")
-		fmt.Fprintf(os.Stderr, "  discount   Manage discounts (subcommands: apply, remove)\n")
+		fmt.Fprintf(os.Stderr, "  discount   Manage discounts (subcommands: apply, remove, schedule, preview)\n")
+		fmt.Fprintf(os.Stderr, "               preview prints the base, effective and saved amounts for\n")
+		fmt.Fprintf(os.Stderr, "               -id and -pct at -at (default now) without applying anything\n")
+		fmt.Fprintf(os.Stderr, "  export     Export the catalog as JSONL\n")
+		fmt.Fprintf(os.Stderr, "  import     Create products from a JSONL export\n")
 	}
 	flag.Parse()
 
@@ -53,6 +79,9 @@ func main() {
 		flag.Usage()
 		os.Exit(1)
 	}
+	if *output != "text" && *output != "json" {
+		log.Fatalf("invalid output %q: expected text or json", *output)
+	}
 
 	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -61,12 +90,16 @@ func main() {
 	defer conn.Close()
 
 	client := productv1.NewProductServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
 	cmd := flag.Args()[0]
 	args := flag.Args()[1:]
 
+	// Ctrl-C / SIGTERM cancel any in-flight RPC cleanly.
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := commandContext(sigCtx, cmd, *timeout)
+	defer cancel()
+
 	switch cmd {
 	case "create":
 		createProduct(ctx, client, args)
@@ -82,6 +115,10 @@ func main() {
 		deactivateProduct(ctx, client, args)
 	case "discount":
 		manageDiscount(ctx, client, args)
+	case "export":
+		exportCatalog(ctx, client, args)
+	case "import":
+		importCatalog(ctx, client, args)
 	default:
 		log.Fatalf("unknown command: %s", cmd)
 	}
@@ -92,17 +129,31 @@ func createProduct(ctx context.Context, client productv1.ProductServiceClient, a
 	name := fs.String("name", "", "Product name")
 	desc := fs.String("desc", "", "Product description")
 	cat := fs.String("cat", "", "Product category")
+	price := fs.Int64("price", -1, "Base price in the smallest currency unit, e.g. 1999 for 19.99 USD (required)") // -1 indicates not set
+	currency := fs.String("currency", "", "Currency of -price, e.g. USD (default the category's currency)")
+	publishAt := fs.String("publish-at", "", "Stage the product until this RFC 3339 time (optional)")
+	unpublishAt := fs.String("unpublish-at", "", "Take the product down at this RFC 3339 time (optional)")
 	fs.Parse(args)
 
-	if *name == "" || *cat == "" {
-		log.Fatal("name and category are required")
+	if *name == "" || *cat == "" || *price < 0 {
+		log.Fatal("name, category and price are required")
 	}
 
-	resp, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{
+	req := &productv1.CreateProductRequest{
 		Name:        *name,
 		Description: *desc,
 		Category:    *cat,
-	})
+		BasePrice:   &productv1.Money{Amount: *price, Currency: *currency},
+	}
+	var err error
+	if req.PublishAt, err = parseTimestampFlag(*publishAt); err != nil {
+		log.Fatalf("invalid publish-at: %v", err)
+	}
+	if req.UnpublishAt, err = parseTimestampFlag(*unpublishAt); err != nil {
+		log.Fatalf("invalid unpublish-at: %v", err)
+	}
+
+	resp, err := client.CreateProduct(ctx, req)
 	if err != nil {
 		log.Fatalf("CreateProduct failed: %v", err)
 	}
@@ -115,12 +166,19 @@ func updateProduct(ctx context.Context, client productv1.ProductServiceClient, a
 	name := fs.String("name", "", "New name (optional)")
 	desc := fs.String("desc", "", "New description (optional)")
 	cat := fs.String("cat", "", "New category (optional)")
-	price := fs.Float64("price", -1, "New base price (optional)") // -1 indicates not set
+	price := fs.Int64("price", -1, "New base price as an integer in the smallest currency unit, e.g. 1999 for 19.99 USD, not a decimal; 0 makes it free (optional)") // -1 indicates not set
+	currency := fs.String("currency", "", "Currency of -price, e.g. USD (default the product's current currency)")
+	featured := fs.String("featured", "", "Mark as featured: true or false (optional)")
+	publishAt := fs.String("publish-at", "", "Schedule the launch at this RFC 3339 time, or \"none\" to cancel it (optional)")
+	unpublishAt := fs.String("unpublish-at", "", "Schedule the takedown at this RFC 3339 time, or \"none\" to cancel it (optional)")
 	fs.Parse(args)
 
 	if *id == "" {
 		log.Fatal("id is required")
 	}
+	if *currency != "" && *price < 0 {
+		log.Fatal("currency requires price")
+	}
 
 	req := &productv1.UpdateProductRequest{Id: *id}
 	if *name != "" {
@@ -133,14 +191,57 @@ func updateProduct(ctx context.Context, client productv1.ProductServiceClient, a
 		req.Category = *cat
 	}
 	if *price >= 0 {
-
+		req.BasePrice = &productv1.Money{Amount: *price, Currency: *currency}
+	}
+	if *featured != "" {
+		v, err := strconv.ParseBool(*featured)
+		if err != nil {
+			log.Fatalf("invalid featured %q: expected true or false", *featured)
+		}
+		req.Featured = &v
+	}
+	var err error
+	if req.PublishAt, err = parseScheduleFlag(*publishAt); err != nil {
+		log.Fatalf("invalid publish-at: %v", err)
+	}
+	if req.UnpublishAt, err = parseScheduleFlag(*unpublishAt); err != nil {
+		log.Fatalf("invalid unpublish-at: %v", err)
 	}
 
-	_, err := client.UpdateProduct(ctx, req)
+	resp, err := client.UpdateProduct(ctx, req)
 	if err != nil {
 		log.Fatalf("UpdateProduct failed: %v", err)
 	}
-	fmt.Println("Product updated successfully")
+	if !resp.Changed {
+		fmt.Println("No changes")
+		return
+	}
+	fmt.Printf("Product updated: %s\n", strings.Join(resp.ChangedFields, ", "))
+}
+
+// parseTimestampFlag parses an optional RFC 3339 flag value; "" is unset.
+func parseTimestampFlag(v string) (*timestamppb.Timestamp, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+// parseScheduleFlag turns an optional schedule flag into an update: an
+// RFC 3339 time sets the schedule, "none" cancels it and "" leaves it untouched.
+func parseScheduleFlag(v string) (*productv1.ScheduleUpdate, error) {
+	if v == "none" {
+		return &productv1.ScheduleUpdate{Clear: true}, nil
+	}
+	at, err := parseTimestampFlag(v)
+	if err != nil || at == nil {
+		return nil, err
+	}
+	return &productv1.ScheduleUpdate{At: at}, nil
 }
 
 func getProduct(ctx context.Context, client productv1.ProductServiceClient, args []string) {
@@ -164,15 +265,21 @@ func listProducts(ctx context.Context, client productv1.ProductServiceClient, ar
 	cat := fs.String("cat", "", "Filter by category")
 	limit := fs.Int("limit", 10, "Limit results")
 	offset := fs.Int("offset", 0, "Offset results")
+	featured := fs.Bool("featured", false, "Only list featured products")
+	onSale := fs.Bool("on-sale", false, "Only list products whose discount is valid now")
 	fs.Parse(args)
 
 	req := &productv1.ListProductsRequest{
 		Limit:  int32(*limit),
 		Offset: int32(*offset),
+		OnSale: *onSale,
 	}
 	if *cat != "" {
 		req.Category = *cat
 	}
+	if *featured {
+		req.Featured = featured
+	}
 
 	resp, err := client.ListProducts(ctx, req)
 	if err != nil {
@@ -184,13 +291,14 @@ func listProducts(ctx context.Context, client productv1.ProductServiceClient, ar
 func activateProduct(ctx context.Context, client productv1.ProductServiceClient, args []string) {
 	fs := flag.NewFlagSet("activate", flag.ExitOnError)
 	id := fs.String("id", "", "Product ID")
+	restore := fs.Bool("restore-discount", false, "Restore the discount removed by the last deactivation if still valid")
 	fs.Parse(args)
 
 	if *id == "" {
 		log.Fatal("id is required")
 	}
 
-	_, err := client.ActivateProduct(ctx, &productv1.ActivateProductRequest{Id: *id})
+	_, err := client.ActivateProduct(ctx, &productv1.ActivateProductRequest{Id: *id, RestoreDiscount: *restore})
 	if err != nil {
 		log.Fatalf("ActivateProduct failed: %v", err)
 	}
@@ -200,13 +308,14 @@ func activateProduct(ctx context.Context, client productv1.ProductServiceClient,
 func deactivateProduct(ctx context.Context, client productv1.ProductServiceClient, args []string) {
 	fs := flag.NewFlagSet("deactivate", flag.ExitOnError)
 	id := fs.String("id", "", "Product ID")
+	force := fs.Bool("force", false, "Drop a scheduled discount that has not started yet")
 	fs.Parse(args)
 
 	if *id == "" {
 		log.Fatal("id is required")
 	}
 
-	_, err := client.DeactivateProduct(ctx, &productv1.DeactivateProductRequest{Id: *id})
+	_, err := client.DeactivateProduct(ctx, &productv1.DeactivateProductRequest{Id: *id, Force: *force})
 	if err != nil {
 		log.Fatalf("DeactivateProduct failed: %v", err)
 	}
@@ -215,7 +324,7 @@ func deactivateProduct(ctx context.Context, client productv1.ProductServiceClien
 
 func manageDiscount(ctx context.Context, client productv1.ProductServiceClient, args []string) {
 	if len(args) < 1 {
-		log.Fatal("subcommand required: apply or remove")
+		log.Fatal("subcommand required: apply, remove, schedule or preview")
 	}
 	sub := args[0]
 	subArgs := args[1:]
@@ -236,14 +345,11 @@ func manageDiscount(ctx context.Context, client productv1.ProductServiceClient,
 		if err != nil {
 			log.Fatalf("invalid duration: %v", err)
 		}
-		startsAt := time.Now()
-		endsAt := startsAt.Add(duration)
-
 		_, err = client.ApplyDiscount(ctx, &productv1.ApplyDiscountRequest{
-			Id:         *id,
-			Percentage: *pct,
-			StartsAt:   timestamppb.New(startsAt),
-			EndsAt:     timestamppb.New(endsAt),
+			Id:              *id,
+			Percentage:      *pct,
+			StartsAt:        timestamppb.Now(),
+			DurationSeconds: int64(duration / time.Second),
 		})
 		if err != nil {
 			log.Fatalf("ApplyDiscount failed: %v", err)
@@ -253,18 +359,25 @@ func manageDiscount(ctx context.Context, client productv1.ProductServiceClient,
 	case "remove":
 		fs := flag.NewFlagSet("discount remove", flag.ExitOnError)
 		id := fs.String("id", "", "Product ID")
+		idempotent := fs.Bool("idempotent", false, "Succeed even if the product has no discount")
 		fs.Parse(subArgs)
 
 		if *id == "" {
 			log.Fatal("id is required")
 		}
 
-		_, err := client.RemoveDiscount(ctx, &productv1.RemoveDiscountRequest{Id: *id})
+		_, err := client.RemoveDiscount(ctx, &productv1.RemoveDiscountRequest{Id: *id, Idempotent: *idempotent})
 		if err != nil {
 			log.Fatalf("RemoveDiscount failed: %v", err)
 		}
 		fmt.Println("Discount removed")
 
+	case "schedule":
+		scheduleDiscounts(ctx, client, subArgs)
+
+	case "preview":
+		previewDiscount(ctx, client, subArgs)
+
 	default:
 		log.Fatalf("unknown discount subcommand: %s", sub)
 	}
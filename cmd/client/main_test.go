@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCommandContext_UnaryCommandsHaveDeadline(t *testing.T) {
+	ctx, cancel := commandContext(context.Background(), "get", 3*time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected unary command context to have a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > 3*time.Second {
+		t.Fatalf("unexpected remaining time %s", remaining)
+	}
+}
+
+func TestCommandContext_LongRunningCommandsHaveNoDeadline(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := commandContext(parent, "export", time.Millisecond)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected long-running command context to have no deadline")
+	}
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected parent cancellation to propagate")
+	}
+}
+
+func TestParseScheduleFlag(t *testing.T) {
+	if upd, err := parseScheduleFlag(""); err != nil || upd != nil {
+		t.Fatalf("expected an empty flag to leave the schedule untouched, got %v (err=%v)", upd, err)
+	}
+	if upd, err := parseScheduleFlag("none"); err != nil || !upd.GetClear() {
+		t.Fatalf("expected none to cancel the schedule, got %v (err=%v)", upd, err)
+	}
+	upd, err := parseScheduleFlag("2026-03-01T09:00:00Z")
+	if err != nil || upd.GetClear() || !upd.GetAt().AsTime().Equal(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the time to be scheduled, got %v (err=%v)", upd, err)
+	}
+	if _, err := parseScheduleFlag("tomorrow"); err == nil {
+		t.Fatal("expected an error for a non-RFC 3339 value")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+)
+
+// previewDiscount prints what a product would cost with a discount applied,
+// without applying it.
+func previewDiscount(ctx context.Context, client productv1.ProductServiceClient, args []string) {
+	fs := flag.NewFlagSet("discount preview", flag.ExitOnError)
+	id := fs.String("id", "", "Product ID")
+	pct := fs.String("pct", "", "Percentage (e.g. 10.5)")
+	at := fs.String("at", "", "Evaluate at this RFC 3339 time (default now)")
+	fs.Parse(args)
+
+	if *id == "" || *pct == "" {
+		log.Fatal("id and pct are required")
+	}
+
+	req := &productv1.PreviewDiscountRequest{Id: *id, Percentage: *pct}
+	var err error
+	if req.At, err = parseTimestampFlag(*at); err != nil {
+		log.Fatalf("invalid at: %v", err)
+	}
+
+	if err := writeDiscountPreview(ctx, client, req, *output, os.Stdout); err != nil {
+		log.Fatalf("PreviewDiscount failed: %v", err)
+	}
+}
+
+// writeDiscountPreview calls PreviewDiscount and writes the reply to w as
+// JSON or, for any other format, as aligned text.
+func writeDiscountPreview(ctx context.Context, client productv1.ProductServiceClient, req *productv1.PreviewDiscountRequest, format string, w io.Writer) error {
+	resp, err := client.PreviewDiscount(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		b, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s%% off %s at %s\n  base:      %s\n  effective: %s\n  saved:     %s\n",
+		resp.Percentage, resp.Id, resp.At.AsTime().Format(time.RFC3339),
+		formatMoney(resp.BasePrice), formatMoney(resp.EffectivePrice), formatMoney(resp.Saved))
+	return err
+}
+
+// formatMoney renders m in minor units followed by its currency, e.g. "849 USD".
+func formatMoney(m *productv1.Money) string {
+	return fmt.Sprintf("%d %s", m.GetAmount(), m.GetCurrency())
+}
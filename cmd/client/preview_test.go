@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+)
+
+// fakePreviewClient answers PreviewDiscount with a fixed 15% off 999 USD and
+// records each request. Any mutating RPC would panic on the nil embedded client.
+type fakePreviewClient struct {
+	productv1.ProductServiceClient
+	requests []*productv1.PreviewDiscountRequest
+}
+
+func (f *fakePreviewClient) PreviewDiscount(_ context.Context, req *productv1.PreviewDiscountRequest, _ ...grpc.CallOption) (*productv1.PreviewDiscountReply, error) {
+	f.requests = append(f.requests, req)
+	return &productv1.PreviewDiscountReply{
+		Id:             req.Id,
+		Percentage:     "15.0",
+		At:             req.At,
+		BasePrice:      &productv1.Money{Amount: 999, Currency: "USD"},
+		EffectivePrice: &productv1.Money{Amount: 849, Currency: "USD"},
+		Saved:          &productv1.Money{Amount: 150, Currency: "USD"},
+	}, nil
+}
+
+func TestWriteDiscountPreview_Text(t *testing.T) {
+	client := &fakePreviewClient{}
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	req := &productv1.PreviewDiscountRequest{Id: "p1", Percentage: "15", At: timestamppb.New(at)}
+
+	var buf bytes.Buffer
+	if err := writeDiscountPreview(context.Background(), client, req, "text", &buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(client.requests) != 1 || client.requests[0].Percentage != "15" || !client.requests[0].At.AsTime().Equal(at) {
+		t.Fatalf("unexpected requests %v", client.requests)
+	}
+	out := buf.String()
+	for _, want := range []string{"15.0% off p1 at 2026-03-01T09:00:00Z", "base:      999 USD", "effective: 849 USD", "saved:     150 USD"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteDiscountPreview_JSON(t *testing.T) {
+	client := &fakePreviewClient{}
+	req := &productv1.PreviewDiscountRequest{Id: "p1", Percentage: "15"}
+
+	var buf bytes.Buffer
+	if err := writeDiscountPreview(context.Background(), client, req, "json", &buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got productv1.PreviewDiscountReply
+	if err := protojson.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a valid reply: %v\n%s", err, buf.String())
+	}
+	if got.Id != "p1" || got.EffectivePrice.GetAmount() != 849 || got.Saved.GetAmount() != 150 {
+		t.Fatalf("unexpected reply %v", &got)
+	}
+}
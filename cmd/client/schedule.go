@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+)
+
+// scheduleRow is one discount in a schedule file. Times are RFC 3339.
+type scheduleRow struct {
+	ProductID  string    `json:"product_id"`
+	Percentage string    `json:"percentage"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+// scheduleDiscounts reads a schedule file and applies it with ApplyDiscountSchedule.
+func scheduleDiscounts(ctx context.Context, client productv1.ProductServiceClient, args []string) {
+	fs := flag.NewFlagSet("discount schedule", flag.ExitOnError)
+	file := fs.String("file", "", "Schedule file (.json array or .csv with a header row)")
+	fs.Parse(args)
+
+	if *file == "" {
+		log.Fatal("file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *file, err)
+	}
+	defer f.Close()
+
+	rows, err := readSchedule(f, strings.EqualFold(filepath.Ext(*file), ".csv"))
+	if err != nil {
+		log.Fatalf("invalid schedule %s: %v", *file, err)
+	}
+
+	req := &productv1.ApplyDiscountScheduleRequest{}
+	for _, r := range rows {
+		req.Entries = append(req.Entries, &productv1.ScheduleEntry{
+			ProductId:  r.ProductID,
+			Percentage: r.Percentage,
+			StartsAt:   timestamppb.New(r.StartsAt),
+			EndsAt:     timestamppb.New(r.EndsAt),
+		})
+	}
+
+	resp, err := client.ApplyDiscountSchedule(ctx, req)
+	if err != nil {
+		log.Fatalf("ApplyDiscountSchedule failed: %v", err)
+	}
+	for i, r := range resp.Results {
+		if !r.Applied {
+			fmt.Printf("entry %d (%s): %s\n", i+1, r.ProductId, r.Error)
+		}
+	}
+	fmt.Printf("Applied %d of %d scheduled discounts\n", resp.AppliedCount, len(resp.Results))
+}
+
+// readSchedule parses a JSON array of rows, or CSV with the header
+// product_id,percentage,starts_at,ends_at when isCSV is set.
+func readSchedule(r io.Reader, isCSV bool) ([]scheduleRow, error) {
+	if !isCSV {
+		var rows []scheduleRow
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 4
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	if strings.Join(header, ",") != "product_id,percentage,starts_at,ends_at" {
+		return nil, fmt.Errorf("header must be product_id,percentage,starts_at,ends_at, got %s", strings.Join(header, ","))
+	}
+
+	var rows []scheduleRow
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		startsAt, err := time.Parse(time.RFC3339, rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: starts_at: %w", line, err)
+		}
+		endsAt, err := time.Parse(time.RFC3339, rec[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: ends_at: %w", line, err)
+		}
+		rows = append(rows, scheduleRow{ProductID: rec[0], Percentage: rec[1], StartsAt: startsAt, EndsAt: endsAt})
+	}
+}
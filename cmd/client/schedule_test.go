@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadSchedule_JSON(t *testing.T) {
+	rows, err := readSchedule(strings.NewReader(`[
+		{"product_id":"p1","percentage":"10","starts_at":"2025-06-01T00:00:00Z","ends_at":"2025-06-08T00:00:00Z"}
+	]`), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ProductID != "p1" || rows[0].Percentage != "10" || rows[0].EndsAt.Day() != 8 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+}
+
+func TestReadSchedule_CSV(t *testing.T) {
+	rows, err := readSchedule(strings.NewReader(
+		"product_id,percentage,starts_at,ends_at\n"+
+			"p1,10,2025-06-01T00:00:00Z,2025-06-08T00:00:00Z\n"+
+			"p2,25.5,2025-07-01T00:00:00Z,2025-07-02T00:00:00Z\n"), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1].ProductID != "p2" || rows[1].Percentage != "25.5" || rows[1].StartsAt.Month() != 7 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+}
+
+func TestReadSchedule_CSVRejectsBadInput(t *testing.T) {
+	for name, in := range map[string]string{
+		"header": "id,pct,from,to\np1,10,2025-06-01T00:00:00Z,2025-06-08T00:00:00Z\n",
+		"time":   "product_id,percentage,starts_at,ends_at\np1,10,tomorrow,2025-06-08T00:00:00Z\n",
+		"fields": "product_id,percentage,starts_at,ends_at\np1,10\n",
+	} {
+		if _, err := readSchedule(strings.NewReader(in), true); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
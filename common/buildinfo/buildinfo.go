@@ -0,0 +1,25 @@
+// Package buildinfo exposes build metadata injected at link time, e.g.:
+//
+//	go build -ldflags "-X github.com/product-catalog-service/common/buildinfo.Version=v1.2.3 \
+//	  -X github.com/product-catalog-service/common/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/product-catalog-service/common/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+// Set via -ldflags; the defaults identify a local, unstamped build.
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info is a snapshot of the build metadata.
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+}
+
+// Get returns the build metadata of the running binary.
+func Get() Info {
+	return Info{Version: Version, Commit: Commit, BuildTime: BuildTime}
+}
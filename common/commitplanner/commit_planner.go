@@ -7,7 +7,8 @@ import (
 )
 
 type Plan struct {
-	muts []*spanner.Mutation
+	muts   []*spanner.Mutation
+	events []any
 }
 
 // Applier is the interface used by interactors to commit a Plan.
@@ -28,11 +29,39 @@ func (p *Plan) Add(mut *spanner.Mutation) {
 	p.muts = append(p.muts, mut)
 }
 
+// AddEvent adds mut, the mutation persisting event, and keeps event so Applier
+// decorators can act on it once the plan commits.
+func (p *Plan) AddEvent(mut *spanner.Mutation, event any) {
+	p.Add(mut)
+	p.events = append(p.events, event)
+}
+
+// Events returns the events added with AddEvent, in order.
+func (p *Plan) Events() []any {
+	return p.events
+}
+
+// Len returns the number of mutations in the plan.
+func (p *Plan) Len() int {
+	return len(p.muts)
+}
+
 func NewCommitter(client *spanner.Client) *Committer {
 	return &Committer{dbClient: client}
 }
 
+// Apply commits p in one attempt. Unlike spanner.Client.Apply, an aborted
+// commit is returned as is rather than retried, so a RetryApplier in front
+// bounds the attempts.
 func (c *Committer) Apply(ctx context.Context, p *Plan) error {
-	_, err := c.dbClient.Apply(ctx, p.muts)
+	tx, err := spanner.NewReadWriteStmtBasedTransaction(ctx, c.dbClient)
+	if err != nil {
+		return err
+	}
+	if err := tx.BufferWrite(p.muts); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	_, err = tx.Commit(ctx)
 	return err
 }
@@ -0,0 +1,66 @@
+package commitplanner
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// InstrumentedApplier wraps an Applier, recording commit latency and mutation
+// count and logging commits slower than a threshold.
+type InstrumentedApplier struct {
+	next      Applier
+	log       *zap.Logger
+	slow      time.Duration
+	duration  metric.Float64Histogram
+	mutations metric.Int64Histogram
+}
+
+// NewInstrumentedApplier decorates next. slow <= 0 disables slow-commit logging.
+func NewInstrumentedApplier(next Applier, meter metric.Meter, log *zap.Logger, slow time.Duration) (*InstrumentedApplier, error) {
+	duration, err := meter.Float64Histogram("spanner.commit.duration",
+		metric.WithDescription("Latency of Spanner commits."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	mutations, err := meter.Int64Histogram("spanner.commit.mutations",
+		metric.WithDescription("Mutations per Spanner commit."),
+		metric.WithUnit("{mutation}"))
+	if err != nil {
+		return nil, err
+	}
+	return &InstrumentedApplier{next: next, log: log, slow: slow, duration: duration, mutations: mutations}, nil
+}
+
+func (a *InstrumentedApplier) Apply(ctx context.Context, p *Plan) error {
+	start := time.Now()
+	err := a.next.Apply(ctx, p)
+	elapsed := time.Since(start)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	code := spanner.ErrCode(err).String()
+	attrs := metric.WithAttributes(
+		attribute.String("outcome", outcome),
+		attribute.String("code", code),
+	)
+	a.duration.Record(ctx, elapsed.Seconds(), attrs)
+	a.mutations.Record(ctx, int64(p.Len()), attrs)
+
+	if a.slow > 0 && elapsed > a.slow {
+		a.log.Warn("slow spanner commit",
+			zap.Duration("duration", elapsed),
+			zap.Int("mutations", p.Len()),
+			zap.String("outcome", outcome),
+			zap.String("code", code),
+		)
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package commitplanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type fakeApplier struct{ err error }
+
+func (f fakeApplier) Apply(context.Context, *Plan) error { return f.err }
+
+func TestInstrumentedApplier_RecordsCommit(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+	core, logs := observer.New(zap.WarnLevel)
+
+	a, err := NewInstrumentedApplier(fakeApplier{err: errors.New("boom")}, meter, zap.New(core), 0)
+	if err != nil {
+		t.Fatalf("new applier: %v", err)
+	}
+
+	plan := NewPlan()
+	plan.Add(&spanner.Mutation{})
+	plan.Add(&spanner.Mutation{})
+	if err := a.Apply(context.Background(), plan); err == nil {
+		t.Fatal("expected the wrapped error to be returned")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	durations, ok := got["spanner.commit.duration"].(metricdata.Histogram[float64])
+	if !ok || len(durations.DataPoints) != 1 || durations.DataPoints[0].Count != 1 {
+		t.Fatalf("expected one duration observation, got %+v", got["spanner.commit.duration"])
+	}
+	if v, _ := durations.DataPoints[0].Attributes.Value("outcome"); v.AsString() != "failure" {
+		t.Fatalf("expected outcome=failure, got %q", v.AsString())
+	}
+
+	mutations, ok := got["spanner.commit.mutations"].(metricdata.Histogram[int64])
+	if !ok || len(mutations.DataPoints) != 1 || mutations.DataPoints[0].Sum != 2 {
+		t.Fatalf("expected 2 mutations observed, got %+v", got["spanner.commit.mutations"])
+	}
+
+	if logs.Len() != 0 {
+		t.Fatalf("slow-commit logging is disabled, got %d logs", logs.Len())
+	}
+}
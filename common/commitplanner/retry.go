@@ -0,0 +1,101 @@
+package commitplanner
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// maxRetryDelay caps the backoff between two attempts.
+const maxRetryDelay = time.Second
+
+// RetryApplier wraps an Applier, retrying commits Spanner aborted. Aborted
+// commits wrote nothing, so the same plan is simply applied again. Each wait
+// is drawn uniformly from zero up to an exponentially growing bound ("full
+// jitter"), so writers that aborted together do not retry in lockstep and
+// collide again. A retry whose wait would outlast the caller's deadline is not
+// attempted; the abort is returned instead. next must make a single attempt,
+// as Committer does: spanner.Client.Apply retries aborts on its own.
+type RetryApplier struct {
+	next        Applier
+	log         *zap.Logger
+	maxAttempts int
+	baseDelay   time.Duration
+
+	// Overridden by tests.
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(n int64) int64 // uniform in [0, n)
+}
+
+// NewRetryApplier decorates next. maxAttempts counts the first attempt, so
+// values below 2 disable retries; baseDelay bounds the first wait and doubles
+// for each further one, up to a second.
+func NewRetryApplier(next Applier, log *zap.Logger, maxAttempts int, baseDelay time.Duration) *RetryApplier {
+	return &RetryApplier{
+		next:        next,
+		log:         log,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		now:         time.Now,
+		sleep:       sleepCtx,
+		jitter:      rand.Int64N,
+	}
+}
+
+func (a *RetryApplier) Apply(ctx context.Context, p *Plan) error {
+	for attempt := 1; ; attempt++ {
+		err := a.next.Apply(ctx, p)
+		if err == nil || spanner.ErrCode(err) != codes.Aborted || attempt >= a.maxAttempts {
+			return err
+		}
+
+		delay := a.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && !a.now().Add(delay).Before(deadline) {
+			a.log.Warn("spanner commit aborted; no time left to retry",
+				zap.Int("attempt", attempt),
+				zap.Int("mutations", p.Len()),
+			)
+			return err
+		}
+		a.log.Info("spanner commit aborted; retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", delay),
+			zap.Int("mutations", p.Len()),
+		)
+		if err := a.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// backoff draws the wait after the given failed attempt: uniform in
+// [0, min(baseDelay·2^(attempt-1), maxRetryDelay)].
+func (a *RetryApplier) backoff(attempt int) time.Duration {
+	bound := a.baseDelay
+	for i := 1; i < attempt && bound < maxRetryDelay; i++ {
+		bound *= 2
+	}
+	bound = min(bound, maxRetryDelay)
+	if bound <= 0 {
+		return 0
+	}
+	return time.Duration(a.jitter(int64(bound) + 1))
+}
+
+// sleepCtx waits for d or until ctx is done, returning ctx's error in the
+// latter case.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,111 @@
+package commitplanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// abortingApplier fails every commit with err and counts the attempts.
+type abortingApplier struct {
+	err   error
+	calls int
+}
+
+func (a *abortingApplier) Apply(context.Context, *Plan) error {
+	a.calls++
+	return a.err
+}
+
+// fakeClock lets a RetryApplier sleep instantly while its view of the time
+// advances by each wait.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) install(a *RetryApplier) {
+	a.now = func() time.Time { return c.now }
+	a.sleep = func(_ context.Context, d time.Duration) error {
+		c.sleeps = append(c.sleeps, d)
+		c.now = c.now.Add(d)
+		return nil
+	}
+	a.jitter = func(n int64) int64 { return n - 1 } // always the longest wait
+}
+
+func TestRetryApplier_StopsAtMaxAttempts(t *testing.T) {
+	next := &abortingApplier{err: status.Error(codes.Aborted, "transaction aborted")}
+	a := NewRetryApplier(next, zap.NewNop(), 4, 10*time.Millisecond)
+	clock := &fakeClock{now: time.Now()}
+	clock.install(a)
+
+	err := a.Apply(context.Background(), NewPlan())
+
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected the last Aborted error, got %v", err)
+	}
+	if next.calls != 4 {
+		t.Fatalf("expected 4 attempts, got %d", next.calls)
+	}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("expected waits %v, got %v", want, clock.sleeps)
+	}
+	for i, d := range clock.sleeps {
+		if d > want[i] {
+			t.Fatalf("wait %d: expected at most %s, got %s", i, want[i], d)
+		}
+	}
+}
+
+func TestRetryApplier_DoesNotSleepPastDeadline(t *testing.T) {
+	next := &abortingApplier{err: status.Error(codes.Aborted, "transaction aborted")}
+	a := NewRetryApplier(next, zap.NewNop(), 10, 40*time.Millisecond)
+	start := time.Now()
+	clock := &fakeClock{now: start}
+	clock.install(a)
+
+	deadline := start.Add(100 * time.Millisecond)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	err := a.Apply(ctx, NewPlan())
+
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected the last Aborted error, got %v", err)
+	}
+	// Waits of 40ms then 80ms: the second would end past the deadline.
+	if next.calls != 2 || len(clock.sleeps) != 1 {
+		t.Fatalf("expected 2 attempts and 1 wait, got %d attempts and waits %v", next.calls, clock.sleeps)
+	}
+	if clock.now.After(deadline) {
+		t.Fatalf("slept until %s, past the deadline %s", clock.now.Sub(start), deadline.Sub(start))
+	}
+}
+
+func TestRetryApplier_OnlyRetriesAborted(t *testing.T) {
+	for _, err := range []error{nil, errors.New("boom"), status.Error(codes.AlreadyExists, "duplicate key")} {
+		next := &abortingApplier{err: err}
+		a := NewRetryApplier(next, zap.NewNop(), 5, time.Millisecond)
+		(&fakeClock{now: time.Now()}).install(a)
+
+		if got := a.Apply(context.Background(), NewPlan()); !errors.Is(got, err) || next.calls != 1 {
+			t.Fatalf("%v: expected a single attempt returning the error, got %d attempts and %v", err, next.calls, got)
+		}
+	}
+}
+
+func TestRetryApplier_JitterStaysWithinCap(t *testing.T) {
+	a := NewRetryApplier(nil, zap.NewNop(), 30, 100*time.Millisecond)
+	for attempt := 1; attempt <= 30; attempt++ {
+		if d := a.backoff(attempt); d < 0 || d > maxRetryDelay {
+			t.Fatalf("attempt %d: backoff %s outside [0, %s]", attempt, d, maxRetryDelay)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package commitplanner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TimeoutApplier wraps an Applier, bounding every commit by a fixed timeout so
+// a caller with a very long (or no) deadline cannot hold a write transaction
+// open indefinitely. A caller deadline shorter than the timeout still wins.
+type TimeoutApplier struct {
+	next    Applier
+	log     *zap.Logger
+	timeout time.Duration
+}
+
+// NewTimeoutApplier decorates next. timeout <= 0 disables the bound.
+func NewTimeoutApplier(next Applier, log *zap.Logger, timeout time.Duration) *TimeoutApplier {
+	return &TimeoutApplier{next: next, log: log, timeout: timeout}
+}
+
+func (a *TimeoutApplier) Apply(ctx context.Context, p *Plan) error {
+	if a.timeout <= 0 {
+		return a.next.Apply(ctx, p)
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	err := a.next.Apply(opCtx, p)
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		a.log.Info("spanner commit abandoned by caller",
+			zap.Int("mutations", p.Len()),
+			zap.NamedError("cause", ctx.Err()),
+		)
+	case errors.Is(opCtx.Err(), context.DeadlineExceeded):
+		a.log.Warn("spanner commit timed out",
+			zap.Duration("timeout", a.timeout),
+			zap.Int("mutations", p.Len()),
+		)
+	}
+	return err
+}
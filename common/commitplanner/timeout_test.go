@@ -0,0 +1,64 @@
+package commitplanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// slowApplier blocks until ctx is done and returns its error.
+type slowApplier struct{}
+
+func (slowApplier) Apply(ctx context.Context, _ *Plan) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTimeoutApplier_TimesOutSlowCommit(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	a := NewTimeoutApplier(slowApplier{}, zap.New(core), 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	if err := a.Apply(ctx, NewPlan()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("caller context must not be affected by the commit timeout")
+	}
+	if n := logs.FilterMessage("spanner commit timed out").Len(); n != 1 {
+		t.Fatalf("expected 1 timeout warning, got %d", n)
+	}
+}
+
+func TestTimeoutApplier_DistinguishesCallerCancellation(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	a := NewTimeoutApplier(slowApplier{}, zap.New(core), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := a.Apply(ctx, NewPlan()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if n := logs.FilterMessage("spanner commit abandoned by caller").Len(); n != 1 {
+		t.Fatalf("expected 1 cancellation log, got %d", n)
+	}
+	if n := logs.FilterMessage("spanner commit timed out").Len(); n != 0 {
+		t.Fatalf("caller cancellation must not be logged as a timeout, got %d", n)
+	}
+}
+
+func TestTimeoutApplier_ZeroDisables(t *testing.T) {
+	wantErr := errors.New("boom")
+	a := NewTimeoutApplier(fakeApplier{err: wantErr}, zap.NewNop(), 0)
+
+	if err := a.Apply(context.Background(), NewPlan()); !errors.Is(err, wantErr) {
+		t.Fatalf("expected wrapped error to pass through, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package common
+
+import "context"
+
+type currencyKey struct{}
+
+// WithCurrency returns a context asking read paths to show prices in
+// currency, e.g. from a request's Accept-Currency header.
+func WithCurrency(ctx context.Context, currency string) context.Context {
+	return context.WithValue(ctx, currencyKey{}, currency)
+}
+
+// CurrencyFromContext returns the currency set by WithCurrency, or "" when
+// prices should stay in their stored currency.
+func CurrencyFromContext(ctx context.Context) string {
+	c, _ := ctx.Value(currencyKey{}).(string)
+	return c
+}
+
+// ParseCurrency upper-cases a client-supplied currency code such as "vnd"
+// and reports whether it is a well-formed ISO-4217 code.
+func ParseCurrency(s string) (string, bool) {
+	if len(s) != 3 {
+		return "", false
+	}
+	code := make([]byte, 3)
+	for i := range 3 {
+		c := s[i]
+		if 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			return "", false
+		}
+		code[i] = c
+	}
+	return string(code), true
+}
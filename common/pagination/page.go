@@ -0,0 +1,21 @@
+// Package pagination holds the response envelope shared by list endpoints.
+package pagination
+
+// Page is one page of a paginated listing.
+type Page[T any] struct {
+	Items      []T
+	TotalCount int    // total matching rows when requested; otherwise the page size
+	NextCursor string // token for the next page; "" on the last page
+	HasNext    bool   // whether NextCursor points at another page
+}
+
+// NewPage builds a page whose TotalCount defaults to len(items).
+// An empty nextCursor marks the last page.
+func NewPage[T any](items []T, nextCursor string) *Page[T] {
+	return &Page[T]{
+		Items:      items,
+		TotalCount: len(items),
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	}
+}
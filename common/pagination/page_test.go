@@ -0,0 +1,23 @@
+package pagination
+
+import "testing"
+
+func TestNewPage_LastPage(t *testing.T) {
+	p := NewPage([]string{"a", "b"}, "")
+
+	if p.TotalCount != 2 || p.HasNext || p.NextCursor != "" {
+		t.Fatalf("unexpected last page: %+v", p)
+	}
+}
+
+func TestNewPage_WithNextCursor(t *testing.T) {
+	type item struct{ ID int }
+	p := NewPage([]*item{{ID: 1}}, "next")
+
+	if !p.HasNext || p.NextCursor != "next" {
+		t.Fatalf("expected a next page, got %+v", p)
+	}
+	if p.TotalCount != 1 || p.Items[0].ID != 1 {
+		t.Fatalf("unexpected items: %+v", p)
+	}
+}
@@ -1,6 +1,9 @@
 package common
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Ticker is a clock abstraction that allows injecting a deterministic time source in tests.
 type Ticker interface {
@@ -14,3 +17,26 @@ func (RealTicker) Now() time.Time { return time.Now() }
 
 // NewRealTicker returns a production Ticker backed by time.Now().
 func NewRealTicker() Ticker { return RealTicker{} }
+
+type nowKey struct{}
+
+// WithNow returns a context that pins the current time to t for read paths
+// that resolve it through NowFromContext, e.g. "price at time T" previews.
+func WithNow(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, nowKey{}, t)
+}
+
+// HasNow reports whether ctx carries a time pinned by WithNow.
+func HasNow(ctx context.Context) bool {
+	_, ok := ctx.Value(nowKey{}).(time.Time)
+	return ok
+}
+
+// NowFromContext returns the time pinned by WithNow, falling back to
+// ticker.Now() when the context carries no override.
+func NowFromContext(ctx context.Context, ticker Ticker) time.Time {
+	if t, ok := ctx.Value(nowKey{}).(time.Time); ok {
+		return t
+	}
+	return ticker.Now()
+}
@@ -19,14 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductService_CreateProduct_FullMethodName     = "/product.v1.ProductService/CreateProduct"
-	ProductService_UpdateProduct_FullMethodName     = "/product.v1.ProductService/UpdateProduct"
-	ProductService_ActivateProduct_FullMethodName   = "/product.v1.ProductService/ActivateProduct"
-	ProductService_DeactivateProduct_FullMethodName = "/product.v1.ProductService/DeactivateProduct"
-	ProductService_ApplyDiscount_FullMethodName     = "/product.v1.ProductService/ApplyDiscount"
-	ProductService_RemoveDiscount_FullMethodName    = "/product.v1.ProductService/RemoveDiscount"
-	ProductService_GetProduct_FullMethodName        = "/product.v1.ProductService/GetProduct"
-	ProductService_ListProducts_FullMethodName      = "/product.v1.ProductService/ListProducts"
+	ProductService_CreateProduct_FullMethodName         = "/product.v1.ProductService/CreateProduct"
+	ProductService_UpdateProduct_FullMethodName         = "/product.v1.ProductService/UpdateProduct"
+	ProductService_ActivateProduct_FullMethodName       = "/product.v1.ProductService/ActivateProduct"
+	ProductService_DeactivateProduct_FullMethodName     = "/product.v1.ProductService/DeactivateProduct"
+	ProductService_ApplyDiscount_FullMethodName         = "/product.v1.ProductService/ApplyDiscount"
+	ProductService_RemoveDiscount_FullMethodName        = "/product.v1.ProductService/RemoveDiscount"
+	ProductService_BulkRemoveDiscount_FullMethodName    = "/product.v1.ProductService/BulkRemoveDiscount"
+	ProductService_BulkActivateProducts_FullMethodName  = "/product.v1.ProductService/BulkActivateProducts"
+	ProductService_ApplyDiscountSchedule_FullMethodName = "/product.v1.ProductService/ApplyDiscountSchedule"
+	ProductService_GetProduct_FullMethodName            = "/product.v1.ProductService/GetProduct"
+	ProductService_ListProducts_FullMethodName          = "/product.v1.ProductService/ListProducts"
+	ProductService_ListSubcategories_FullMethodName     = "/product.v1.ProductService/ListSubcategories"
+	ProductService_BatchGetProducts_FullMethodName      = "/product.v1.ProductService/BatchGetProducts"
+	ProductService_CheckProductsExist_FullMethodName    = "/product.v1.ProductService/CheckProductsExist"
+	ProductService_PreviewDiscount_FullMethodName       = "/product.v1.ProductService/PreviewDiscount"
+	ProductService_GetVersion_FullMethodName            = "/product.v1.ProductService/GetVersion"
+	ProductService_GetMetadata_FullMethodName           = "/product.v1.ProductService/GetMetadata"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -40,9 +49,19 @@ type ProductServiceClient interface {
 	DeactivateProduct(ctx context.Context, in *DeactivateProductRequest, opts ...grpc.CallOption) (*DeactivateProductReply, error)
 	ApplyDiscount(ctx context.Context, in *ApplyDiscountRequest, opts ...grpc.CallOption) (*ApplyDiscountReply, error)
 	RemoveDiscount(ctx context.Context, in *RemoveDiscountRequest, opts ...grpc.CallOption) (*RemoveDiscountReply, error)
+	BulkRemoveDiscount(ctx context.Context, in *BulkRemoveDiscountRequest, opts ...grpc.CallOption) (*BulkRemoveDiscountReply, error)
+	BulkActivateProducts(ctx context.Context, in *BulkActivateProductsRequest, opts ...grpc.CallOption) (*BulkActivateProductsReply, error)
+	ApplyDiscountSchedule(ctx context.Context, in *ApplyDiscountScheduleRequest, opts ...grpc.CallOption) (*ApplyDiscountScheduleReply, error)
 	// Queries
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductReply, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsReply, error)
+	ListSubcategories(ctx context.Context, in *ListSubcategoriesRequest, opts ...grpc.CallOption) (*ListSubcategoriesReply, error)
+	BatchGetProducts(ctx context.Context, in *BatchGetProductsRequest, opts ...grpc.CallOption) (*BatchGetProductsReply, error)
+	CheckProductsExist(ctx context.Context, in *CheckProductsExistRequest, opts ...grpc.CallOption) (*CheckProductsExistReply, error)
+	PreviewDiscount(ctx context.Context, in *PreviewDiscountRequest, opts ...grpc.CallOption) (*PreviewDiscountReply, error)
+	// Operations
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error)
+	GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataReply, error)
 }
 
 type productServiceClient struct {
@@ -113,6 +132,36 @@ func (c *productServiceClient) RemoveDiscount(ctx context.Context, in *RemoveDis
 	return out, nil
 }
 
+func (c *productServiceClient) BulkRemoveDiscount(ctx context.Context, in *BulkRemoveDiscountRequest, opts ...grpc.CallOption) (*BulkRemoveDiscountReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkRemoveDiscountReply)
+	err := c.cc.Invoke(ctx, ProductService_BulkRemoveDiscount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) BulkActivateProducts(ctx context.Context, in *BulkActivateProductsRequest, opts ...grpc.CallOption) (*BulkActivateProductsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkActivateProductsReply)
+	err := c.cc.Invoke(ctx, ProductService_BulkActivateProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ApplyDiscountSchedule(ctx context.Context, in *ApplyDiscountScheduleRequest, opts ...grpc.CallOption) (*ApplyDiscountScheduleReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyDiscountScheduleReply)
+	err := c.cc.Invoke(ctx, ProductService_ApplyDiscountSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetProductReply)
@@ -133,6 +182,66 @@ func (c *productServiceClient) ListProducts(ctx context.Context, in *ListProduct
 	return out, nil
 }
 
+func (c *productServiceClient) ListSubcategories(ctx context.Context, in *ListSubcategoriesRequest, opts ...grpc.CallOption) (*ListSubcategoriesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubcategoriesReply)
+	err := c.cc.Invoke(ctx, ProductService_ListSubcategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) BatchGetProducts(ctx context.Context, in *BatchGetProductsRequest, opts ...grpc.CallOption) (*BatchGetProductsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetProductsReply)
+	err := c.cc.Invoke(ctx, ProductService_BatchGetProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) CheckProductsExist(ctx context.Context, in *CheckProductsExistRequest, opts ...grpc.CallOption) (*CheckProductsExistReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckProductsExistReply)
+	err := c.cc.Invoke(ctx, ProductService_CheckProductsExist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) PreviewDiscount(ctx context.Context, in *PreviewDiscountRequest, opts ...grpc.CallOption) (*PreviewDiscountReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewDiscountReply)
+	err := c.cc.Invoke(ctx, ProductService_PreviewDiscount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionReply)
+	err := c.cc.Invoke(ctx, ProductService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMetadataReply)
+	err := c.cc.Invoke(ctx, ProductService_GetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServiceServer is the server API for ProductService service.
 // All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility.
@@ -144,9 +253,19 @@ type ProductServiceServer interface {
 	DeactivateProduct(context.Context, *DeactivateProductRequest) (*DeactivateProductReply, error)
 	ApplyDiscount(context.Context, *ApplyDiscountRequest) (*ApplyDiscountReply, error)
 	RemoveDiscount(context.Context, *RemoveDiscountRequest) (*RemoveDiscountReply, error)
+	BulkRemoveDiscount(context.Context, *BulkRemoveDiscountRequest) (*BulkRemoveDiscountReply, error)
+	BulkActivateProducts(context.Context, *BulkActivateProductsRequest) (*BulkActivateProductsReply, error)
+	ApplyDiscountSchedule(context.Context, *ApplyDiscountScheduleRequest) (*ApplyDiscountScheduleReply, error)
 	// Queries
 	GetProduct(context.Context, *GetProductRequest) (*GetProductReply, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsReply, error)
+	ListSubcategories(context.Context, *ListSubcategoriesRequest) (*ListSubcategoriesReply, error)
+	BatchGetProducts(context.Context, *BatchGetProductsRequest) (*BatchGetProductsReply, error)
+	CheckProductsExist(context.Context, *CheckProductsExistRequest) (*CheckProductsExistReply, error)
+	PreviewDiscount(context.Context, *PreviewDiscountRequest) (*PreviewDiscountReply, error)
+	// Operations
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error)
+	GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataReply, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -175,12 +294,39 @@ func (UnimplementedProductServiceServer) ApplyDiscount(context.Context, *ApplyDi
 func (UnimplementedProductServiceServer) RemoveDiscount(context.Context, *RemoveDiscountRequest) (*RemoveDiscountReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveDiscount not implemented")
 }
+func (UnimplementedProductServiceServer) BulkRemoveDiscount(context.Context, *BulkRemoveDiscountRequest) (*BulkRemoveDiscountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkRemoveDiscount not implemented")
+}
+func (UnimplementedProductServiceServer) BulkActivateProducts(context.Context, *BulkActivateProductsRequest) (*BulkActivateProductsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkActivateProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ApplyDiscountSchedule(context.Context, *ApplyDiscountScheduleRequest) (*ApplyDiscountScheduleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyDiscountSchedule not implemented")
+}
 func (UnimplementedProductServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
 }
 func (UnimplementedProductServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
 }
+func (UnimplementedProductServiceServer) ListSubcategories(context.Context, *ListSubcategoriesRequest) (*ListSubcategoriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubcategories not implemented")
+}
+func (UnimplementedProductServiceServer) BatchGetProducts(context.Context, *BatchGetProductsRequest) (*BatchGetProductsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetProducts not implemented")
+}
+func (UnimplementedProductServiceServer) CheckProductsExist(context.Context, *CheckProductsExistRequest) (*CheckProductsExistReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckProductsExist not implemented")
+}
+func (UnimplementedProductServiceServer) PreviewDiscount(context.Context, *PreviewDiscountRequest) (*PreviewDiscountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewDiscount not implemented")
+}
+func (UnimplementedProductServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedProductServiceServer) GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetadata not implemented")
+}
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
 
@@ -310,6 +456,60 @@ func _ProductService_RemoveDiscount_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_BulkRemoveDiscount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRemoveDiscountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BulkRemoveDiscount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BulkRemoveDiscount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BulkRemoveDiscount(ctx, req.(*BulkRemoveDiscountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_BulkActivateProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkActivateProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BulkActivateProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BulkActivateProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BulkActivateProducts(ctx, req.(*BulkActivateProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ApplyDiscountSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyDiscountScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ApplyDiscountSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ApplyDiscountSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ApplyDiscountSchedule(ctx, req.(*ApplyDiscountScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProductRequest)
 	if err := dec(in); err != nil {
@@ -346,6 +546,114 @@ func _ProductService_ListProducts_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_ListSubcategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubcategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListSubcategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListSubcategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListSubcategories(ctx, req.(*ListSubcategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_BatchGetProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BatchGetProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BatchGetProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BatchGetProducts(ctx, req.(*BatchGetProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_CheckProductsExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckProductsExistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CheckProductsExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CheckProductsExist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CheckProductsExist(ctx, req.(*CheckProductsExistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_PreviewDiscount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewDiscountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).PreviewDiscount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_PreviewDiscount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).PreviewDiscount(ctx, req.(*PreviewDiscountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetMetadata(ctx, req.(*GetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -377,6 +685,18 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveDiscount",
 			Handler:    _ProductService_RemoveDiscount_Handler,
 		},
+		{
+			MethodName: "BulkRemoveDiscount",
+			Handler:    _ProductService_BulkRemoveDiscount_Handler,
+		},
+		{
+			MethodName: "BulkActivateProducts",
+			Handler:    _ProductService_BulkActivateProducts_Handler,
+		},
+		{
+			MethodName: "ApplyDiscountSchedule",
+			Handler:    _ProductService_ApplyDiscountSchedule_Handler,
+		},
 		{
 			MethodName: "GetProduct",
 			Handler:    _ProductService_GetProduct_Handler,
@@ -385,6 +705,30 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProducts",
 			Handler:    _ProductService_ListProducts_Handler,
 		},
+		{
+			MethodName: "ListSubcategories",
+			Handler:    _ProductService_ListSubcategories_Handler,
+		},
+		{
+			MethodName: "BatchGetProducts",
+			Handler:    _ProductService_BatchGetProducts_Handler,
+		},
+		{
+			MethodName: "CheckProductsExist",
+			Handler:    _ProductService_CheckProductsExist_Handler,
+		},
+		{
+			MethodName: "PreviewDiscount",
+			Handler:    _ProductService_PreviewDiscount_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _ProductService_GetVersion_Handler,
+		},
+		{
+			MethodName: "GetMetadata",
+			Handler:    _ProductService_GetMetadata_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "product/v1/product.proto",
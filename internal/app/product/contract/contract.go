@@ -2,6 +2,7 @@ package contract
 
 import (
 	"context"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/product-catalog-service/internal/app/product/domain"
@@ -12,26 +13,126 @@ type ProductRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Product, error)
 	InsertMut(p *domain.Product) *spanner.Mutation
 	UpdateMut(p *domain.Product) *spanner.Mutation
+	// ForceClearDiscountMut nulls the current and previous discount columns of
+	// the product with id without loading it, for rows whose stored discount
+	// cannot be decoded.
+	ForceClearDiscountMut(id string) *spanner.Mutation
+	// ListDiscountedByCategory loads every active product in category that has a discount.
+	ListDiscountedByCategory(ctx context.Context, category string) ([]*domain.Product, error)
+	// ListByCategory loads every product listed in category, as its primary or
+	// an additional category, whatever its status.
+	ListByCategory(ctx context.Context, category string) ([]*domain.Product, error)
+	// SlugsWithPrefix returns the stored slugs equal to base or of the form
+	// base-N, for picking a free slug with domain.UniqueSlug.
+	SlugsWithPrefix(ctx context.Context, base string) ([]string, error)
+	// ListDuePublish loads up to limit inactive, unarchived products whose
+	// publish time is at or before now, earliest first.
+	ListDuePublish(ctx context.Context, now time.Time, limit int) ([]*domain.Product, error)
+	// ListDueUnpublish loads up to limit active, unarchived products whose
+	// unpublish time is at or before now, earliest first.
+	ListDueUnpublish(ctx context.Context, now time.Time, limit int) ([]*domain.Product, error)
 }
 
 // EventRepository is the write-only contract for persisting domain events to the outbox.
 type EventRepository interface {
-	InsertMut(event domain.DomainEvent) *spanner.Mutation
+	// InsertMut fails when the event cannot be serialised; callers must abort
+	// the operation rather than commit it without the event.
+	InsertMut(event domain.DomainEvent) (*spanner.Mutation, error)
 }
 
 // ListProductsFilter holds optional filter parameters for listing products.
 type ListProductsFilter struct {
-	Category *string // nil = no filter
+	Statuses      []domain.ProductStatus // empty = active only
+	Category      *string                // nil = no filter; ignored when Categories is set
+	Categories    []string               // matches any of these categories; empty = use Category
+	CreatedAfter  *time.Time             // inclusive lower bound on created_at; nil = no bound
+	CreatedBefore *time.Time             // exclusive upper bound on created_at; nil = no bound
+	Featured      *bool                  // nil = no filter
+	InStockOnly   bool                   // only products with stock_quantity > 0
+	Attribute     *AttributeMatch        // nil = no filter
+	// OnSaleAt keeps only products whose discount is valid at this instant:
+	// started and not yet ended. nil = no filter. The read model does not
+	// store discount periods, so ReadModelRepository cannot apply it.
+	OnSaleAt *time.Time
 }
 
-// Page holds pagination parameters.
+// AttributeMatch selects products whose attribute Key is set to Value.
+type AttributeMatch struct {
+	Key   string
+	Value string
+}
+
+// Page holds pagination parameters. Limit is always positive; callers apply defaults.
 type Page struct {
 	Limit  int
 	Offset int
 }
 
 // QueryRepository is the read-only contract for product queries.
+// Implementations must not hand out aggregates shared with writers; return
+// freshly loaded products or Product.Snapshot copies.
 type QueryRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Product, error)
+	// GetBySlug returns domain.ErrProductNotFound when no product has slug.
+	GetBySlug(ctx context.Context, slug string) (*domain.Product, error)
 	ListActive(ctx context.Context, filter ListProductsFilter, page Page) ([]*domain.Product, error)
+	// ListActiveSummaries is like ListActive but may omit fields not needed for list
+	// summaries (e.g. description).
+	ListActiveSummaries(ctx context.Context, filter ListProductsFilter, page Page) ([]*domain.Product, error)
+	// ListActiveSummariesByDiscount is like ListActiveSummaries but orders the
+	// products by the discount percentage valid at at, deepest first; products
+	// without a discount valid then come last. Ties keep the featured-first order.
+	ListActiveSummariesByDiscount(ctx context.Context, filter ListProductsFilter, at time.Time, page Page) ([]*domain.Product, error)
+	// CountActive returns the number of products matching filter, ignoring pagination.
+	CountActive(ctx context.Context, filter ListProductsFilter) (int, error)
+	// GetByIDs loads the products among ids that exist, in any status and no
+	// particular order. Missing ids are skipped rather than reported.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Product, error)
+	// ExistsBatch reports, for every distinct id, whether a product with that
+	// id exists in any status.
+	ExistsBatch(ctx context.Context, ids []string) (map[string]bool, error)
+}
+
+// ProductListing is a denormalised listing row whose prices were computed when
+// it was projected.
+type ProductListing struct {
+	ProductID            string
+	Name                 string
+	Category             string
+	AdditionalCategories []string // listed besides Category
+	Status               domain.ProductStatus
+	Featured             bool
+	StockQuantity        int64
+	Attributes           map[string]string // kept so attribute filters apply to listings
+	CreatedAt            *time.Time        // nil until the product.created event is projected
+	BasePrice            *domain.Money
+	EffectivePrice       *domain.Money
+	IsDiscounted         bool
+	DiscountEndsAt       *time.Time // nil when not discounted
+	// NextPriceChangeAt is when the discount next starts or ends; nil when the
+	// effective price cannot change without a write.
+	NextPriceChangeAt *time.Time
+}
+
+// ReadModelRepository is the contract for the projected listing table.
+type ReadModelRepository interface {
+	// ListListings is the read-model counterpart of QueryRepository.ListActiveSummaries,
+	// with the same filtering and ordering.
+	ListListings(ctx context.Context, filter ListProductsFilter, page Page) ([]*ProductListing, error)
+	// Upsert writes l. A nil CreatedAt leaves the stored value untouched.
+	Upsert(ctx context.Context, l *ProductListing) error
+	// ListDue returns up to limit product IDs whose NextPriceChangeAt is at or before now.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]string, error)
+}
+
+// CategoryRepository is the read-only contract for the category taxonomy.
+type CategoryRepository interface {
+	ListAll(ctx context.Context) ([]*domain.Category, error)
+}
+
+// ExchangeRateProvider supplies currency conversion rates for read paths.
+type ExchangeRateProvider interface {
+	// Rate returns the price of one major unit of from in major units of to,
+	// or domain.ErrUnsupportedCurrency when the pair has no rate.
+	Rate(ctx context.Context, from, to string) (float64, error)
 }
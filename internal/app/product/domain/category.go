@@ -0,0 +1,88 @@
+package domain
+
+// Category is a node in the product category taxonomy, e.g. "laptops" under
+// "electronics". Products reference categories by ID through their category string.
+type Category struct {
+	id       string
+	name     string
+	parentID string // "" for a root category
+}
+
+// NewCategory creates and validates a Category. parentID may be empty for a root.
+func NewCategory(id, name, parentID string) (*Category, error) {
+	if id == "" {
+		return nil, ErrCategoryIDRequired
+	}
+	if name == "" {
+		return nil, ErrCategoryNameRequired
+	}
+	if parentID == id {
+		return nil, ErrCategoryCycle
+	}
+	return &Category{id: id, name: name, parentID: parentID}, nil
+}
+
+func (c *Category) ID() string       { return c.id }
+func (c *Category) Name() string     { return c.name }
+func (c *Category) ParentID() string { return c.parentID }
+func (c *Category) IsRoot() bool     { return c.parentID == "" }
+
+// Taxonomy is an in-memory view of the whole category tree used to resolve subtrees.
+type Taxonomy struct {
+	byID     map[string]*Category
+	children map[string][]*Category
+}
+
+// NewTaxonomy indexes categories by ID and parent.
+func NewTaxonomy(categories []*Category) *Taxonomy {
+	t := &Taxonomy{
+		byID:     make(map[string]*Category, len(categories)),
+		children: make(map[string][]*Category),
+	}
+	for _, c := range categories {
+		t.byID[c.id] = c
+		if !c.IsRoot() {
+			t.children[c.parentID] = append(t.children[c.parentID], c)
+		}
+	}
+	return t
+}
+
+// Descendants returns every category below id, breadth-first, excluding id itself.
+// Returns ErrCategoryNotFound when id is not part of the taxonomy.
+func (t *Taxonomy) Descendants(id string) ([]*Category, error) {
+	if _, ok := t.byID[id]; !ok {
+		return nil, ErrCategoryNotFound
+	}
+
+	var out []*Category
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, c := range t.children[parent] {
+			if seen[c.id] { // guard against cycles in stored data
+				continue
+			}
+			seen[c.id] = true
+			out = append(out, c)
+			queue = append(queue, c.id)
+		}
+	}
+	return out, nil
+}
+
+// Subtree returns id followed by the IDs of all its descendants.
+func (t *Taxonomy) Subtree(id string) ([]string, error) {
+	descendants, err := t.Descendants(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(descendants)+1)
+	ids = append(ids, id)
+	for _, c := range descendants {
+		ids = append(ids, c.id)
+	}
+	return ids, nil
+}
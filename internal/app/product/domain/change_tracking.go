@@ -1,6 +1,9 @@
 package domain
 
-import "sync"
+import (
+	"slices"
+	"sync"
+)
 
 type Field string
 
@@ -31,3 +34,15 @@ func (c *Changes) Clear() {
 	defer c.mu.Unlock()
 	clear(c.dirty)
 }
+
+// Fields returns the dirty fields in sorted order.
+func (c *Changes) Fields() []Field {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	fields := make([]Field, 0, len(c.dirty))
+	for f := range c.dirty {
+		fields = append(fields, f)
+	}
+	slices.Sort(fields)
+	return fields
+}
@@ -1,43 +1,145 @@
 package domain
 
 import (
+	"math"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// DiscountState describes where a discount sits relative to its validity window.
+type DiscountState string
+
+const (
+	DiscountStateActive   DiscountState = "active"
+	DiscountStateUpcoming DiscountState = "upcoming"
+	DiscountStateExpired  DiscountState = "expired"
+)
+
+// DiscountStates lists every discount state; ParseDiscountState accepts
+// exactly these and GetMetadata publishes them.
+var DiscountStates = []DiscountState{DiscountStateActive, DiscountStateUpcoming, DiscountStateExpired}
+
+// ParseDiscountState validates s against the known discount states.
+func ParseDiscountState(s string) (DiscountState, error) {
+	if state := DiscountState(s); slices.Contains(DiscountStates, state) {
+		return state, nil
+	}
+	return "", ErrInvalidDiscountState
+}
+
 // Discount is a value object belonging to the Product aggregate.
 // It has no identity of its own — it exists only in the context of a Product.
 type Discount struct {
-	percentage string // stored as string to preserve exact decimal representation
-	startsAt   time.Time
-	endsAt     time.Time
+	percentage    string // canonical decimal form, see canonicalPercentage
+	startsAt      time.Time
+	endsAt        time.Time
+	maxBackdating time.Duration // see WithMaxBackdating; not persisted
+	skewTolerance time.Duration // see WithSkewTolerance; not persisted
 }
 
 // NewDiscount creates and validates a new Discount.
+// The percentage is stored in canonical form and both dates are normalized to
+// UTC so stored values round-trip unchanged.
 func NewDiscount(percentage string, startsAt, endsAt time.Time) (*Discount, error) {
-	pct, err := strconv.ParseFloat(percentage, 64)
-	if err != nil || pct < 0 || pct > 100 {
-		return nil, ErrDiscountInvalidPercentage
+	pct, err := parsePercentage(percentage)
+	if err != nil {
+		return nil, err
 	}
-	if !endsAt.After(startsAt) {
-		return nil, ErrDiscountInvalidPeriod
+	if err := validatePeriod(startsAt, endsAt); err != nil {
+		return nil, err
 	}
 	return &Discount{
-		percentage: percentage,
-		startsAt:   startsAt,
-		endsAt:     endsAt,
+		percentage: canonicalPercentage(pct),
+		startsAt:   startsAt.UTC(),
+		endsAt:     endsAt.UTC(),
 	}, nil
 }
 
+// parsePercentage parses a discount percentage between 0 and 100.
+func parsePercentage(percentage string) (float64, error) {
+	pct, err := strconv.ParseFloat(percentage, 64)
+	// ParseFloat accepts "NaN" and "Inf"; NaN fails every comparison, so it
+	// must be rejected explicitly.
+	if err != nil || math.IsNaN(pct) || math.IsInf(pct, 0) || pct < 0 || pct > 100 {
+		return 0, ErrDiscountInvalidPercentage
+	}
+	return pct, nil
+}
+
+// validatePeriod checks that a discount window ends after it starts.
+func validatePeriod(startsAt, endsAt time.Time) error {
+	if !endsAt.After(startsAt) {
+		return ErrDiscountInvalidPeriod
+	}
+	return nil
+}
+
+// canonicalPercentage renders pct with trailing zeros trimmed but at least one
+// decimal place: "10.00" → "10.0", "10.50" → "10.5", "33.33" → "33.33".
+// Storage keeps a NUMERIC, so this is the only form the domain ever exposes.
+func canonicalPercentage(pct float64) string {
+	s := strconv.FormatFloat(pct, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// NewDiscountForDuration creates a Discount that starts at startsAt and lasts for d.
+func NewDiscountForDuration(percentage string, startsAt time.Time, d time.Duration) (*Discount, error) {
+	if d <= 0 {
+		return nil, ErrDiscountInvalidDuration
+	}
+	return NewDiscount(percentage, startsAt, startsAt.Add(d))
+}
+
 // Accessors
 
 func (d *Discount) Percentage() string  { return d.percentage }
 func (d *Discount) StartsAt() time.Time { return d.startsAt }
 func (d *Discount) EndsAt() time.Time   { return d.endsAt }
 
-// IsValidAt returns true when now falls within [startsAt, endsAt).
+// WithMaxBackdating returns a copy of d that ApplyDiscount refuses with
+// ErrDiscountBackdatedTooFar when it starts more than window before now: a
+// start years in the past is almost always a mistake even if the end is still
+// ahead. Zero, the default, allows any start, e.g. for data migrations.
+func (d *Discount) WithMaxBackdating(window time.Duration) *Discount {
+	c := *d
+	c.maxBackdating = window
+	return &c
+}
+
+// IsBackdatedTooFar reports whether d starts more than its max backdating
+// window before now. It is always false when no window is set.
+func (d *Discount) IsBackdatedTooFar(now time.Time) bool {
+	return d.maxBackdating > 0 && d.startsAt.Before(now.Add(-d.maxBackdating))
+}
+
+// WithSkewTolerance returns a copy of d whose IsValidAt treats a start up to
+// tolerance after now as already reached, so a discount a client asks to start
+// "now" is not refused because the client's clock runs slightly ahead of the
+// server's. The end of the window is unaffected.
+func (d *Discount) WithSkewTolerance(tolerance time.Duration) *Discount {
+	c := *d
+	c.skewTolerance = tolerance
+	return &c
+}
+
+// IsValidAt returns true when now falls within [startsAt, endsAt), with the
+// start moved back by the skew tolerance, zero unless set by WithSkewTolerance.
 func (d *Discount) IsValidAt(now time.Time) bool {
-	return !now.Before(d.startsAt) && now.Before(d.endsAt)
+	return !now.Add(d.skewTolerance).Before(d.startsAt) && now.Before(d.endsAt)
+}
+
+// Overlaps reports whether the [startsAt, endsAt) periods of d and other intersect.
+// Adjacent periods, where one ends exactly when the other starts, do not overlap.
+func (d *Discount) Overlaps(other *Discount) bool {
+	if other == nil {
+		return false
+	}
+	return d.startsAt.Before(other.endsAt) && other.startsAt.Before(d.endsAt)
 }
 
 // IsExpired returns true when now is at or after endsAt.
@@ -50,6 +152,34 @@ func (d *Discount) IsUpcoming(now time.Time) bool {
 	return now.Before(d.startsAt)
 }
 
+// StateAt classifies the discount as upcoming, active or expired at now.
+func (d *Discount) StateAt(now time.Time) DiscountState {
+	switch {
+	case d.IsUpcoming(now):
+		return DiscountStateUpcoming
+	case d.IsExpired(now):
+		return DiscountStateExpired
+	default:
+		return DiscountStateActive
+	}
+}
+
+// Progress returns how far now is through the discount window: 0 before it
+// starts, 1 once it has ended, and the linear fraction in between.
+func (d *Discount) Progress(now time.Time) float64 {
+	switch {
+	case now.Before(d.startsAt):
+		return 0
+	case !now.Before(d.endsAt):
+		return 1
+	}
+	window := d.endsAt.Sub(d.startsAt)
+	if window <= 0 {
+		return 1
+	}
+	return float64(now.Sub(d.startsAt)) / float64(window)
+}
+
 // PercentageFloat64 returns the parsed percentage as float64.
 func (d *Discount) PercentageFloat64() float64 {
 	pct, _ := strconv.ParseFloat(d.percentage, 64)
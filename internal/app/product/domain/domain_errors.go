@@ -6,25 +6,69 @@ import "errors"
 var (
 	ErrDiscountInvalidPercentage = errors.New("discount percentage must be between 0 and 100")
 	ErrDiscountInvalidPeriod     = errors.New("discount end date must be after start date")
+	ErrDiscountInvalidDuration   = errors.New("discount duration must be positive")
 
 	// Product errors
 	ErrProductNotActive         = errors.New("product is not active")
 	ErrProductNotFound          = errors.New("product not found")
+	ErrProductArchived          = errors.New("product has been archived")
 	ErrProductIDRequired        = errors.New("product id is required")
 	ErrProductNameRequired      = errors.New("product name is required")
+	ErrProductCategoryRequired  = errors.New("product category is required")
 	ErrProductBasePriceRequired = errors.New("product base price is required")
+	ErrProductFieldTooLong      = errors.New("value exceeds the maximum length")
+	ErrNoFieldsToUpdate         = errors.New("update does not set any field")
+	ErrInvalidMediaURL          = errors.New("media URL must be an absolute http or https URL")
+	ErrTooManyMediaURLs         = errors.New("too many media URLs")
+	ErrSlugConflict             = errors.New("product slug is already taken")
+	ErrNegativeStock            = errors.New("stock quantity cannot be negative")
+	ErrStockOverflow            = errors.New("stock quantity is too large")
+	ErrInvalidAttributeKey      = errors.New("attribute key must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidAttributeValue    = errors.New("attribute value must not be empty or too long")
+	ErrTooManyAttributes        = errors.New("too many attributes")
+	ErrProductAlreadyActive     = errors.New("product is already active")
+	ErrPublishAtNotInFuture     = errors.New("publish time must be in the future")
+	ErrUnpublishBeforePublish   = errors.New("unpublish time must be after the publish time")
+	ErrCurrencyChangeNotAllowed = errors.New("base price currency cannot be changed")
+
+	// Category errors
+	ErrCategoryIDRequired     = errors.New("category id is required")
+	ErrCategoryNameRequired   = errors.New("category name is required")
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryCycle          = errors.New("category cannot be its own ancestor")
+	ErrDuplicateCategory      = errors.New("product is already listed in this category")
+	ErrTooManyCategories      = errors.New("product is listed in too many categories")
+	ErrPrimaryCategoryRemoval = errors.New("primary category cannot be removed; change it instead")
 
 	// Discount errors
-	ErrInvalidDiscountPeriod = errors.New("invalid discount period")
-	ErrNoActiveDiscount      = errors.New("product has no active discount")
+	ErrInvalidDiscountPeriod    = errors.New("invalid discount period")
+	ErrNoActiveDiscount         = errors.New("product has no active discount")
+	ErrDiscountOverlap          = errors.New("discount period overlaps the existing discount")
+	ErrScheduledDiscountPending = errors.New("product has a scheduled discount that has not started yet")
+	ErrDiscountBackdatedTooFar  = errors.New("discount start is too far in the past")
+	ErrInvalidQuantityTier      = errors.New("quantity tier minimum quantity must be at least 1")
+	ErrDuplicateQuantityTier    = errors.New("quantity tiers must have distinct minimum quantities")
+	ErrTooManyQuantityTiers     = errors.New("too many quantity tiers")
+	ErrInvalidQuantity          = errors.New("quantity must be at least 1")
 
 	// General validation errors
-	ErrInvalidStatus = errors.New("invalid product status")
+	ErrInvalidStatus        = errors.New("invalid product status")
+	ErrInvalidDiscountState = errors.New("invalid discount state")
+	ErrDuplicateIDInBatch   = errors.New("id appears more than once in the batch")
+	ErrInvalidCursor        = errors.New("invalid pagination cursor")
+	ErrInvalidPagination    = errors.New("limit and offset must not be negative")
+	ErrInvalidSortOrder     = errors.New("invalid sort order")
+	ErrIncompleteAttribute  = errors.New("attribute key and value must be given together")
 
 	// Money errors
 	ErrNegativeAmount        = errors.New("money amount cannot be negative")
 	ErrCurrencyMismatch      = errors.New("currency mismatch")
 	ErrInvalidCurrency       = errors.New("invalid currency code")
+	ErrUnsupportedCurrency   = errors.New("no exchange rate for currency")
+	ErrInvalidDecimalAmount  = errors.New("amount must be a plain decimal such as 19.99")
+	ErrAmountTooPrecise      = errors.New("amount has more decimal places than the currency allows")
+	ErrAmountOverflow        = errors.New("money amount is too large")
 	ErrDivisionByZero        = errors.New("division by zero")
 	ErrInvalidDiscountAmount = errors.New("discount amount must be between 0 and 100")
+	ErrInvalidRoundingMode   = errors.New("invalid rounding mode")
 )
@@ -6,36 +6,52 @@ import "time"
 type DomainEvent interface {
 	EventName() string
 	OccurredAt() time.Time
+	// Sequence numbers the event within the operation that raised it on its
+	// aggregate, starting at 1, so consumers can order events committed together.
+	Sequence() int64
 }
 
+// eventSequence is embedded in every event to carry its Sequence.
+type eventSequence struct {
+	seq int64
+}
+
+func (s *eventSequence) Sequence() int64     { return s.seq }
+func (s *eventSequence) setSequence(n int64) { s.seq = n }
+
 // ────────────────────────────────────────────────────────────────────────────
 // Product events
 // ────────────────────────────────────────────────────────────────────────────
 
 // ProductCreatedEvent is raised when a new product is created.
+// It carries the full initial state so projections can build a row without re-fetching.
 type ProductCreatedEvent struct {
-	productID string
-	name      string
-	category  string
-	basePrice *Money
-	status    ProductStatus
-	at        time.Time
+	eventSequence
+	productID   string
+	name        string
+	description string
+	category    string
+	basePrice   *Money
+	status      ProductStatus
+	at          time.Time
 }
 
-func NewProductCreatedEvent(productID, name, category string, basePrice *Money, status ProductStatus, at time.Time) *ProductCreatedEvent {
-	return &ProductCreatedEvent{productID: productID, name: name, category: category, basePrice: basePrice, status: status, at: at}
+func NewProductCreatedEvent(productID, name, description, category string, basePrice *Money, status ProductStatus, at time.Time) *ProductCreatedEvent {
+	return &ProductCreatedEvent{productID: productID, name: name, description: description, category: category, basePrice: basePrice, status: status, at: at}
 }
 
 func (e *ProductCreatedEvent) EventName() string     { return "product.created" }
 func (e *ProductCreatedEvent) OccurredAt() time.Time { return e.at }
 func (e *ProductCreatedEvent) ProductID() string     { return e.productID }
 func (e *ProductCreatedEvent) Name() string          { return e.name }
+func (e *ProductCreatedEvent) Description() string   { return e.description }
 func (e *ProductCreatedEvent) Category() string      { return e.category }
 func (e *ProductCreatedEvent) BasePrice() *Money     { return e.basePrice }
 func (e *ProductCreatedEvent) Status() ProductStatus { return e.status }
 
 // ProductUpdatedEvent is raised when mutable fields of a product change.
 type ProductUpdatedEvent struct {
+	eventSequence
 	productID     string
 	changedFields []Field
 	at            time.Time
@@ -52,6 +68,7 @@ func (e *ProductUpdatedEvent) ChangedFields() []Field { return e.changedFields }
 
 // ProductActivatedEvent is raised when a product transitions to active status.
 type ProductActivatedEvent struct {
+	eventSequence
 	productID string
 	at        time.Time
 }
@@ -64,19 +81,257 @@ func (e *ProductActivatedEvent) EventName() string     { return "product.activat
 func (e *ProductActivatedEvent) OccurredAt() time.Time { return e.at }
 func (e *ProductActivatedEvent) ProductID() string     { return e.productID }
 
+// DeactivationReason says why a product was deactivated.
+type DeactivationReason string
+
+const (
+	DeactivationReasonManual    DeactivationReason = "manual"    // deactivated on request
+	DeactivationReasonScheduled DeactivationReason = "scheduled" // taken down at its unpublish time
+)
+
 // ProductDeactivatedEvent is raised when a product transitions to inactive status.
 type ProductDeactivatedEvent struct {
+	eventSequence
+	productID string
+	reason    DeactivationReason
+	at        time.Time
+}
+
+func NewProductDeactivatedEvent(productID string, reason DeactivationReason, at time.Time) *ProductDeactivatedEvent {
+	return &ProductDeactivatedEvent{productID: productID, reason: reason, at: at}
+}
+
+func (e *ProductDeactivatedEvent) EventName() string          { return "product.deactivated" }
+func (e *ProductDeactivatedEvent) OccurredAt() time.Time      { return e.at }
+func (e *ProductDeactivatedEvent) ProductID() string          { return e.productID }
+func (e *ProductDeactivatedEvent) Reason() DeactivationReason { return e.reason }
+
+// ProductRestoredEvent is raised when an archived product is restored.
+type ProductRestoredEvent struct {
+	eventSequence
+	productID string
+	at        time.Time
+}
+
+func NewProductRestoredEvent(productID string, at time.Time) *ProductRestoredEvent {
+	return &ProductRestoredEvent{productID: productID, at: at}
+}
+
+func (e *ProductRestoredEvent) EventName() string     { return "product.restored" }
+func (e *ProductRestoredEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductRestoredEvent) ProductID() string     { return e.productID }
+
+// ProductFeaturedChangedEvent is raised when a product is marked or unmarked as featured.
+type ProductFeaturedChangedEvent struct {
+	eventSequence
+	productID string
+	featured  bool
+	at        time.Time
+}
+
+func NewProductFeaturedChangedEvent(productID string, featured bool, at time.Time) *ProductFeaturedChangedEvent {
+	return &ProductFeaturedChangedEvent{productID: productID, featured: featured, at: at}
+}
+
+func (e *ProductFeaturedChangedEvent) EventName() string     { return "product.featured_changed" }
+func (e *ProductFeaturedChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductFeaturedChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductFeaturedChangedEvent) Featured() bool        { return e.featured }
+
+// ProductStockChangedEvent is raised when a product's stock quantity changes.
+// It carries the new quantity.
+type ProductStockChangedEvent struct {
+	eventSequence
+	productID string
+	quantity  int64
+	at        time.Time
+}
+
+func NewProductStockChangedEvent(productID string, quantity int64, at time.Time) *ProductStockChangedEvent {
+	return &ProductStockChangedEvent{productID: productID, quantity: quantity, at: at}
+}
+
+func (e *ProductStockChangedEvent) EventName() string     { return "product.stock_changed" }
+func (e *ProductStockChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductStockChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductStockChangedEvent) Quantity() int64       { return e.quantity }
+
+// ProductCategoriesChangedEvent is raised when a product is listed in or
+// unlisted from an additional category. It carries every category the product
+// is now listed in, primary first.
+type ProductCategoriesChangedEvent struct {
+	eventSequence
+	productID  string
+	categories []string
+	at         time.Time
+}
+
+func NewProductCategoriesChangedEvent(productID string, categories []string, at time.Time) *ProductCategoriesChangedEvent {
+	return &ProductCategoriesChangedEvent{productID: productID, categories: categories, at: at}
+}
+
+func (e *ProductCategoriesChangedEvent) EventName() string     { return "product.categories_changed" }
+func (e *ProductCategoriesChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductCategoriesChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductCategoriesChangedEvent) Categories() []string  { return e.categories }
+
+// ProductAttributesChangedEvent is raised when a product attribute is set or
+// removed. It carries the full new set of attributes.
+type ProductAttributesChangedEvent struct {
+	eventSequence
+	productID  string
+	attributes map[string]string
+	at         time.Time
+}
+
+func NewProductAttributesChangedEvent(productID string, attributes map[string]string, at time.Time) *ProductAttributesChangedEvent {
+	return &ProductAttributesChangedEvent{productID: productID, attributes: attributes, at: at}
+}
+
+func (e *ProductAttributesChangedEvent) EventName() string             { return "product.attributes_changed" }
+func (e *ProductAttributesChangedEvent) OccurredAt() time.Time         { return e.at }
+func (e *ProductAttributesChangedEvent) ProductID() string             { return e.productID }
+func (e *ProductAttributesChangedEvent) Attributes() map[string]string { return e.attributes }
+
+// ProductScheduledEvent is raised when a product's launch or takedown is
+// scheduled, rescheduled or cancelled. It carries the full new schedule;
+// PublishAt and UnpublishAt are nil when unscheduled.
+type ProductScheduledEvent struct {
+	eventSequence
+	productID   string
+	publishAt   *time.Time
+	unpublishAt *time.Time
+	at          time.Time
+}
+
+func NewProductScheduledEvent(productID string, publishAt, unpublishAt *time.Time, at time.Time) *ProductScheduledEvent {
+	return &ProductScheduledEvent{productID: productID, publishAt: publishAt, unpublishAt: unpublishAt, at: at}
+}
+
+func (e *ProductScheduledEvent) EventName() string       { return "product.scheduled" }
+func (e *ProductScheduledEvent) OccurredAt() time.Time   { return e.at }
+func (e *ProductScheduledEvent) ProductID() string       { return e.productID }
+func (e *ProductScheduledEvent) PublishAt() *time.Time   { return e.publishAt }
+func (e *ProductScheduledEvent) UnpublishAt() *time.Time { return e.unpublishAt }
+
+// ProductQuantityTiersChangedEvent is raised when a product's volume
+// discounts are replaced. It carries the full new list.
+type ProductQuantityTiersChangedEvent struct {
+	eventSequence
+	productID string
+	tiers     []QuantityTier
+	at        time.Time
+}
+
+func NewProductQuantityTiersChangedEvent(productID string, tiers []QuantityTier, at time.Time) *ProductQuantityTiersChangedEvent {
+	return &ProductQuantityTiersChangedEvent{productID: productID, tiers: tiers, at: at}
+}
+
+func (e *ProductQuantityTiersChangedEvent) EventName() string {
+	return "product.quantity_tiers_changed"
+}
+func (e *ProductQuantityTiersChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductQuantityTiersChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductQuantityTiersChangedEvent) Tiers() []QuantityTier { return e.tiers }
+
+// ProductCategoryChangedEvent is raised when a category rename moves a product
+// to the new category, or for a category change when an update records
+// field-level events.
+type ProductCategoryChangedEvent struct {
+	eventSequence
+	productID string
+	from      string
+	to        string
+	at        time.Time
+}
+
+func NewProductCategoryChangedEvent(productID, from, to string, at time.Time) *ProductCategoryChangedEvent {
+	return &ProductCategoryChangedEvent{productID: productID, from: from, to: to, at: at}
+}
+
+func (e *ProductCategoryChangedEvent) EventName() string     { return "product.category_changed" }
+func (e *ProductCategoryChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductCategoryChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductCategoryChangedEvent) From() string          { return e.from }
+func (e *ProductCategoryChangedEvent) To() string            { return e.to }
+
+// ProductNameChangedEvent is raised instead of ProductUpdatedEvent for a
+// rename when an update records field-level events.
+type ProductNameChangedEvent struct {
+	eventSequence
+	productID string
+	from      string
+	to        string
+	at        time.Time
+}
+
+func NewProductNameChangedEvent(productID, from, to string, at time.Time) *ProductNameChangedEvent {
+	return &ProductNameChangedEvent{productID: productID, from: from, to: to, at: at}
+}
+
+func (e *ProductNameChangedEvent) EventName() string     { return "product.name_changed" }
+func (e *ProductNameChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductNameChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductNameChangedEvent) From() string          { return e.from }
+func (e *ProductNameChangedEvent) To() string            { return e.to }
+
+// ProductDescriptionChangedEvent is the description counterpart of
+// ProductNameChangedEvent.
+type ProductDescriptionChangedEvent struct {
+	eventSequence
+	productID string
+	from      string
+	to        string
+	at        time.Time
+}
+
+func NewProductDescriptionChangedEvent(productID, from, to string, at time.Time) *ProductDescriptionChangedEvent {
+	return &ProductDescriptionChangedEvent{productID: productID, from: from, to: to, at: at}
+}
+
+func (e *ProductDescriptionChangedEvent) EventName() string     { return "product.description_changed" }
+func (e *ProductDescriptionChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductDescriptionChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductDescriptionChangedEvent) From() string          { return e.from }
+func (e *ProductDescriptionChangedEvent) To() string            { return e.to }
+
+// ProductPriceChangedEvent is the base price counterpart of
+// ProductNameChangedEvent.
+type ProductPriceChangedEvent struct {
+	eventSequence
+	productID string
+	from      *Money
+	to        *Money
+	at        time.Time
+}
+
+func NewProductPriceChangedEvent(productID string, from, to *Money, at time.Time) *ProductPriceChangedEvent {
+	return &ProductPriceChangedEvent{productID: productID, from: from, to: to, at: at}
+}
+
+func (e *ProductPriceChangedEvent) EventName() string     { return "product.price_changed" }
+func (e *ProductPriceChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductPriceChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductPriceChangedEvent) From() *Money          { return e.from }
+func (e *ProductPriceChangedEvent) To() *Money            { return e.to }
+
+// ProductMediaChangedEvent is raised when a product's media URLs are replaced.
+// It carries the full new list, in display order.
+type ProductMediaChangedEvent struct {
+	eventSequence
 	productID string
+	mediaURLs []string
 	at        time.Time
 }
 
-func NewProductDeactivatedEvent(productID string, at time.Time) *ProductDeactivatedEvent {
-	return &ProductDeactivatedEvent{productID: productID, at: at}
+func NewProductMediaChangedEvent(productID string, mediaURLs []string, at time.Time) *ProductMediaChangedEvent {
+	return &ProductMediaChangedEvent{productID: productID, mediaURLs: mediaURLs, at: at}
 }
 
-func (e *ProductDeactivatedEvent) EventName() string     { return "product.deactivated" }
-func (e *ProductDeactivatedEvent) OccurredAt() time.Time { return e.at }
-func (e *ProductDeactivatedEvent) ProductID() string     { return e.productID }
+func (e *ProductMediaChangedEvent) EventName() string     { return "product.media_changed" }
+func (e *ProductMediaChangedEvent) OccurredAt() time.Time { return e.at }
+func (e *ProductMediaChangedEvent) ProductID() string     { return e.productID }
+func (e *ProductMediaChangedEvent) MediaURLs() []string   { return e.mediaURLs }
 
 // ────────────────────────────────────────────────────────────────────────────
 // Discount events
@@ -84,6 +339,7 @@ func (e *ProductDeactivatedEvent) ProductID() string     { return e.productID }
 
 // DiscountAppliedEvent is raised when a discount is successfully applied to a product.
 type DiscountAppliedEvent struct {
+	eventSequence
 	productID  string
 	percentage string
 	startsAt   time.Time
@@ -104,6 +360,7 @@ func (e *DiscountAppliedEvent) EndsAt() time.Time     { return e.endsAt }
 
 // DiscountRemovedEvent is raised when an active discount is removed from a product.
 type DiscountRemovedEvent struct {
+	eventSequence
 	productID string
 	at        time.Time
 }
@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 // Money is an immutable value object representing a monetary amount.
@@ -24,6 +27,59 @@ func NewMoney(amount int64, currency string) (*Money, error) {
 	return &Money{amount: amount, currency: currency}, nil
 }
 
+// minorUnitScale lists currencies whose minor unit is not 1/100 of the major unit.
+var minorUnitScale = map[string]int{
+	"VND": 0, "JPY": 0, "KRW": 0, "CLP": 0, "ISK": 0,
+	"BHD": 3, "JOD": 3, "KWD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyScale returns the number of decimal places of currency's minor unit (2 unless listed).
+func CurrencyScale(currency string) int {
+	if scale, ok := minorUnitScale[currency]; ok {
+		return scale
+	}
+	return 2
+}
+
+// NewMoneyFromDecimalString parses a human decimal such as "19.99" into minor
+// units using the currency's scale. Inputs with more decimals than the
+// currency allows (e.g. "19.999" USD, "10.5" VND) return ErrAmountTooPrecise.
+func NewMoneyFromDecimalString(s, currency string) (*Money, error) {
+	if len(currency) != 3 {
+		return nil, ErrInvalidCurrency
+	}
+	if strings.HasPrefix(s, "-") {
+		return nil, ErrNegativeAmount
+	}
+
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if !isDigits(whole) || (hasPoint && !isDigits(frac)) {
+		return nil, ErrInvalidDecimalAmount
+	}
+	scale := CurrencyScale(currency)
+	if len(frac) > scale {
+		return nil, ErrAmountTooPrecise
+	}
+
+	amount, err := strconv.ParseInt(whole+frac+strings.Repeat("0", scale-len(frac)), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidDecimalAmount
+	}
+	return NewMoney(amount, currency)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // MustNewMoney is like NewMoney but panics on error. Useful in tests / constants.
 func MustNewMoney(amount int64, currency string) *Money {
 	m, err := NewMoney(amount, currency)
@@ -45,9 +101,44 @@ func (m *Money) Currency() string {
 
 // String returns a human-readable representation, e.g. "10.00 USD".
 func (m *Money) String() string {
-	major := m.amount / 100
-	minor := m.amount % 100
-	return fmt.Sprintf("%d.%02d %s", major, minor, m.currency)
+	return m.Decimal() + " " + m.currency
+}
+
+// Decimal returns the amount in major units with the currency's number of
+// decimal places, e.g. "10.00" for 1000 USD or "1000" for 1000 VND.
+func (m *Money) Decimal() string {
+	scale := CurrencyScale(m.currency)
+	if scale == 0 {
+		return strconv.FormatInt(m.amount, 10)
+	}
+	unit := int64(math.Pow10(scale))
+	return fmt.Sprintf("%d.%0*d", m.amount/unit, scale, m.amount%unit)
+}
+
+// moneyJSON is the wire shape of Money: {"amount": 1000, "currency": "USD"}.
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes m as {"amount", "currency"}.
+func (m *Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency})
+}
+
+// UnmarshalJSON decodes {"amount", "currency"} into m, applying the same
+// validation as NewMoney.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = *parsed
+	return nil
 }
 
 // Equals returns true when both amount and currency are equal.
@@ -63,6 +154,10 @@ func (m *Money) Add(other *Money) (*Money, error) {
 	if err := m.sameCurrency(other); err != nil {
 		return nil, err
 	}
+	// Amounts are never negative, so only the upper bound can be exceeded.
+	if other.amount > math.MaxInt64-m.amount {
+		return nil, ErrAmountOverflow
+	}
 	return &Money{amount: m.amount + other.amount, currency: m.currency}, nil
 }
 
@@ -84,18 +179,53 @@ func (m *Money) Multiply(factor float64) (*Money, error) {
 	if factor < 0 {
 		return nil, ErrNegativeAmount
 	}
-	result := int64(math.Round(float64(m.amount) * factor))
-	return &Money{amount: result, currency: m.currency}, nil
+	result := math.Round(float64(m.amount) * factor)
+	// float64(math.MaxInt64) rounds up to 2^63, the first value that no longer
+	// fits; the negated comparison also rejects NaN and +Inf.
+	if !(result < math.MaxInt64) {
+		return nil, ErrAmountOverflow
+	}
+	return &Money{amount: int64(result), currency: m.currency}, nil
+}
+
+// Convert returns m expressed in currency, where rate is the price of one
+// major unit of m's currency in major units of currency (e.g. 25000 for USD
+// to VND). The result is rounded to the target currency's minor unit.
+func (m *Money) Convert(currency string, rate float64) (*Money, error) {
+	if len(currency) != 3 {
+		return nil, ErrInvalidCurrency
+	}
+	converted, err := m.Multiply(ConversionFactor(m.currency, currency, rate))
+	if err != nil {
+		return nil, err
+	}
+	converted.currency = currency
+	return converted, nil
+}
+
+// ConversionFactor is the multiplier Convert applies to an amount in from's
+// minor units to express it in to's minor units, before rounding.
+func ConversionFactor(from, to string, rate float64) float64 {
+	return rate * math.Pow10(CurrencyScale(to)-CurrencyScale(from))
 }
 
 // ApplyPercentageDiscount returns a new Money after applying a percentage discount.
-// percentage must be between 0 and 100.
+// percentage must be between 0 and 100. Fractional units are rounded half-up.
 func (m *Money) ApplyPercentageDiscount(percentage float64) (*Money, error) {
+	return m.ApplyPercentageDiscountRounded(percentage, RoundHalfUp)
+}
+
+// ApplyPercentageDiscountRounded is like ApplyPercentageDiscount but rounds the
+// result using the given mode.
+func (m *Money) ApplyPercentageDiscountRounded(percentage float64, mode RoundingMode) (*Money, error) {
 	if percentage < 0 || percentage > 100 {
 		return nil, ErrInvalidDiscountAmount
 	}
 	discounted := float64(m.amount) * (1 - percentage/100)
-	return &Money{amount: int64(math.Round(discounted)), currency: m.currency}, nil
+	// Clamp so rounding can never make the discounted price exceed the
+	// original or go negative, however small the amount.
+	amount := min(max(int64(mode.round(discounted)), 0), m.amount)
+	return &Money{amount: amount, currency: m.currency}, nil
 }
 
 // IsGreaterThan returns true when m > other.
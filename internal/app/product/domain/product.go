@@ -2,6 +2,9 @@ package domain
 
 import (
 	"errors"
+	"maps"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +17,18 @@ const (
 	ProductStatusInactive ProductStatus = "inactive"
 )
 
+// ProductStatuses lists every product status; ParseProductStatus accepts
+// exactly these and GetMetadata publishes them.
+var ProductStatuses = []ProductStatus{ProductStatusActive, ProductStatusInactive}
+
+// ParseProductStatus validates s against the known product statuses.
+func ParseProductStatus(s string) (ProductStatus, error) {
+	if status := ProductStatus(s); slices.Contains(ProductStatuses, status) {
+		return status, nil
+	}
+	return "", ErrInvalidStatus
+}
+
 const (
 	FieldName        Field = "name"
 	FieldDiscount    Field = "discount"
@@ -21,6 +36,18 @@ const (
 	FieldCategory    Field = "category"
 	FieldBasePrice   Field = "base_price"
 	FieldStatus      Field = "status"
+	FieldArchivedAt  Field = "archived_at"
+
+	FieldPreviousDiscount Field = "previous_discount"
+	FieldFeatured         Field = "featured"
+	FieldMedia            Field = "media_urls"
+	FieldSlug             Field = "slug"
+	FieldStock            Field = "stock_quantity"
+	FieldQuantityTiers    Field = "quantity_tiers"
+	FieldCategories       Field = "additional_categories"
+	FieldAttributes       Field = "attributes"
+	FieldPublishAt        Field = "publish_at"
+	FieldUnpublishAt      Field = "unpublish_at"
 )
 
 // Product is the aggregate root of the product domain.
@@ -28,13 +55,31 @@ const (
 type Product struct {
 	id          string
 	name        string
+	slug        string // URL-friendly unique name; "" for products created before slugs
 	description string
 	category    string
-	basePrice   *Money
-	discount    *Discount
-	status      ProductStatus
-	changes     *Changes
-	events      []DomainEvent
+	// additionalCategories are the categories the product is listed in besides
+	// its primary category, in the order they were added. They never repeat
+	// each other or category.
+	additionalCategories []string
+	basePrice            *Money
+	discount             *Discount
+	// quantityTiers are volume discounts ordered by minimum quantity; they
+	// apply independently of discount.
+	quantityTiers []QuantityTier
+	// previousDiscount is the discount dropped by the last deactivation, kept
+	// so Activate can restore it.
+	previousDiscount *Discount
+	status           ProductStatus
+	featured         bool              // featured products are listed first
+	mediaURLs        []string          // image and other media URLs, in display order
+	stockQuantity    int64             // units on hand; never negative
+	attributes       map[string]string // free-form properties such as color or size, keyed by snake_case name
+	archivedAt       *time.Time        // nil when the product is not archived
+	publishAt        *time.Time        // when the inactive product is due to go live; nil when unscheduled
+	unpublishAt      *time.Time        // when the product is due to be taken down; always after publishAt
+	changes          *Changes
+	events           []DomainEvent
 }
 
 // ────────────────────────────────────────────────────────────────────────────
@@ -42,11 +87,33 @@ type Product struct {
 // ────────────────────────────────────────────────────────────────────────────
 
 // NewProduct creates a brand-new product aggregate.
-// It validates required fields and raises a ProductCreatedEvent.
+// It validates required fields and raises a ProductCreatedEvent. The slug is
+// derived from name; callers resolve collisions with SetSlug before inserting.
 func NewProduct(name, description, category string, basePrice *Money, now time.Time) (*Product, error) {
+	return newProduct(name, description, category, basePrice, ProductStatusActive, now)
+}
+
+// NewStagedProduct is like NewProduct but creates the product inactive, to go
+// live at publishAt: ProductCreatedEvent carries the inactive status and is
+// followed by ProductScheduledEvent. publishAt must be after now.
+func NewStagedProduct(name, description, category string, basePrice *Money, publishAt, now time.Time) (*Product, error) {
+	p, err := newProduct(name, description, category, basePrice, ProductStatusInactive, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.SetPublishAt(&publishAt, now); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func newProduct(name, description, category string, basePrice *Money, status ProductStatus, now time.Time) (*Product, error) {
 	if name == "" {
 		return nil, ErrProductNameRequired
 	}
+	if category == "" {
+		return nil, ErrProductCategoryRequired
+	}
 	if basePrice == nil {
 		return nil, ErrProductBasePriceRequired
 	}
@@ -55,67 +122,187 @@ func NewProduct(name, description, category string, basePrice *Money, now time.T
 	p := &Product{
 		id:          id,
 		name:        name,
+		slug:        Slugify(name),
 		description: description,
 		category:    category,
 		basePrice:   basePrice,
-		status:      ProductStatusActive,
+		status:      status,
 		changes:     NewChanges(),
 	}
 
-	p.events = append(p.events, NewProductCreatedEvent(id, name, category, basePrice, ProductStatusActive, now))
+	p.raise(NewProductCreatedEvent(id, name, description, category, basePrice, status, now))
 
 	return p, nil
 }
 
+// ProductState is a product's persisted state, as Reconstitute takes it. Zero
+// values mean absent: no discount, no schedule, no media, and so on.
+type ProductState struct {
+	ID, Name, Description, Category string
+	BasePrice                       *Money
+	Discount                        *Discount
+	PreviousDiscount                *Discount
+	Status                          ProductStatus
+	ArchivedAt                      *time.Time
+	Featured                        bool
+	MediaURLs                       []string
+	Slug                            string
+	StockQuantity                   int64
+	QuantityTiers                   []QuantityTier
+	AdditionalCategories            []string
+	Attributes                      map[string]string
+	PublishAt, UnpublishAt          *time.Time
+}
+
 // Reconstitute rebuilds a Product from persisted state without raising events.
 // Use this in repository implementations when loading from storage.
-func Reconstitute(
-	id, name, description, category string,
-	basePrice *Money,
-	discount *Discount,
-	status ProductStatus,
-) (*Product, error) {
-	if id == "" {
+func Reconstitute(s ProductState) (*Product, error) {
+	if s.ID == "" {
 		return nil, ErrProductIDRequired
 	}
-	if name == "" {
+	if s.Name == "" {
 		return nil, ErrProductNameRequired
 	}
-	if status != ProductStatusActive && status != ProductStatusInactive {
+	if s.Status != ProductStatusActive && s.Status != ProductStatusInactive {
 		return nil, ErrInvalidStatus
 	}
 	return &Product{
-		id:          id,
-		name:        name,
-		description: description,
-		category:    category,
-		basePrice:   basePrice,
-		discount:    discount,
-		status:      status,
-		changes:     NewChanges(),
+		id:                   s.ID,
+		name:                 s.Name,
+		description:          s.Description,
+		category:             s.Category,
+		basePrice:            s.BasePrice,
+		discount:             s.Discount,
+		status:               s.Status,
+		archivedAt:           s.ArchivedAt,
+		changes:              NewChanges(),
+		previousDiscount:     s.PreviousDiscount,
+		featured:             s.Featured,
+		mediaURLs:            s.MediaURLs,
+		slug:                 s.Slug,
+		stockQuantity:        s.StockQuantity,
+		quantityTiers:        sortedTiers(s.QuantityTiers),
+		additionalCategories: s.AdditionalCategories,
+		attributes:           s.Attributes,
+		publishAt:            s.PublishAt,
+		unpublishAt:          s.UnpublishAt,
 	}, nil
 }
 
+// Snapshot returns a deep copy of p for read paths. Later mutations of p are
+// not visible through the snapshot; it carries no pending events or dirty fields.
+// Money and Discount are immutable value objects and are shared.
+func (p *Product) Snapshot() *Product {
+	s := *p
+	s.changes = NewChanges()
+	s.events = nil
+	if p.archivedAt != nil {
+		at := *p.archivedAt
+		s.archivedAt = &at
+	}
+	if p.publishAt != nil {
+		at := *p.publishAt
+		s.publishAt = &at
+	}
+	if p.unpublishAt != nil {
+		at := *p.unpublishAt
+		s.unpublishAt = &at
+	}
+	s.mediaURLs = slices.Clone(p.mediaURLs)
+	s.quantityTiers = slices.Clone(p.quantityTiers)
+	s.additionalCategories = slices.Clone(p.additionalCategories)
+	s.attributes = maps.Clone(p.attributes)
+	return &s
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // Accessors (read-only)
 // ────────────────────────────────────────────────────────────────────────────
 
-func (p *Product) Changes() *Changes     { return p.changes }
-func (p *Product) ID() string            { return p.id }
-func (p *Product) Name() string          { return p.name }
-func (p *Product) Description() string   { return p.description }
-func (p *Product) Category() string      { return p.category }
-func (p *Product) BasePrice() *Money     { return p.basePrice }
-func (p *Product) Discount() *Discount   { return p.discount }
-func (p *Product) Status() ProductStatus { return p.status }
-func (p *Product) Events() []DomainEvent { return p.events }
-func (p *Product) IsActive() bool        { return p.status == ProductStatusActive }
+func (p *Product) Changes() *Changes           { return p.changes }
+func (p *Product) ID() string                  { return p.id }
+func (p *Product) Name() string                { return p.name }
+func (p *Product) Slug() string                { return p.slug }
+func (p *Product) Description() string         { return p.description }
+func (p *Product) Category() string            { return p.category }
+func (p *Product) BasePrice() *Money           { return p.basePrice }
+func (p *Product) Discount() *Discount         { return p.discount }
+func (p *Product) Status() ProductStatus       { return p.status }
+func (p *Product) ArchivedAt() *time.Time      { return p.archivedAt }
+func (p *Product) PublishAt() *time.Time       { return p.publishAt }
+func (p *Product) UnpublishAt() *time.Time     { return p.unpublishAt }
+func (p *Product) PreviousDiscount() *Discount { return p.previousDiscount }
+func (p *Product) Events() []DomainEvent       { return p.events }
+func (p *Product) IsActive() bool              { return p.status == ProductStatusActive }
+func (p *Product) IsArchived() bool            { return p.archivedAt != nil }
+func (p *Product) IsFeatured() bool            { return p.featured }
+func (p *Product) MediaURLs() []string         { return slices.Clone(p.mediaURLs) }
+func (p *Product) StockQuantity() int64        { return p.stockQuantity }
+func (p *Product) InStock() bool               { return p.stockQuantity > 0 }
+
+// Categories returns every category the product is listed in, primary first.
+func (p *Product) Categories() []string {
+	return append([]string{p.category}, p.additionalCategories...)
+}
+
+// AdditionalCategories returns the categories besides the primary one.
+func (p *Product) AdditionalCategories() []string { return slices.Clone(p.additionalCategories) }
+
+// InCategory reports whether category is the primary or an additional category.
+func (p *Product) InCategory(category string) bool {
+	return p.category == category || slices.Contains(p.additionalCategories, category)
+}
+
+// Attributes returns a copy of the product's attributes.
+func (p *Product) Attributes() map[string]string { return maps.Clone(p.attributes) }
+
+// Attribute returns the value of the attribute key and whether it is set.
+func (p *Product) Attribute(key string) (string, bool) {
+	v, ok := p.attributes[key]
+	return v, ok
+}
+
+// PublishDue reports whether the product is staged for a launch that is due at now.
+func (p *Product) PublishDue(now time.Time) bool {
+	return p.publishAt != nil && !p.publishAt.After(now) &&
+		p.status == ProductStatusInactive && p.archivedAt == nil
+}
+
+// UnpublishDue reports whether the live product is due to be taken down at now.
+func (p *Product) UnpublishDue(now time.Time) bool {
+	return p.unpublishAt != nil && !p.unpublishAt.After(now) &&
+		p.status == ProductStatusActive && p.archivedAt == nil
+}
+
+// QuantityTiers returns the product's volume discounts ordered by minimum quantity.
+func (p *Product) QuantityTiers() []QuantityTier { return slices.Clone(p.quantityTiers) }
+
+// sequencedEvent is a DomainEvent whose Sequence is assigned by the aggregate.
+type sequencedEvent interface {
+	DomainEvent
+	setSequence(n int64)
+}
+
+// raise appends e to the pending events, numbering it after those already
+// raised in this operation.
+func (p *Product) raise(e sequencedEvent) {
+	e.setSequence(int64(len(p.events)) + 1)
+	p.events = append(p.events, e)
+}
 
 // ClearEvents resets the in-memory event slice after they have been dispatched.
+// Interactors call it once their commit succeeds.
 func (p *Product) ClearEvents() {
 	p.events = nil
 }
 
+// MarkPersisted clears the pending events and dirty fields once a commit has
+// written them, so a reused aggregate cannot emit or write them twice.
+func (p *Product) MarkPersisted() {
+	p.ClearEvents()
+	p.changes.Clear()
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // Business methods (mutating, enforce invariants)
 // ────────────────────────────────────────────────────────────────────────────
@@ -133,6 +320,16 @@ func (p *Product) SetName(name string) error {
 	return nil
 }
 
+// SetSlug updates the product slug and marks the field dirty. Callers make
+// sure slug is unique, e.g. with UniqueSlug.
+func (p *Product) SetSlug(slug string) {
+	if p.slug == slug {
+		return
+	}
+	p.slug = slug
+	p.changes.MarkDirty(FieldSlug)
+}
+
 // SetDescription updates the product description and marks the field dirty.
 func (p *Product) SetDescription(description string) {
 	if p.description == description {
@@ -142,17 +339,36 @@ func (p *Product) SetDescription(description string) {
 	p.changes.MarkDirty(FieldDescription)
 }
 
-// SetCategory updates the product category and marks the field dirty.
-func (p *Product) SetCategory(category string) {
+// SetCategory updates the product category and marks the field dirty. A
+// category the product already lists as additional cannot become primary.
+func (p *Product) SetCategory(category string) error {
+	if category == "" {
+		return ErrProductCategoryRequired
+	}
 	if p.category == category {
-		return
+		return nil
+	}
+	if slices.Contains(p.additionalCategories, category) {
+		return ErrDuplicateCategory
 	}
 	p.category = category
 	p.changes.MarkDirty(FieldCategory)
+	return nil
 }
 
 // SetBasePrice updates the product base price and marks the field dirty.
+// The currency is fixed for the product's life, so past events stay
+// comparable; a price in another currency returns ErrCurrencyChangeNotAllowed.
 func (p *Product) SetBasePrice(price *Money) error {
+	if price != nil && p.basePrice != nil && price.Currency() != p.basePrice.Currency() {
+		return ErrCurrencyChangeNotAllowed
+	}
+	return p.CorrectBasePrice(price)
+}
+
+// CorrectBasePrice is SetBasePrice without the currency guard, for admin
+// corrections of a product priced in the wrong currency.
+func (p *Product) CorrectBasePrice(price *Money) error {
 	if price == nil {
 		return ErrProductBasePriceRequired
 	}
@@ -161,51 +377,455 @@ func (p *Product) SetBasePrice(price *Money) error {
 	return nil
 }
 
+// ChangeCategory moves the product to category and raises
+// ProductCategoryChangedEvent. Moving to the current category is a no-op.
+func (p *Product) ChangeCategory(category string, now time.Time) error {
+	from := p.category
+	if err := p.SetCategory(category); err != nil {
+		return err
+	}
+	if from != p.category {
+		p.raise(NewProductCategoryChangedEvent(p.id, from, p.category, now))
+	}
+	return nil
+}
+
+// AddCategory lists the product in one more category and raises
+// ProductCategoriesChangedEvent. maxCategories caps the total number of
+// categories, the primary one included. Adding a listed category fails with
+// ErrDuplicateCategory.
+func (p *Product) AddCategory(category string, maxCategories int, now time.Time) error {
+	if category == "" {
+		return ErrProductCategoryRequired
+	}
+	if p.InCategory(category) {
+		return ErrDuplicateCategory
+	}
+	if 1+len(p.additionalCategories) >= maxCategories {
+		return ErrTooManyCategories
+	}
+	p.additionalCategories = append(slices.Clone(p.additionalCategories), category)
+	p.categoriesChanged(now)
+	return nil
+}
+
+// RemoveCategory unlists the product from an additional category and raises
+// ProductCategoriesChangedEvent. The primary category cannot be removed;
+// removing a category the product is not listed in is a no-op.
+func (p *Product) RemoveCategory(category string, now time.Time) error {
+	if category == p.category {
+		return ErrPrimaryCategoryRemoval
+	}
+	i := slices.Index(p.additionalCategories, category)
+	if i < 0 {
+		return nil
+	}
+	p.additionalCategories = slices.Delete(slices.Clone(p.additionalCategories), i, i+1)
+	p.categoriesChanged(now)
+	return nil
+}
+
+// RenameCategory replaces from with to wherever the product lists it. A
+// primary category moves as with ChangeCategory; an additional one is
+// replaced in place, or dropped when the product already lists to.
+func (p *Product) RenameCategory(from, to string, now time.Time) error {
+	if to == "" {
+		return ErrProductCategoryRequired
+	}
+	if from == to || !p.InCategory(from) {
+		return nil
+	}
+
+	primary := p.category
+	if primary == from {
+		primary = to
+	}
+	extras := make([]string, 0, len(p.additionalCategories))
+	for _, c := range p.additionalCategories {
+		if c == from {
+			c = to
+		}
+		if c != primary && !slices.Contains(extras, c) {
+			extras = append(extras, c)
+		}
+	}
+	changed := !slices.Equal(extras, p.additionalCategories)
+	p.additionalCategories = extras
+
+	if p.category == from {
+		if err := p.ChangeCategory(to, now); err != nil {
+			return err
+		}
+	}
+	if changed {
+		p.categoriesChanged(now)
+	}
+	return nil
+}
+
+func (p *Product) categoriesChanged(now time.Time) {
+	p.changes.MarkDirty(FieldCategories)
+	p.raise(NewProductCategoriesChangedEvent(p.id, p.Categories(), now))
+}
+
+// SetAttribute sets the attribute key to value and raises
+// ProductAttributesChangedEvent. A product holds at most MaxAttributes
+// attributes; setting the current value is a no-op.
+func (p *Product) SetAttribute(key, value string, now time.Time) error {
+	if err := ValidateAttributeKey(key); err != nil {
+		return err
+	}
+	if err := ValidateAttributeValue(value); err != nil {
+		return err
+	}
+	current, ok := p.attributes[key]
+	if ok && current == value {
+		return nil
+	}
+	if !ok && len(p.attributes) >= MaxAttributes {
+		return ErrTooManyAttributes
+	}
+	attributes := maps.Clone(p.attributes)
+	if attributes == nil {
+		attributes = make(map[string]string, 1)
+	}
+	attributes[key] = value
+	p.attributes = attributes
+	p.attributesChanged(now)
+	return nil
+}
+
+// RemoveAttribute deletes the attribute key and raises
+// ProductAttributesChangedEvent. Removing an unset attribute is a no-op.
+func (p *Product) RemoveAttribute(key string, now time.Time) {
+	if _, ok := p.attributes[key]; !ok {
+		return
+	}
+	attributes := maps.Clone(p.attributes)
+	delete(attributes, key)
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	p.attributes = attributes
+	p.attributesChanged(now)
+}
+
+func (p *Product) attributesChanged(now time.Time) {
+	p.changes.MarkDirty(FieldAttributes)
+	p.raise(NewProductAttributesChangedEvent(p.id, p.Attributes(), now))
+}
+
+// SetPublishAt schedules the inactive product to be activated at at and raises
+// ProductScheduledEvent; a nil at cancels the schedule. Only inactive,
+// unarchived products can be scheduled, and at must be after now and before
+// any scheduled takedown. Activating the product, by hand or on schedule,
+// clears the schedule.
+func (p *Product) SetPublishAt(at *time.Time, now time.Time) error {
+	if at == nil {
+		if p.publishAt == nil {
+			return nil
+		}
+		p.publishAt = nil
+		p.changes.MarkDirty(FieldPublishAt)
+		p.raise(NewProductScheduledEvent(p.id, nil, p.unpublishAt, now))
+		return nil
+	}
+	if p.archivedAt != nil {
+		return ErrProductArchived
+	}
+	if p.status == ProductStatusActive {
+		return ErrProductAlreadyActive
+	}
+	if !at.After(now) {
+		return ErrPublishAtNotInFuture
+	}
+	if p.unpublishAt != nil && !p.unpublishAt.After(*at) {
+		return ErrUnpublishBeforePublish
+	}
+	if p.publishAt != nil && p.publishAt.Equal(*at) {
+		return nil
+	}
+	t := *at
+	p.publishAt = &t
+	p.changes.MarkDirty(FieldPublishAt)
+	p.raise(NewProductScheduledEvent(p.id, p.publishAt, p.unpublishAt, now))
+	return nil
+}
+
+// SetUnpublishAt schedules the product to be deactivated at at and raises
+// ProductScheduledEvent; a nil at cancels the takedown. at must be after now
+// and after any scheduled launch, and the product must not be archived.
+// Deactivating the product, by hand or on schedule, clears the takedown.
+func (p *Product) SetUnpublishAt(at *time.Time, now time.Time) error {
+	if at == nil {
+		if p.unpublishAt == nil {
+			return nil
+		}
+		p.unpublishAt = nil
+		p.changes.MarkDirty(FieldUnpublishAt)
+		p.raise(NewProductScheduledEvent(p.id, p.publishAt, nil, now))
+		return nil
+	}
+	if p.archivedAt != nil {
+		return ErrProductArchived
+	}
+	if !at.After(now) {
+		return ErrPublishAtNotInFuture
+	}
+	if p.publishAt != nil && !at.After(*p.publishAt) {
+		return ErrUnpublishBeforePublish
+	}
+	if p.unpublishAt != nil && p.unpublishAt.Equal(*at) {
+		return nil
+	}
+	t := *at
+	p.unpublishAt = &t
+	p.changes.MarkDirty(FieldUnpublishAt)
+	p.raise(NewProductScheduledEvent(p.id, p.publishAt, p.unpublishAt, now))
+	return nil
+}
+
+// SetFeatured marks or unmarks the product as featured and raises
+// ProductFeaturedChangedEvent. Setting the current value is a no-op.
+func (p *Product) SetFeatured(featured bool, now time.Time) {
+	if p.featured == featured {
+		return
+	}
+	p.featured = featured
+	p.changes.MarkDirty(FieldFeatured)
+	p.raise(NewProductFeaturedChangedEvent(p.id, featured, now))
+}
+
+// SetMedia replaces the product's media URLs, kept in the given order, and
+// raises ProductMediaChangedEvent. Setting the current list is a no-op.
+func (p *Product) SetMedia(urls []string, now time.Time) error {
+	if err := ValidateMediaURLs(urls); err != nil {
+		return err
+	}
+	if slices.Equal(p.mediaURLs, urls) {
+		return nil
+	}
+	p.mediaURLs = slices.Clone(urls)
+	p.changes.MarkDirty(FieldMedia)
+	p.raise(NewProductMediaChangedEvent(p.id, p.mediaURLs, now))
+	return nil
+}
+
+// SetQuantityTiers replaces the product's volume discounts and raises
+// ProductQuantityTiersChangedEvent. An empty list removes them all; setting
+// the current tiers is a no-op.
+func (p *Product) SetQuantityTiers(tiers []QuantityTier, now time.Time) error {
+	if err := ValidateQuantityTiers(tiers); err != nil {
+		return err
+	}
+	sorted := sortedTiers(tiers)
+	if slices.Equal(p.quantityTiers, sorted) {
+		return nil
+	}
+	p.quantityTiers = sorted
+	p.changes.MarkDirty(FieldQuantityTiers)
+	p.raise(NewProductQuantityTiersChangedEvent(p.id, slices.Clone(sorted), now))
+	return nil
+}
+
+// SetStock sets the units on hand and raises ProductStockChangedEvent.
+// Setting the current quantity is a no-op.
+func (p *Product) SetStock(quantity int64, now time.Time) error {
+	if quantity < 0 {
+		return ErrNegativeStock
+	}
+	if p.stockQuantity == quantity {
+		return nil
+	}
+	p.stockQuantity = quantity
+	p.changes.MarkDirty(FieldStock)
+	p.raise(NewProductStockChangedEvent(p.id, quantity, now))
+	return nil
+}
+
+// AdjustStock adds delta, which may be negative, to the units on hand. It
+// fails with ErrNegativeStock rather than take the quantity below zero.
+func (p *Product) AdjustStock(delta int64, now time.Time) error {
+	if delta > 0 && p.stockQuantity > math.MaxInt64-delta {
+		return ErrStockOverflow
+	}
+	return p.SetStock(p.stockQuantity+delta, now)
+}
+
 // Activate transitions the product to active status and raises ProductActivatedEvent.
-func (p *Product) Activate(now time.Time) error {
+// With restoreDiscount set, the discount dropped by the last deactivation is
+// re-applied if it has not expired yet. The stored previous discount is cleared
+// either way.
+func (p *Product) Activate(now time.Time, restoreDiscount bool) error {
 	if p.status == ProductStatusActive {
 		return nil
 	}
 	p.status = ProductStatusActive
 	p.changes.MarkDirty(FieldStatus)
-	p.events = append(p.events, NewProductActivatedEvent(p.id, now))
+	p.raise(NewProductActivatedEvent(p.id, now))
+	if p.publishAt != nil {
+		p.publishAt = nil
+		p.changes.MarkDirty(FieldPublishAt)
+	}
+
+	prev := p.previousDiscount
+	if prev == nil {
+		return nil
+	}
+	p.previousDiscount = nil
+	p.changes.MarkDirty(FieldPreviousDiscount)
+	if restoreDiscount && !prev.IsExpired(now) {
+		return p.setDiscount(prev, now)
+	}
 	return nil
 }
 
 // Deactivate transitions the product to inactive status and raises ProductDeactivatedEvent.
-// Any active discount is also removed.
-func (p *Product) Deactivate(now time.Time) error {
+// Any active discount is also removed. A scheduled discount that has not started yet
+// blocks deactivation with ErrScheduledDiscountPending unless force is set.
+// Deactivating clears a scheduled takedown.
+func (p *Product) Deactivate(now time.Time, force bool) error {
+	return p.deactivate(now, force, DeactivationReasonManual)
+}
+
+// Unpublish takes the product down on its unpublish schedule: it is
+// deactivated regardless of a pending discount, and ProductDeactivatedEvent
+// carries DeactivationReasonScheduled.
+func (p *Product) Unpublish(now time.Time) error {
+	return p.deactivate(now, true, DeactivationReasonScheduled)
+}
+
+func (p *Product) deactivate(now time.Time, force bool, reason DeactivationReason) error {
 	if p.status == ProductStatusInactive {
 		return nil
 	}
+	if !force && p.discount != nil && p.discount.StateAt(now) == DiscountStateUpcoming {
+		return ErrScheduledDiscountPending
+	}
 	p.status = ProductStatusInactive
 	p.changes.MarkDirty(FieldStatus)
+	if p.unpublishAt != nil {
+		p.unpublishAt = nil
+		p.changes.MarkDirty(FieldUnpublishAt)
+	}
 	if p.discount != nil {
-		p.events = append(p.events, NewDiscountRemovedEvent(p.id, now))
+		p.raise(NewDiscountRemovedEvent(p.id, now))
+		p.previousDiscount = p.discount
 		p.discount = nil
 		p.changes.MarkDirty(FieldDiscount)
+		p.changes.MarkDirty(FieldPreviousDiscount)
 	}
-	p.events = append(p.events, NewProductDeactivatedEvent(p.id, now))
+	p.raise(NewProductDeactivatedEvent(p.id, reason, now))
+	return nil
+}
+
+// Restore clears the archived marker and raises ProductRestoredEvent.
+// Restoring a product that is not archived is a no-op.
+func (p *Product) Restore(now time.Time) error {
+	if p.archivedAt == nil {
+		return nil
+	}
+	p.archivedAt = nil
+	p.changes.MarkDirty(FieldArchivedAt)
+	p.raise(NewProductRestoredEvent(p.id, now))
 	return nil
 }
 
 // ApplyDiscount applies a discount to the product.
 // Only active products can receive discounts and the discount period must be valid.
+// The start must be within the discount's max backdating window, if any.
+// A stored discount that has not expired must not overlap the new period.
 func (p *Product) ApplyDiscount(discount *Discount, now time.Time) error {
+	if discount == nil {
+		return errors.New("discount must not be nil")
+	}
+	if verr := p.discountViolations(discount, now); len(verr.Fields) > 0 {
+		return verr.Fields[0].Err
+	}
+	p.storeDiscount(discount, now)
+	return nil
+}
+
+// ValidateDiscount runs every check NewDiscount and ApplyDiscount make for a
+// discount of percentage over [startsAt, endsAt) without changing p. Unlike
+// those, it reports all violations at once as a *ValidationError; nil means
+// applying the discount, with the given skew tolerance and max backdating, at
+// now would succeed.
+func (p *Product) ValidateDiscount(percentage string, startsAt, endsAt, now time.Time, skewTolerance, maxBackdating time.Duration) error {
+	var input []FieldError
+	if _, err := parsePercentage(percentage); err != nil {
+		input = append(input, FieldError{Field: "percentage", Err: err})
+	}
+	var window *Discount
+	if err := validatePeriod(startsAt, endsAt); err != nil {
+		input = append(input, FieldError{Field: "ends_at", Err: err})
+	} else {
+		window = &Discount{startsAt: startsAt.UTC(), endsAt: endsAt.UTC(), skewTolerance: skewTolerance, maxBackdating: maxBackdating}
+	}
+	verr := p.discountViolations(window, now, input...)
+	return verr.OrNil()
+}
+
+// discountViolations collects every reason ApplyDiscount would refuse discount,
+// in the order it checks them: the product's status, then input (violations
+// already found in the request), then the discount's window. A nil discount,
+// whose period could not be formed, skips the window checks.
+func (p *Product) discountViolations(discount *Discount, now time.Time, input ...FieldError) ValidationError {
+	var verr ValidationError
+	if p.status != ProductStatusActive {
+		verr.Add("product", ErrProductNotActive)
+	}
+	verr.Fields = append(verr.Fields, input...)
+	if discount == nil {
+		return verr
+	}
+	if !discount.IsValidAt(now) {
+		verr.Add("starts_at", ErrInvalidDiscountPeriod)
+	}
+	if discount.IsBackdatedTooFar(now) {
+		verr.Add("starts_at", ErrDiscountBackdatedTooFar)
+	}
+	if p.overlapsStoredDiscount(discount, now) {
+		verr.Add("starts_at", ErrDiscountOverlap)
+	}
+	return verr
+}
+
+// ScheduleDiscount is like ApplyDiscount but also accepts a discount that starts
+// in the future. The discount must not already be expired at now.
+func (p *Product) ScheduleDiscount(discount *Discount, now time.Time) error {
 	if p.status != ProductStatusActive {
 		return ErrProductNotActive
 	}
 	if discount == nil {
 		return errors.New("discount must not be nil")
 	}
-	if !discount.IsValidAt(now) {
+	if discount.IsExpired(now) {
 		return ErrInvalidDiscountPeriod
 	}
+	return p.setDiscount(discount, now)
+}
+
+// setDiscount stores discount unless it overlaps a stored, unexpired discount.
+func (p *Product) setDiscount(discount *Discount, now time.Time) error {
+	if p.overlapsStoredDiscount(discount, now) {
+		return ErrDiscountOverlap
+	}
+	p.storeDiscount(discount, now)
+	return nil
+}
 
+// storeDiscount sets discount and raises DiscountAppliedEvent.
+func (p *Product) storeDiscount(discount *Discount, now time.Time) {
 	p.discount = discount
 	p.changes.MarkDirty(FieldDiscount)
-	p.events = append(p.events, NewDiscountAppliedEvent(p.id, discount.Percentage(), discount.StartsAt(), discount.EndsAt(), now))
-	return nil
+	p.raise(NewDiscountAppliedEvent(p.id, discount.Percentage(), discount.StartsAt(), discount.EndsAt(), now))
+}
+
+// overlapsStoredDiscount reports whether discount clashes with the stored
+// discount; an expired stored discount never does.
+func (p *Product) overlapsStoredDiscount(discount *Discount, now time.Time) bool {
+	return p.discount != nil && !p.discount.IsExpired(now) && p.discount.Overlaps(discount)
 }
 
 // RemoveDiscount removes any active discount from the product and raises DiscountRemovedEvent.
@@ -215,7 +835,7 @@ func (p *Product) RemoveDiscount(now time.Time) error {
 	}
 	p.discount = nil
 	p.changes.MarkDirty(FieldDiscount)
-	p.events = append(p.events, NewDiscountRemovedEvent(p.id, now))
+	p.raise(NewDiscountRemovedEvent(p.id, now))
 	return nil
 }
 
@@ -229,5 +849,37 @@ func (p *Product) RecordUpdate(now time.Time) {
 	if len(dirty) == 0 {
 		return
 	}
-	p.events = append(p.events, NewProductUpdatedEvent(p.id, dirty, now))
+	p.raise(NewProductUpdatedEvent(p.id, dirty, now))
+}
+
+// RecordFieldChanges is the field-level alternative to RecordUpdate: it raises
+// one event per dirty field, taking old values from before, a Snapshot taken
+// ahead of the changes. Name, description, category and base price get their
+// own events; any other dirty fields, such as a regenerated slug, are listed
+// in a ProductUpdatedEvent.
+func (p *Product) RecordFieldChanges(before *Product, now time.Time) {
+	var rest []Field
+	for f := range p.changes.dirty {
+		switch f {
+		case FieldName, FieldDescription, FieldCategory, FieldBasePrice:
+		default:
+			rest = append(rest, f)
+		}
+	}
+
+	if p.changes.Dirty(FieldName) {
+		p.raise(NewProductNameChangedEvent(p.id, before.name, p.name, now))
+	}
+	if p.changes.Dirty(FieldDescription) {
+		p.raise(NewProductDescriptionChangedEvent(p.id, before.description, p.description, now))
+	}
+	if p.changes.Dirty(FieldCategory) {
+		p.raise(NewProductCategoryChangedEvent(p.id, before.category, p.category, now))
+	}
+	if p.changes.Dirty(FieldBasePrice) {
+		p.raise(NewProductPriceChangedEvent(p.id, before.basePrice, p.basePrice, now))
+	}
+	if len(rest) > 0 {
+		p.raise(NewProductUpdatedEvent(p.id, rest, now))
+	}
 }
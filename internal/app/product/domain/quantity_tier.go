@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// MaxQuantityTiers caps how many quantity tiers a product can carry.
+const MaxQuantityTiers = 10
+
+// QuantityTier is a volume discount: buying at least MinQuantity units takes
+// Percentage off the unit price. Like Discount it is a value object belonging
+// to the Product aggregate, but it has no validity window.
+type QuantityTier struct {
+	minQuantity int64
+	percentage  string // canonical decimal form, see canonicalPercentage
+}
+
+// NewQuantityTier creates and validates a QuantityTier. minQuantity must be at
+// least 1 and percentage between 0 and 100.
+func NewQuantityTier(minQuantity int64, percentage string) (QuantityTier, error) {
+	if minQuantity < 1 {
+		return QuantityTier{}, ErrInvalidQuantityTier
+	}
+	pct, err := parsePercentage(percentage)
+	if err != nil {
+		return QuantityTier{}, err
+	}
+	return QuantityTier{minQuantity: minQuantity, percentage: canonicalPercentage(pct)}, nil
+}
+
+func (t QuantityTier) MinQuantity() int64 { return t.minQuantity }
+func (t QuantityTier) Percentage() string { return t.percentage }
+
+// PercentageFloat64 returns the parsed percentage as float64.
+func (t QuantityTier) PercentageFloat64() float64 {
+	pct, _ := strconv.ParseFloat(t.percentage, 64)
+	return pct
+}
+
+// AppliesTo reports whether buying quantity units qualifies for t.
+func (t QuantityTier) AppliesTo(quantity int64) bool {
+	return quantity >= t.minQuantity
+}
+
+// ValidateQuantityTiers checks that tiers holds at most MaxQuantityTiers
+// entries with distinct minimum quantities.
+func ValidateQuantityTiers(tiers []QuantityTier) error {
+	if len(tiers) > MaxQuantityTiers {
+		return ErrTooManyQuantityTiers
+	}
+	seen := make(map[int64]bool, len(tiers))
+	for _, t := range tiers {
+		if seen[t.minQuantity] {
+			return ErrDuplicateQuantityTier
+		}
+		seen[t.minQuantity] = true
+	}
+	return nil
+}
+
+// sortedTiers returns a copy of tiers ordered by minimum quantity.
+func sortedTiers(tiers []QuantityTier) []QuantityTier {
+	sorted := slices.Clone(tiers)
+	slices.SortFunc(sorted, func(a, b QuantityTier) int { return cmp.Compare(a.minQuantity, b.minQuantity) })
+	return sorted
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "math"
+
+// RoundingMode selects how fractional minor units are rounded after a price computation.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (1.5 → 2, 2.5 → 3). This is the default.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even unit (1.5 → 2, 2.5 → 2), a.k.a. banker's rounding.
+	RoundHalfEven
+	// RoundFloor always rounds down (1.5 → 1, 2.5 → 2), never overcharging the customer.
+	RoundFloor
+)
+
+// ParseRoundingMode converts a config value ("half_up", "half_even", "floor") to a RoundingMode.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch s {
+	case "half_up":
+		return RoundHalfUp, nil
+	case "half_even":
+		return RoundHalfEven, nil
+	case "floor":
+		return RoundFloor, nil
+	default:
+		return RoundHalfUp, ErrInvalidRoundingMode
+	}
+}
+
+// round applies the rounding mode to v.
+func (r RoundingMode) round(v float64) float64 {
+	switch r {
+	case RoundHalfEven:
+		return math.RoundToEven(v)
+	case RoundFloor:
+		return math.Floor(v)
+	default:
+		return math.Round(v)
+	}
+}
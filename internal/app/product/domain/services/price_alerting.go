@@ -0,0 +1,56 @@
+package services
+
+import (
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// CrossingDirection tells which way a price moved through a threshold.
+type CrossingDirection string
+
+const (
+	CrossedBelow CrossingDirection = "below" // the price fell under the threshold
+	CrossedAbove CrossingDirection = "above" // the price rose back to or over the threshold
+)
+
+// PriceCrossing is one watched threshold crossed by a price change.
+type PriceCrossing struct {
+	Threshold *domain.Money
+	Direction CrossingDirection
+}
+
+// PriceAlerting is a domain service that decides which watched price
+// thresholds an effective price change crosses. It keeps no state: callers
+// feed it the prices before and after an event, e.g. from a discount being
+// applied or removed.
+type PriceAlerting struct{}
+
+// NewPriceAlerting returns a new PriceAlerting.
+func NewPriceAlerting() *PriceAlerting {
+	return &PriceAlerting{}
+}
+
+// Crossings returns the thresholds crossed moving from before to after, in
+// the order given. A price falls below a threshold when it goes from at or
+// above it to under it, and rises above it on the way back. Every price must
+// share one currency.
+func (pa *PriceAlerting) Crossings(before, after *domain.Money, thresholds []*domain.Money) ([]PriceCrossing, error) {
+	if before.Currency() != after.Currency() {
+		return nil, domain.ErrCurrencyMismatch
+	}
+
+	var crossings []PriceCrossing
+	for _, t := range thresholds {
+		if t.Currency() != before.Currency() {
+			return nil, domain.ErrCurrencyMismatch
+		}
+		wasBelow := before.Amount() < t.Amount()
+		isBelow := after.Amount() < t.Amount()
+		switch {
+		case !wasBelow && isBelow:
+			crossings = append(crossings, PriceCrossing{Threshold: t, Direction: CrossedBelow})
+		case wasBelow && !isBelow:
+			crossings = append(crossings, PriceCrossing{Threshold: t, Direction: CrossedAbove})
+		}
+	}
+	return crossings, nil
+}
@@ -9,11 +9,46 @@ import (
 
 // PricingCalculator is a domain service that handles price computation logic.
 // It is stateless and depends only on domain value objects (Money, Discount).
-type PricingCalculator struct{}
+type PricingCalculator struct {
+	rounding          domain.RoundingMode
+	discountsDisabled func() bool
+}
+
+// Option customises a PricingCalculator.
+type Option func(*PricingCalculator)
+
+// WithRoundingMode sets how discounted prices are rounded to the smallest currency unit.
+func WithRoundingMode(mode domain.RoundingMode) Option {
+	return func(pc *PricingCalculator) { pc.rounding = mode }
+}
 
-// NewPricingCalculator returns a new PricingCalculator.
-func NewPricingCalculator() *PricingCalculator {
-	return &PricingCalculator{}
+// WithDiscountsDisabled makes the calculator ignore every discount, serving base
+// prices, whenever disabled reports true. Stored discounts are left untouched.
+func WithDiscountsDisabled(disabled func() bool) Option {
+	return func(pc *PricingCalculator) { pc.discountsDisabled = disabled }
+}
+
+// NewPricingCalculator returns a new PricingCalculator. Without options it rounds half-up.
+func NewPricingCalculator(opts ...Option) *PricingCalculator {
+	pc := &PricingCalculator{rounding: domain.RoundHalfUp, discountsDisabled: func() bool { return false }}
+	for _, opt := range opts {
+		opt(pc)
+	}
+	return pc
+}
+
+// DiscountsDisabled reports whether every discount is currently ignored.
+func (pc *PricingCalculator) DiscountsDisabled() bool {
+	return pc.discountsDisabled()
+}
+
+// IgnoringDisabledDiscounts returns a copy of pc that always honours stored
+// discounts, for precomputed prices that must stay correct after the
+// discounts-disabled switch is turned off again.
+func (pc *PricingCalculator) IgnoringDisabledDiscounts() *PricingCalculator {
+	c := *pc
+	c.discountsDisabled = func() bool { return false }
+	return &c
 }
 
 // EffectivePrice returns the price a customer would pay for a product at a given point in time.
@@ -24,7 +59,7 @@ func (pc *PricingCalculator) EffectivePrice(basePrice *domain.Money, discount *d
 		return nil, domain.ErrProductBasePriceRequired
 	}
 
-	if discount == nil || !discount.IsValidAt(now) {
+	if !pc.IsDiscounted(discount, now) {
 		return basePrice, nil
 	}
 
@@ -33,7 +68,23 @@ func (pc *PricingCalculator) EffectivePrice(basePrice *domain.Money, discount *d
 		return nil, domain.ErrDiscountInvalidPercentage
 	}
 
-	return basePrice.ApplyPercentageDiscount(pct)
+	return basePrice.ApplyPercentageDiscountRounded(pct, pc.rounding)
+}
+
+// ExactEffectivePrice is EffectivePrice before rounding, in basePrice's minor
+// units, for auditing the rounding EffectivePrice applies.
+func (pc *PricingCalculator) ExactEffectivePrice(basePrice *domain.Money, discount *domain.Discount, now time.Time) (float64, error) {
+	if basePrice == nil {
+		return 0, domain.ErrProductBasePriceRequired
+	}
+	if !pc.IsDiscounted(discount, now) {
+		return float64(basePrice.Amount()), nil
+	}
+	pct, err := strconv.ParseFloat(discount.Percentage(), 64)
+	if err != nil {
+		return 0, domain.ErrDiscountInvalidPercentage
+	}
+	return float64(basePrice.Amount()) * (1 - pct/100), nil
 }
 
 // DiscountAmount returns the absolute monetary value saved by the discount at a given time.
@@ -48,7 +99,7 @@ func (pc *PricingCalculator) DiscountAmount(basePrice *domain.Money, discount *d
 		return nil, err
 	}
 
-	if discount == nil || !discount.IsValidAt(now) {
+	if !pc.IsDiscounted(discount, now) {
 		return zero, nil
 	}
 
@@ -60,7 +111,44 @@ func (pc *PricingCalculator) DiscountAmount(basePrice *domain.Money, discount *d
 	return basePrice.Subtract(effective)
 }
 
-// IsDiscounted returns true when the product has a valid discount at the given time.
+// IsDiscounted returns true when the product has a valid discount at the given
+// time and discounts are not globally disabled.
 func (pc *PricingCalculator) IsDiscounted(discount *domain.Discount, now time.Time) bool {
-	return discount != nil && discount.IsValidAt(now)
+	return discount != nil && discount.IsValidAt(now) && !pc.discountsDisabled()
+}
+
+// BestQuantityTier returns the tier with the highest percentage among those
+// quantity qualifies for; ok is false when none applies or discounts are
+// globally disabled.
+func (pc *PricingCalculator) BestQuantityTier(tiers []domain.QuantityTier, quantity int64) (best domain.QuantityTier, ok bool) {
+	if pc.discountsDisabled() {
+		return domain.QuantityTier{}, false
+	}
+	for _, t := range tiers {
+		if !t.AppliesTo(quantity) {
+			continue
+		}
+		if !ok || t.PercentageFloat64() > best.PercentageFloat64() {
+			best, ok = t, true
+		}
+	}
+	return best, ok
+}
+
+// EffectiveUnitPrice returns the unit price when buying quantity units, with
+// the best applicable quantity tier taken off base. Without one, base is
+// returned unchanged. The time-windowed discount is not considered.
+func (pc *PricingCalculator) EffectiveUnitPrice(base *domain.Money, tiers []domain.QuantityTier, quantity int64) (*domain.Money, error) {
+	if base == nil {
+		return nil, domain.ErrProductBasePriceRequired
+	}
+	if quantity < 1 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	tier, ok := pc.BestQuantityTier(tiers, quantity)
+	if !ok {
+		return base, nil
+	}
+	return base.ApplyPercentageDiscountRounded(tier.PercentageFloat64(), pc.rounding)
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength mirrors the products.slug column.
+const MaxSlugLength = 100
+
+// fallbackSlug is used for names with no letters or digits to keep.
+const fallbackSlug = "product"
+
+// Slugify derives a URL slug such as "wireless-keyboard" from a product name:
+// accents are dropped, letters lower-cased, and every other run of characters
+// becomes a single hyphen.
+func Slugify(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range norm.NFD.String(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue // combining accent split off by NFD
+		}
+		if r == 'đ' || r == 'Đ' { // has no decomposed form
+			r = 'd'
+		}
+		r = unicode.ToLower(r)
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteRune(r)
+	}
+	slug := truncateSlug(b.String(), MaxSlugLength)
+	if slug == "" {
+		return fallbackSlug
+	}
+	return slug
+}
+
+// UniqueSlug returns base when it is not in taken, otherwise base with the
+// lowest "-N" suffix (N >= 2) that is free, shortening base to stay within
+// MaxSlugLength.
+func UniqueSlug(base string, taken []string) string {
+	if !slices.Contains(taken, base) {
+		return base
+	}
+	for n := 2; ; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		candidate := truncateSlug(base, MaxSlugLength-len(suffix)) + suffix
+		if !slices.Contains(taken, candidate) {
+			return candidate
+		}
+	}
+}
+
+// truncateSlug cuts slug to at most n bytes without leaving a trailing hyphen.
+func truncateSlug(slug string, n int) string {
+	if len(slug) > n {
+		slug = slug[:n]
+	}
+	return strings.TrimRight(slug, "-")
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Field length limits mirroring the products table columns.
+const (
+	MaxNameLength        = 255
+	MaxCategoryLength    = 100
+	MaxDescriptionLength = 4096
+	MaxMediaURLs         = 10
+	MaxMediaURLLength    = 2048
+
+	MaxAttributes           = 50
+	MaxAttributeKeyLength   = 64
+	MaxAttributeValueLength = 255
+)
+
+// DefaultMaxCategories is how many categories, the primary one included, a
+// product can be listed in unless configured otherwise.
+const DefaultMaxCategories = 5
+
+// ValidateMediaURLs checks that urls holds at most MaxMediaURLs absolute
+// http(s) URLs of at most MaxMediaURLLength bytes each.
+func ValidateMediaURLs(urls []string) error {
+	if len(urls) > MaxMediaURLs {
+		return ErrTooManyMediaURLs
+	}
+	for _, raw := range urls {
+		if len(raw) > MaxMediaURLLength {
+			return ErrInvalidMediaURL
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidMediaURL
+		}
+	}
+	return nil
+}
+
+// ValidateAttributeKey checks that key is a snake_case identifier such as
+// "color" or "screen_size" of at most MaxAttributeKeyLength bytes.
+func ValidateAttributeKey(key string) error {
+	if key == "" || len(key) > MaxAttributeKeyLength || key[0] < 'a' || key[0] > 'z' {
+		return ErrInvalidAttributeKey
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return ErrInvalidAttributeKey
+		}
+	}
+	return nil
+}
+
+// ValidateAttributeValue checks that value is non-empty and at most
+// MaxAttributeValueLength characters.
+func ValidateAttributeValue(value string) error {
+	if value == "" || utf8.RuneCountInString(value) > MaxAttributeValueLength {
+		return ErrInvalidAttributeValue
+	}
+	return nil
+}
+
+// FieldError describes one invalid request field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// ValidationError collects every invalid field of a request so callers see
+// all problems at once. errors.Is matches any of the wrapped field errors.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add records an invalid field.
+func (e *ValidationError) Add(field string, err error) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Err: err})
+}
+
+// OrNil returns e when at least one field is invalid, otherwise nil.
+func (e *ValidationError) OrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Err.Error())
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
@@ -0,0 +1,131 @@
+// Package projection maintains the product_read_model listing table from
+// outbox events.
+package projection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// productCreated is the event whose outbox commit timestamp doubles as the
+// product's created_at.
+const productCreated = "product.created"
+
+// Projector rebuilds a product's listing row whenever one of its events is
+// delivered. Rows are derived from the current product state rather than the
+// event payload, so projection is idempotent and tolerates replays and
+// out-of-order delivery. It implements outbox.Publisher, so it can be driven
+// by a Relay or bootstrapped with a Replayer.
+type Projector struct {
+	products  contract.QueryRepository
+	readModel contract.ReadModelRepository
+	pricing   *services.PricingCalculator
+	ticker    common.Ticker
+}
+
+func NewProjector(products contract.QueryRepository, readModel contract.ReadModelRepository, pricing *services.PricingCalculator, ticker common.Ticker) *Projector {
+	return &Projector{
+		products:  products,
+		readModel: readModel,
+		// The discounts-disabled switch is applied when listings are read.
+		pricing: pricing.IgnoringDisabledDiscounts(),
+		ticker:  ticker,
+	}
+}
+
+// Publish projects the product the event belongs to.
+func (p *Projector) Publish(ctx context.Context, event m_outbox.OutboxEventRow) error {
+	if event.AggregateID == "" {
+		return nil
+	}
+	var createdAt *time.Time
+	if event.EventType == productCreated {
+		createdAt = &event.CreatedAt
+	}
+	return p.project(ctx, event.AggregateID, createdAt)
+}
+
+// Sweep re-projects up to limit rows whose discount has started or ended
+// since they were projected and returns how many it refreshed. Callers run it
+// periodically, repeating while it returns limit.
+func (p *Projector) Sweep(ctx context.Context, limit int) (int, error) {
+	ids, err := p.readModel.ListDue(ctx, p.ticker.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		if err := p.project(ctx, id, nil); err != nil {
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+func (p *Projector) project(ctx context.Context, id string, createdAt *time.Time) error {
+	product, err := p.products.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	listing, err := BuildListing(product, p.pricing, p.ticker.Now())
+	if err != nil {
+		return err
+	}
+	listing.CreatedAt = createdAt
+	return p.readModel.Upsert(ctx, listing)
+}
+
+// BuildListing computes the listing row of product at now.
+func BuildListing(product *domain.Product, pricing *services.PricingCalculator, now time.Time) (*contract.ProductListing, error) {
+	effective, err := pricing.EffectivePrice(product.BasePrice(), product.Discount(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &contract.ProductListing{
+		ProductID:            product.ID(),
+		Name:                 product.Name(),
+		Category:             product.Category(),
+		AdditionalCategories: product.AdditionalCategories(),
+		Status:               product.Status(),
+		Featured:             product.IsFeatured(),
+		StockQuantity:        product.StockQuantity(),
+		Attributes:           product.Attributes(),
+		BasePrice:            product.BasePrice(),
+		EffectivePrice:       effective,
+		IsDiscounted:         pricing.IsDiscounted(product.Discount(), now),
+	}
+	if d := product.Discount(); d != nil {
+		if listing.IsDiscounted {
+			endsAt := d.EndsAt()
+			listing.DiscountEndsAt = &endsAt
+		}
+		listing.NextPriceChangeAt = nextPriceChange(d, now)
+	}
+	return listing, nil
+}
+
+// nextPriceChange returns when d next starts or ends after now; nil once it
+// has expired.
+func nextPriceChange(d *domain.Discount, now time.Time) *time.Time {
+	var at time.Time
+	switch {
+	case now.Before(d.StartsAt()):
+		at = d.StartsAt()
+	case now.Before(d.EndsAt()):
+		at = d.EndsAt()
+	default:
+		return nil
+	}
+	return &at
+}
@@ -0,0 +1,37 @@
+// Package analytics holds the response shapes shared by the analytics
+// queries, so every analytics endpoint serialises money the same way.
+package analytics
+
+import (
+	"encoding/json"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// MoneyDTO is a monetary amount in an analytics response.
+type MoneyDTO struct {
+	Amount   int64 // in the smallest currency unit
+	Currency string
+}
+
+// NewMoneyDTO converts m to its analytics representation.
+func NewMoneyDTO(m *domain.Money) MoneyDTO {
+	return MoneyDTO{Amount: m.Amount(), Currency: m.Currency()}
+}
+
+// moneyJSON is the wire shape of MoneyDTO:
+// {"amount_minor": 1999, "currency": "USD", "display": "19.99 USD"}.
+type moneyJSON struct {
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+	Display     string `json:"display"`
+}
+
+// MarshalJSON encodes d with a display string formatted for its currency.
+func (d MoneyDTO) MarshalJSON() ([]byte, error) {
+	display := ""
+	if m, err := domain.NewMoney(d.Amount, d.Currency); err == nil {
+		display = m.String()
+	}
+	return json.Marshal(moneyJSON{AmountMinor: d.Amount, Currency: d.Currency, Display: display})
+}
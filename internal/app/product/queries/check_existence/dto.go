@@ -0,0 +1,12 @@
+package checkexistence
+
+// CheckExistenceRequest lists the product IDs to look up. Duplicates and empty
+// IDs are ignored.
+type CheckExistenceRequest struct {
+	ProductIDs []string
+}
+
+// CheckExistenceResponse maps each distinct requested ID to whether it exists.
+type CheckExistenceResponse struct {
+	Exists map[string]bool
+}
@@ -0,0 +1,38 @@
+package checkexistence
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/internal/app/product/contract"
+)
+
+// CheckExistenceQuery reports which of a list of product IDs already exist,
+// e.g. before an import, without loading full products.
+type CheckExistenceQuery struct {
+	repo contract.QueryRepository
+}
+
+func NewCheckExistenceQuery(repo contract.QueryRepository) *CheckExistenceQuery {
+	return &CheckExistenceQuery{repo: repo}
+}
+
+func (q *CheckExistenceQuery) Execute(ctx context.Context, req *CheckExistenceRequest) (*CheckExistenceResponse, error) {
+	seen := make(map[string]bool, len(req.ProductIDs))
+	ids := make([]string, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return &CheckExistenceResponse{Exists: map[string]bool{}}, nil
+	}
+
+	exists, err := q.repo.ExistsBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckExistenceResponse{Exists: exists}, nil
+}
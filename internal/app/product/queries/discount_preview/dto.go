@@ -0,0 +1,28 @@
+package discountpreview
+
+import "time"
+
+// DiscountPreviewRequest asks what a product would cost with a discount of
+// Percentage in effect at At.
+type DiscountPreviewRequest struct {
+	ProductID  string
+	Percentage string
+	At         time.Time // zero = now
+}
+
+// DiscountPreviewDTO is the outcome of a hypothetical discount. The product
+// itself is left untouched.
+type DiscountPreviewDTO struct {
+	ProductID      string
+	Percentage     string    // canonical form, e.g. "15.0"
+	At             time.Time // when the prices were evaluated
+	BasePrice      MoneyDTO
+	EffectivePrice MoneyDTO
+	Saved          MoneyDTO // BasePrice − EffectivePrice
+}
+
+// MoneyDTO is a flat representation of a monetary amount.
+type MoneyDTO struct {
+	Amount   int64
+	Currency string
+}
@@ -0,0 +1,67 @@
+package discountpreview
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+)
+
+// DiscountPreviewQuery prices a product as if a discount were applied, without
+// applying it. The discounts-disabled switch is ignored: the preview answers
+// what the discount itself would do.
+type DiscountPreviewQuery struct {
+	queryRepo contract.QueryRepository
+	pricing   *services.PricingCalculator
+	ticker    common.Ticker
+}
+
+func NewDiscountPreviewQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *DiscountPreviewQuery {
+	return &DiscountPreviewQuery{queryRepo: queryRepo, pricing: pricing.IgnoringDisabledDiscounts(), ticker: ticker}
+}
+
+func (q *DiscountPreviewQuery) Execute(ctx context.Context, req *DiscountPreviewRequest) (*DiscountPreviewDTO, error) {
+	at := req.At
+	if at.IsZero() {
+		at = common.NowFromContext(ctx, q.ticker)
+	}
+	// Only the evaluation instant matters, so any window opening then will do.
+	discount, err := domain.NewDiscountForDuration(req.Percentage, at, time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := q.queryRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsArchived() {
+		return nil, domain.ErrProductArchived
+	}
+
+	base := product.BasePrice()
+	effective, err := q.pricing.EffectivePrice(base, discount, at)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := base.Subtract(effective)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscountPreviewDTO{
+		ProductID:      product.ID(),
+		Percentage:     discount.Percentage(),
+		At:             at.UTC(),
+		BasePrice:      toMoneyDTO(base),
+		EffectivePrice: toMoneyDTO(effective),
+		Saved:          toMoneyDTO(saved),
+	}, nil
+}
+
+func toMoneyDTO(m *domain.Money) MoneyDTO {
+	return MoneyDTO{Amount: m.Amount(), Currency: m.Currency()}
+}
@@ -0,0 +1,16 @@
+package effectivepricebackfill
+
+// BackfillRequest selects the batch to process. An empty Cursor starts from the
+// beginning; pass the previous NextCursor to resume.
+type BackfillRequest struct {
+	Cursor string
+	Limit  int // 0 = DefaultLimit; values above MaxLimit are clamped
+}
+
+// BackfillResponse reports one processed batch.
+type BackfillResponse struct {
+	Scanned    int    // active products examined in this batch
+	Differing  int    // products whose effective price differs from the base price
+	NextCursor string // "" once every active product has been processed
+	DryRun     bool   // true while there is no snapshot column to write
+}
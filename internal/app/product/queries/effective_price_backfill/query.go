@@ -0,0 +1,95 @@
+package effectivepricebackfill
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+)
+
+const (
+	DefaultLimit = 500
+	MaxLimit     = 1000
+)
+
+// BackfillQuery recomputes effective prices for active products at the current
+// time, one batch per call, so a denormalised effective-price snapshot could be
+// refreshed as discount windows open and close.
+//
+// No snapshot column exists yet, so it runs as a dry run: it writes nothing,
+// raises no domain events and only reports how many products' effective price
+// differs from their base price. Being read-only, re-running any batch is safe.
+type BackfillQuery struct {
+	queryRepo contract.QueryRepository
+	pricing   *services.PricingCalculator
+	ticker    common.Ticker
+}
+
+func NewBackfillQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *BackfillQuery {
+	return &BackfillQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+}
+
+func (q *BackfillQuery) Execute(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	offset := 0
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		offset = c.Offset
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	products, err := q.queryRepo.ListActiveSummaries(ctx, contract.ListProductsFilter{}, contract.Page{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+
+	now := q.ticker.Now()
+	resp := &BackfillResponse{Scanned: len(products), DryRun: true}
+	for _, p := range products {
+		effective, err := q.pricing.EffectivePrice(p.BasePrice(), p.Discount(), now)
+		if err != nil {
+			return nil, err
+		}
+		if !effective.Equals(p.BasePrice()) {
+			resp.Differing++
+		}
+	}
+	if len(products) == limit {
+		resp.NextCursor = encodeCursor(cursor{Offset: offset + limit})
+	}
+	return resp, nil
+}
+
+// cursor is the decoded form of the opaque resume token.
+type cursor struct {
+	Offset int `json:"o"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a token produced by encodeCursor; anything else yields
+// domain.ErrInvalidCursor.
+func decodeCursor(token string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Offset < 0 {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+	return c, nil
+}
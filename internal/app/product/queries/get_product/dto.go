@@ -6,12 +6,31 @@ import "time"
 type ProductDTO struct {
 	ID             string
 	Name           string
+	Slug           string // "" for products created before slugs
 	Description    string
 	Category       string
+	Categories     []string // every category the product is listed in, primary first
 	Status         string
 	BasePrice      MoneyDTO
 	EffectivePrice MoneyDTO
-	Discount       *DiscountDTO // nil when no active discount
+	Discount       *DiscountDTO // nil when no discount is stored or its state was not requested
+	Featured       bool
+	MediaURLs      []string // in display order
+	StockQuantity  int64
+	QuantityTiers  []QuantityTierDTO // ordered by minimum quantity
+	Attributes     map[string]string // nil when the product has none
+	// ConvertedCurrency is the currency prices were converted to on request;
+	// "" when they are in the product's stored currency.
+	ConvertedCurrency string
+	// PreviewAt is the upcoming discount start the product was priced at when
+	// PreviewAsScheduled was requested; nil for a regular, current price.
+	PreviewAt   *time.Time
+	PublishAt   *time.Time // when the inactive product is scheduled to go live; nil when unscheduled
+	UnpublishAt *time.Time // when the product is scheduled to be taken down; nil when unscheduled
+	// RoundingDelta is the exact computed effective price minus the rounded
+	// EffectivePrice.Amount, in minor units of its currency. Set only when
+	// the query runs with WithRoundingAudit.
+	RoundingDelta *float64 `json:",omitempty"`
 }
 
 // MoneyDTO is a flat representation of a monetary amount.
@@ -20,10 +39,28 @@ type MoneyDTO struct {
 	Currency string
 }
 
+// QuantityTierDTO describes a volume discount: Percentage off the unit price
+// when buying at least MinQuantity units.
+type QuantityTierDTO struct {
+	MinQuantity int64
+	Percentage  string
+}
+
 // DiscountDTO contains the discount details for a product.
 type DiscountDTO struct {
 	Percentage string
 	StartsAt   time.Time
 	EndsAt     time.Time
 	IsActive   bool
+	State      string  // "active", "upcoming" or "expired"
+	Progress   float64 // fraction of the discount window elapsed, from 0 to 1
+}
+
+// BatchGetProductsResult is the partial-success answer to a batch get: the
+// products that were found plus which of the requested IDs were not. Each ID
+// appears once, in request order, in whichever of the three it falls into.
+type BatchGetProductsResult struct {
+	Found    map[string]*ProductDTO `json:"found"`
+	NotFound []string               `json:"not_found"` // missing or archived
+	Invalid  []string               `json:"invalid"`   // not a well-formed product ID
 }
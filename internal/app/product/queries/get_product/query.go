@@ -2,9 +2,14 @@ package getproduct
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/product-catalog-service/common"
 	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
 	"github.com/product-catalog-service/internal/app/product/domain/services"
 )
 
@@ -14,53 +19,300 @@ type GetProductQuery struct {
 	queryRepo contract.QueryRepository
 	pricing   *services.PricingCalculator
 	ticker    common.Ticker
+	rates     contract.ExchangeRateProvider // nil = prices always in the stored currency
+	// roundingAudit reports RoundingDelta on every result.
+	roundingAudit bool
+}
+
+// Option customises a GetProductQuery.
+type Option func(*GetProductQuery)
+
+// WithExchangeRates converts prices into the currency requested through
+// common.WithCurrency when rates has a rate for it.
+func WithExchangeRates(rates contract.ExchangeRateProvider) Option {
+	return func(q *GetProductQuery) { q.rates = rates }
+}
+
+// WithRoundingAudit sets ProductDTO.RoundingDelta on every result, for
+// reconciling rounding drift in audit builds.
+func WithRoundingAudit() Option {
+	return func(q *GetProductQuery) { q.roundingAudit = true }
 }
 
-func NewGetProductQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *GetProductQuery {
-	return &GetProductQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+func NewGetProductQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker, opts ...Option) *GetProductQuery {
+	q := &GetProductQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
 }
 
 type GetProductRequest struct {
 	ProductID string
+	// IncludeDiscountStates limits which stored discounts are returned, by state.
+	// Empty means every stored discount is returned.
+	IncludeDiscountStates []string
+	// PreviewAsScheduled prices a product whose discount has not started yet
+	// as of the discount's start, e.g. to preview a sale's landing page. The
+	// result's PreviewAt is then set. Other products are priced as usual.
+	PreviewAsScheduled bool
+}
+
+// BatchGetProductsRequest lists the products to fetch at once. Empty IDs are
+// ignored and duplicates are loaded once.
+type BatchGetProductsRequest struct {
+	ProductIDs            []string
+	IncludeDiscountStates []string // as in GetProductRequest
+}
+
+// GetProductBySlugRequest addresses the product by its slug instead of its ID.
+type GetProductBySlugRequest struct {
+	Slug                  string
+	IncludeDiscountStates []string // as in GetProductRequest
+	PreviewAsScheduled    bool     // as in GetProductRequest
 }
 
 func (q *GetProductQuery) Execute(ctx context.Context, req *GetProductRequest) (*ProductDTO, error) {
-	product, err := q.queryRepo.GetByID(ctx, req.ProductID)
+	return q.execute(ctx, req.IncludeDiscountStates, req.PreviewAsScheduled, func() (*domain.Product, error) {
+		return q.queryRepo.GetByID(ctx, req.ProductID)
+	})
+}
+
+// ExecuteBySlug is Execute for a product looked up by slug, e.g. from a
+// storefront URL such as /p/wireless-keyboard.
+func (q *GetProductQuery) ExecuteBySlug(ctx context.Context, req *GetProductBySlugRequest) (*ProductDTO, error) {
+	return q.execute(ctx, req.IncludeDiscountStates, req.PreviewAsScheduled, func() (*domain.Product, error) {
+		return q.queryRepo.GetBySlug(ctx, req.Slug)
+	})
+}
+
+// ExecuteBatch loads req.ProductIDs in one read and returns the products keyed
+// by ID, for lookups. Missing and archived IDs are absent from the result.
+func (q *GetProductQuery) ExecuteBatch(ctx context.Context, req *BatchGetProductsRequest) (map[string]*ProductDTO, error) {
+	include, err := parseDiscountStates(req.IncludeDiscountStates)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.ProductIDs))
+	ids := make([]string, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	dtos := make(map[string]*ProductDTO, len(ids))
+	if len(ids) == 0 {
+		return dtos, nil
+	}
+
+	loaded, err := q.queryRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range loaded {
+		product := l.Snapshot()
+		if product.IsArchived() {
+			continue
+		}
+		dto, err := q.toDTO(ctx, product, include, false)
+		if err != nil {
+			return nil, err
+		}
+		dtos[product.ID()] = dto
+	}
+	return dtos, nil
+}
+
+// ExecuteBatchOrdered is ExecuteBatch with the results aligned to
+// req.ProductIDs, e.g. to render a wishlist in the shopper's order: element i
+// is the product for ProductIDs[i], or nil when that ID is missing or archived.
+func (q *GetProductQuery) ExecuteBatchOrdered(ctx context.Context, req *BatchGetProductsRequest) ([]*ProductDTO, error) {
+	byID, err := q.ExecuteBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ordered := make([]*ProductDTO, len(req.ProductIDs))
+	for i, id := range req.ProductIDs {
+		ordered[i] = byID[id]
+	}
+	return ordered, nil
+}
+
+// ExecuteBatchPartial is ExecuteBatch reporting which IDs did not resolve, so
+// callers can use the products that did: IDs that are not well-formed product
+// IDs are listed as Invalid without being looked up, and well-formed IDs with
+// no live product as NotFound.
+func (q *GetProductQuery) ExecuteBatchPartial(ctx context.Context, req *BatchGetProductsRequest) (*BatchGetProductsResult, error) {
+	res := &BatchGetProductsResult{NotFound: []string{}, Invalid: []string{}}
+	seen := make(map[string]bool, len(req.ProductIDs))
+	valid := make([]string, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if uuid.Validate(id) != nil {
+			res.Invalid = append(res.Invalid, id)
+			continue
+		}
+		valid = append(valid, id)
+	}
+
+	found, err := q.ExecuteBatch(ctx, &BatchGetProductsRequest{
+		ProductIDs:            valid,
+		IncludeDiscountStates: req.IncludeDiscountStates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Found = found
+	for _, id := range valid {
+		if found[id] == nil {
+			res.NotFound = append(res.NotFound, id)
+		}
+	}
+	return res, nil
+}
+
+func (q *GetProductQuery) execute(ctx context.Context, includeStates []string, previewAsScheduled bool, load func() (*domain.Product, error)) (*ProductDTO, error) {
+	include, err := parseDiscountStates(includeStates)
 	if err != nil {
 		return nil, err
 	}
 
-	now := q.ticker.Now()
+	loaded, err := load()
+	if err != nil {
+		return nil, err
+	}
+	product := loaded.Snapshot()
+	// Archived products did exist; report them distinctly from unknown IDs.
+	if product.IsArchived() {
+		return nil, domain.ErrProductArchived
+	}
+	return q.toDTO(ctx, product, include, previewAsScheduled)
+}
+
+// parseDiscountStates converts the requested discount states into a set; an
+// empty set means every state.
+func parseDiscountStates(states []string) (map[domain.DiscountState]bool, error) {
+	include := make(map[domain.DiscountState]bool, len(states))
+	for _, s := range states {
+		state, err := domain.ParseDiscountState(s)
+		if err != nil {
+			return nil, err
+		}
+		include[state] = true
+	}
+	return include, nil
+}
+
+// toDTO prices product as of now, or as of its upcoming discount's start when
+// previewAsScheduled, and maps it to its DTO.
+func (q *GetProductQuery) toDTO(ctx context.Context, product *domain.Product, include map[domain.DiscountState]bool, previewAsScheduled bool) (*ProductDTO, error) {
+	now := common.NowFromContext(ctx, q.ticker)
+	var previewAt *time.Time
+	if d := product.Discount(); previewAsScheduled && d != nil && d.StateAt(now) == domain.DiscountStateUpcoming {
+		start := d.StartsAt()
+		previewAt, now = &start, start
+	}
 
 	effective, err := q.pricing.EffectivePrice(product.BasePrice(), product.Discount(), now)
 	if err != nil {
 		return nil, err
 	}
+	base, effective, converted, rate, err := q.inRequestedCurrency(ctx, product.BasePrice(), effective)
+	if err != nil {
+		return nil, err
+	}
+	var roundingDelta *float64
+	if q.roundingAudit {
+		exact, err := q.pricing.ExactEffectivePrice(product.BasePrice(), product.Discount(), now)
+		if err != nil {
+			return nil, err
+		}
+		if converted != "" {
+			exact *= domain.ConversionFactor(product.BasePrice().Currency(), converted, rate)
+		}
+		delta := exact - float64(effective.Amount())
+		roundingDelta = &delta
+	}
 
 	dto := &ProductDTO{
-		ID:          product.ID(),
-		Name:        product.Name(),
-		Description: product.Description(),
-		Category:    product.Category(),
-		Status:      string(product.Status()),
+		ID:            product.ID(),
+		Name:          product.Name(),
+		Slug:          product.Slug(),
+		Description:   product.Description(),
+		Category:      product.Category(),
+		Categories:    product.Categories(),
+		Status:        string(product.Status()),
+		Featured:      product.IsFeatured(),
+		MediaURLs:     product.MediaURLs(),
+		StockQuantity: product.StockQuantity(),
+		QuantityTiers: toQuantityTierDTOs(product.QuantityTiers()),
+		Attributes:    product.Attributes(),
 		BasePrice: MoneyDTO{
-			Amount:   product.BasePrice().Amount(),
-			Currency: product.BasePrice().Currency(),
+			Amount:   base.Amount(),
+			Currency: base.Currency(),
 		},
 		EffectivePrice: MoneyDTO{
 			Amount:   effective.Amount(),
 			Currency: effective.Currency(),
 		},
+		ConvertedCurrency: converted,
+		PreviewAt:         previewAt,
+		PublishAt:         product.PublishAt(),
+		UnpublishAt:       product.UnpublishAt(),
+		RoundingDelta:     roundingDelta,
 	}
 
 	if d := product.Discount(); d != nil {
-		dto.Discount = &DiscountDTO{
-			Percentage: d.Percentage(),
-			StartsAt:   d.StartsAt(),
-			EndsAt:     d.EndsAt(),
-			IsActive:   d.IsValidAt(now),
+		state := d.StateAt(now)
+		if len(include) == 0 || include[state] {
+			dto.Discount = &DiscountDTO{
+				Percentage: d.Percentage(),
+				StartsAt:   d.StartsAt(),
+				EndsAt:     d.EndsAt(),
+				IsActive:   state == domain.DiscountStateActive && q.pricing.IsDiscounted(d, now),
+				State:      string(state),
+				Progress:   d.Progress(now),
+			}
 		}
 	}
 
 	return dto, nil
 }
+
+// inRequestedCurrency converts base and effective into the currency asked for
+// in ctx, returning that currency and the rate used, or "" when the prices
+// stay as stored because none was asked for or no rate is known.
+func (q *GetProductQuery) inRequestedCurrency(ctx context.Context, base, effective *domain.Money) (*domain.Money, *domain.Money, string, float64, error) {
+	to := common.CurrencyFromContext(ctx)
+	if q.rates == nil || to == "" || to == base.Currency() {
+		return base, effective, "", 1, nil
+	}
+	rate, err := q.rates.Rate(ctx, base.Currency(), to)
+	if errors.Is(err, domain.ErrUnsupportedCurrency) {
+		return base, effective, "", 1, nil
+	}
+	if err != nil {
+		return nil, nil, "", 0, err
+	}
+	if base, err = base.Convert(to, rate); err != nil {
+		return nil, nil, "", 0, err
+	}
+	if effective, err = effective.Convert(to, rate); err != nil {
+		return nil, nil, "", 0, err
+	}
+	return base, effective, to, rate, nil
+}
+
+func toQuantityTierDTOs(tiers []domain.QuantityTier) []QuantityTierDTO {
+	dtos := make([]QuantityTierDTO, 0, len(tiers))
+	for _, t := range tiers {
+		dtos = append(dtos, QuantityTierDTO{MinQuantity: t.MinQuantity(), Percentage: t.Percentage()})
+	}
+	return dtos
+}
@@ -0,0 +1,106 @@
+package listproducts
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/product-catalog-service/internal/app/product/contract"
+)
+
+// countCache memoizes CountActive results per filter for a short TTL.
+//
+// Caveats: the cache is per process and is not invalidated by writes, so a
+// cached total may lag the table by up to the TTL and differ between
+// replicas. Clients that need an exact figure should request a refresh.
+// Expired entries are dropped whenever a count is stored.
+type countCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]countEntry
+}
+
+type countEntry struct {
+	count     int
+	expiresAt time.Time
+}
+
+func newCountCache(ttl time.Duration) *countCache {
+	return &countCache{ttl: ttl, entries: make(map[string]countEntry)}
+}
+
+// get returns the cached count for filter, calling load on a miss, an expired
+// entry, or when refresh is set. A non-positive TTL disables caching.
+func (c *countCache) get(ctx context.Context, filter contract.ListProductsFilter, now time.Time, refresh bool,
+	load func(context.Context, contract.ListProductsFilter) (int, error)) (int, error) {
+	if c.ttl <= 0 {
+		return load(ctx, filter)
+	}
+
+	key := filterKey(filter)
+	if !refresh {
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && now.Before(e.expiresAt) {
+			return e.count, nil
+		}
+	}
+
+	count, err := load(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = countEntry{count: count, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return count, nil
+}
+
+// filterKey renders filter as a canonical string; status order does not matter.
+// OnSaleAt is keyed as a flag: it is always the request's now, and a total
+// cached for the TTL may lag sales starting or ending like any other write.
+func filterKey(f contract.ListProductsFilter) string {
+	statuses := make([]string, 0, len(f.Statuses))
+	for _, s := range f.Statuses {
+		statuses = append(statuses, string(s))
+	}
+	slices.Sort(statuses)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "s=%s", strings.Join(statuses, ","))
+	if len(f.Categories) > 0 {
+		categories := slices.Sorted(slices.Values(f.Categories))
+		fmt.Fprintf(&b, "|cs=%s", strings.Join(categories, ","))
+	} else if f.Category != nil {
+		fmt.Fprintf(&b, "|c=%s", *f.Category)
+	}
+	if f.Featured != nil {
+		fmt.Fprintf(&b, "|f=%t", *f.Featured)
+	}
+	if f.InStockOnly {
+		b.WriteString("|stock")
+	}
+	if f.Attribute != nil {
+		fmt.Fprintf(&b, "|attr=%q:%q", f.Attribute.Key, f.Attribute.Value)
+	}
+	if f.OnSaleAt != nil {
+		b.WriteString("|sale")
+	}
+	if f.CreatedAfter != nil {
+		fmt.Fprintf(&b, "|a=%d", f.CreatedAfter.UnixNano())
+	}
+	if f.CreatedBefore != nil {
+		fmt.Fprintf(&b, "|b=%d", f.CreatedBefore.UnixNano())
+	}
+	return b.String()
+}
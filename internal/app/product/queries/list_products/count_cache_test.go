@@ -0,0 +1,31 @@
+package listproducts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/product-catalog-service/internal/app/product/contract"
+)
+
+func TestCountCache_DropsExpiredEntriesOnStore(t *testing.T) {
+	c := newCountCache(time.Minute)
+	load := func(context.Context, contract.ListProductsFilter) (int, error) { return 1, nil }
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Distinct created-after bounds give distinct keys, as clients paging by
+	// "created in the last hour" do.
+	for i := range 5 {
+		after := now.Add(-time.Duration(i) * time.Second)
+		if _, err := c.get(context.Background(), contract.ListProductsFilter{CreatedAfter: &after}, now, false, load); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	}
+	if _, err := c.get(context.Background(), contract.ListProductsFilter{}, now.Add(2*time.Minute), false, load); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	if len(c.entries) != 1 {
+		t.Fatalf("expected only the fresh entry to remain, got %d", len(c.entries))
+	}
+}
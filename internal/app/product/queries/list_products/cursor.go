@@ -0,0 +1,63 @@
+package listproducts
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// defaultSortKey identifies the ordering used when the request does not ask for one:
+// featured products first, then by product_id.
+const defaultSortKey = "featured,product_id"
+
+// discountSortKey identifies the SortByDiscount ordering: current discount
+// percentage descending, ties kept in the default order.
+const discountSortKey = "discount,featured,product_id"
+
+// sortKeyFor maps a request's SortBy to the key its cursors are issued for.
+func sortKeyFor(sortBy string) (string, error) {
+	switch sortBy {
+	case "":
+		return defaultSortKey, nil
+	case SortByDiscount:
+		return discountSortKey, nil
+	default:
+		return "", domain.ErrInvalidSortOrder
+	}
+}
+
+// cursor is the decoded form of the opaque pagination token handed to clients.
+// Sort ties the token to the ordering it was issued for, so it cannot be replayed
+// against a differently-sorted listing.
+type cursor struct {
+	Sort   string `json:"s"`
+	Offset int    `json:"o"`
+}
+
+// encodeCursor serialises c as URL-safe base64 JSON.
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a token produced by encodeCursor and checks it was issued
+// for sortKey. Any malformed or mismatched token yields domain.ErrInvalidCursor.
+func decodeCursor(token, sortKey string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+	if c.Sort == "" || c.Offset < 0 {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+	if c.Sort != sortKey {
+		return cursor{}, domain.ErrInvalidCursor
+	}
+	return c, nil
+}
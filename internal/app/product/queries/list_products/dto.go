@@ -1,6 +1,11 @@
 package listproducts
 
-import "time"
+import (
+	"time"
+
+	"github.com/product-catalog-service/common/pagination"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
 
 // ProductSummaryDTO is the lightweight read model returned by the ListProducts query.
 // It intentionally omits heavy fields (e.g. Description) to keep list responses compact.
@@ -13,6 +18,11 @@ type ProductSummaryDTO struct {
 	EffectivePrice MoneyDTO
 	IsDiscounted   bool
 	DiscountEndsAt *time.Time // nil when no active discount
+	Featured       bool
+	StockQuantity  int64
+	// ConvertedCurrency is the currency prices were converted to on request;
+	// "" when they are in the product's stored currency.
+	ConvertedCurrency string
 }
 
 // MoneyDTO is a flat representation of a monetary amount.
@@ -21,15 +31,44 @@ type MoneyDTO struct {
 	Currency string
 }
 
+func (m MoneyDTO) convert(currency string, rate float64) (MoneyDTO, error) {
+	money, err := domain.NewMoney(m.Amount, m.Currency)
+	if err != nil {
+		return MoneyDTO{}, err
+	}
+	converted, err := money.Convert(currency, rate)
+	if err != nil {
+		return MoneyDTO{}, err
+	}
+	return MoneyDTO{Amount: converted.Amount(), Currency: converted.Currency()}, nil
+}
+
 // ListProductsRequest carries pagination and filter parameters.
 type ListProductsRequest struct {
-	Category *string // nil = all categories
-	Limit    int     // max items per page; defaults to 20
-	Offset   int     // 0-based offset for pagination
+	Statuses []string // empty = active only
+	Category *string  // nil = all categories
+	// IncludeSubcategories widens Category to its whole subtree in the taxonomy.
+	IncludeSubcategories bool
+	CreatedAfter         *time.Time // only products created at or after this time; nil = no bound
+	CreatedBefore        *time.Time // only products created before this time; nil = no bound
+	Featured             *bool      // true = featured only, false = non-featured only; nil = all
+	InStockOnly          bool       // only products with stock on hand
+	AttributeKey         string     // with AttributeValue, only products whose attribute matches; "" = no filter
+	AttributeValue       string     // required when AttributeKey is set
+	OnSale               bool       // only products whose discount is valid now, not upcoming or expired
+	Limit                int        // max items per page; 0 = configured default
+	Offset               int        // 0-based offset for pagination; ignored when Cursor is set
+	Cursor               string     // opaque token from a previous NextCursor; "" = start from Offset
+	IncludeTotal         bool       // compute TotalCount across all pages (may be cached briefly)
+	RefreshTotal         bool       // bypass the total-count cache; only used with IncludeTotal
+	SortBy               string     // "" = featured first; SortByDiscount = deepest current discount first
 }
 
-// ListProductsResponse wraps the result slice.
-type ListProductsResponse struct {
-	Items      []*ProductSummaryDTO
-	TotalCount int // total matching rows (for pagination UI)
-}
+// SortByDiscount orders products by their currently valid discount percentage,
+// highest first, with undiscounted products last.
+const SortByDiscount = "discount"
+
+// ListProductsResponse is a page of product summaries. TotalCount covers
+// all pages only when IncludeTotal is set; NextCursor is "" when this page
+// was not full.
+type ListProductsResponse = pagination.Page[*ProductSummaryDTO]
@@ -2,44 +2,189 @@ package listproducts
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/product-catalog-service/common"
-	"github.com/product-catalog-service/internal/app/product/domain/services"
+	"github.com/product-catalog-service/common/pagination"
 	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
 )
 
-const defaultLimit = 20
+// Config holds the pagination limits applied by ListProductsQuery.
+type Config struct {
+	DefaultLimit int // used when the request does not specify a positive limit
+	MaxLimit     int // upper bound on the page size; requests above it are clamped
+	// TotalCountTTL is how long a requested total count is cached per filter;
+	// 0 disables caching.
+	TotalCountTTL time.Duration
+}
+
+// DefaultConfig returns the built-in pagination limits.
+func DefaultConfig() Config {
+	return Config{DefaultLimit: 20, MaxLimit: 100, TotalCountTTL: 10 * time.Second}
+}
 
 // ListProductsQuery lists active products with optional category filter and pagination.
 // It uses the PricingCalculator to compute the effective price for each product.
 type ListProductsQuery struct {
-	queryRepo contract.QueryRepository
-	pricing   *services.PricingCalculator
-	ticker    common.Ticker
+	queryRepo  contract.QueryRepository
+	categories contract.CategoryRepository
+	pricing    *services.PricingCalculator
+	ticker     common.Ticker
+	cfg        Config
+	counts     *countCache
+	readModel  contract.ReadModelRepository  // nil = always compute prices per request
+	rates      contract.ExchangeRateProvider // nil = prices always in the stored currency
+}
+
+// Option customises a ListProductsQuery.
+type Option func(*ListProductsQuery)
+
+// WithReadModel serves listings in the default order from the projected read
+// model instead of pricing every product per request. Other orderings, pinned
+// request times and the discounts-disabled switch still use the computed path.
+func WithReadModel(readModel contract.ReadModelRepository) Option {
+	return func(q *ListProductsQuery) { q.readModel = readModel }
+}
+
+// WithExchangeRates converts prices into the currency requested through
+// common.WithCurrency when rates has a rate for it.
+func WithExchangeRates(rates contract.ExchangeRateProvider) Option {
+	return func(q *ListProductsQuery) { q.rates = rates }
 }
 
-func NewListProductsQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *ListProductsQuery {
-	return &ListProductsQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+func NewListProductsQuery(queryRepo contract.QueryRepository, categories contract.CategoryRepository, pricing *services.PricingCalculator, ticker common.Ticker, cfg Config, opts ...Option) *ListProductsQuery {
+	q := &ListProductsQuery{
+		queryRepo:  queryRepo,
+		categories: categories,
+		pricing:    pricing,
+		ticker:     ticker,
+		cfg:        cfg,
+		counts:     newCountCache(cfg.TotalCountTTL),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
 }
 
 func (q *ListProductsQuery) Execute(ctx context.Context, req *ListProductsRequest) (*ListProductsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, domain.ErrInvalidPagination
+	}
+
 	limit := req.Limit
 	if limit <= 0 {
-		limit = defaultLimit
+		limit = q.cfg.DefaultLimit
+	}
+	if q.cfg.MaxLimit > 0 && limit > q.cfg.MaxLimit {
+		limit = q.cfg.MaxLimit
+	}
+
+	sortKey, err := sortKeyFor(req.SortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := req.Offset
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor, sortKey)
+		if err != nil {
+			return nil, err
+		}
+		offset = c.Offset
+	}
+
+	statuses := make([]domain.ProductStatus, 0, len(req.Statuses))
+	for _, s := range req.Statuses {
+		status, err := domain.ParseProductStatus(s)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	attribute, err := attributeMatch(req.AttributeKey, req.AttributeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := contract.ListProductsFilter{
+		Statuses:      statuses,
+		Category:      req.Category,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		Featured:      req.Featured,
+		InStockOnly:   req.InStockOnly,
+		Attribute:     attribute,
+	}
+	if req.IncludeSubcategories && req.Category != nil {
+		subtree, err := q.categorySubtree(ctx, *req.Category)
+		if err != nil {
+			return nil, err
+		}
+		filter.Categories = subtree
+	}
+
+	now := common.NowFromContext(ctx, q.ticker)
+	page := contract.Page{Limit: limit, Offset: offset}
+
+	if req.OnSale {
+		// With discounts switched off nothing sells at a discount.
+		if q.pricing.DiscountsDisabled() {
+			return pagination.NewPage([]*ProductSummaryDTO{}, ""), nil
+		}
+		filter.OnSaleAt = &now
+	}
+
+	var items []*ProductSummaryDTO
+	if q.readModel != nil && sortKey == defaultSortKey && !common.HasNow(ctx) && !q.pricing.DiscountsDisabled() && filter.OnSaleAt == nil {
+		items, err = q.listFromReadModel(ctx, filter, page)
+	} else {
+		items, err = q.listComputed(ctx, filter, page, sortKey, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := q.convertPrices(ctx, items); err != nil {
+		return nil, err
+	}
+
+	var next string
+	if len(items) == limit {
+		next = encodeCursor(cursor{Sort: sortKey, Offset: offset + limit})
 	}
+	resp := pagination.NewPage(items, next)
+	if req.IncludeTotal {
+		resp.TotalCount, err = q.counts.get(ctx, filter, now, req.RefreshTotal, q.queryRepo.CountActive)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
 
-	products, err := q.queryRepo.ListActive(ctx,
-		contract.ListProductsFilter{Category: req.Category},
-		contract.Page{Limit: limit, Offset: req.Offset},
-	)
+// listComputed loads one page of products and prices each of them at now.
+func (q *ListProductsQuery) listComputed(ctx context.Context, filter contract.ListProductsFilter, page contract.Page, sortKey string, now time.Time) ([]*ProductSummaryDTO, error) {
+	var products []*domain.Product
+	var err error
+	// With discounts switched off every product counts as undiscounted, which
+	// leaves the default order.
+	if sortKey == discountSortKey && !q.pricing.DiscountsDisabled() {
+		products, err = q.queryRepo.ListActiveSummariesByDiscount(ctx, filter, now, page)
+	} else {
+		products, err = q.queryRepo.ListActiveSummaries(ctx, filter, page)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	now := q.ticker.Now()
 	items := make([]*ProductSummaryDTO, 0, len(products))
 
 	for _, p := range products {
+		p = p.Snapshot()
 		effective, err := q.pricing.EffectivePrice(p.BasePrice(), p.Discount(), now)
 		if err != nil {
 			return nil, err
@@ -58,19 +203,113 @@ func (q *ListProductsQuery) Execute(ctx context.Context, req *ListProductsReques
 				Amount:   effective.Amount(),
 				Currency: effective.Currency(),
 			},
-			IsDiscounted: q.pricing.IsDiscounted(p.Discount(), now),
+			IsDiscounted:  q.pricing.IsDiscounted(p.Discount(), now),
+			Featured:      p.IsFeatured(),
+			StockQuantity: p.StockQuantity(),
 		}
 
-		if d := p.Discount(); d != nil && d.IsValidAt(now) {
-			endsAt := d.EndsAt()
+		if summary.IsDiscounted {
+			endsAt := p.Discount().EndsAt()
 			summary.DiscountEndsAt = &endsAt
 		}
 
 		items = append(items, summary)
 	}
+	return items, nil
+}
+
+// listFromReadModel serves one page of precomputed listings. Prices are as
+// fresh as the projector's last event or sweep.
+func (q *ListProductsQuery) listFromReadModel(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*ProductSummaryDTO, error) {
+	listings, err := q.readModel.ListListings(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*ProductSummaryDTO, 0, len(listings))
+	for _, l := range listings {
+		items = append(items, &ProductSummaryDTO{
+			ID:       l.ProductID,
+			Name:     l.Name,
+			Category: l.Category,
+			Status:   string(l.Status),
+			BasePrice: MoneyDTO{
+				Amount:   l.BasePrice.Amount(),
+				Currency: l.BasePrice.Currency(),
+			},
+			EffectivePrice: MoneyDTO{
+				Amount:   l.EffectivePrice.Amount(),
+				Currency: l.EffectivePrice.Currency(),
+			},
+			IsDiscounted:   l.IsDiscounted,
+			DiscountEndsAt: l.DiscountEndsAt,
+			Featured:       l.Featured,
+			StockQuantity:  l.StockQuantity,
+		})
+	}
+	return items, nil
+}
+
+// convertPrices converts each item's prices into the currency asked for in
+// ctx. Items whose currency has no rate keep their stored prices.
+func (q *ListProductsQuery) convertPrices(ctx context.Context, items []*ProductSummaryDTO) error {
+	to := common.CurrencyFromContext(ctx)
+	if q.rates == nil || to == "" {
+		return nil
+	}
+
+	rates := make(map[string]float64) // by source currency; 0 = unsupported
+	for _, item := range items {
+		from := item.BasePrice.Currency
+		if from == to {
+			continue
+		}
+		rate, seen := rates[from]
+		if !seen {
+			var err error
+			rate, err = q.rates.Rate(ctx, from, to)
+			if err != nil && !errors.Is(err, domain.ErrUnsupportedCurrency) {
+				return err
+			}
+			rates[from] = rate
+		}
+		if rate == 0 {
+			continue
+		}
 
-	return &ListProductsResponse{
-		Items:      items,
-		TotalCount: len(items), // Note: replace with a real COUNT query when DB is wired
-	}, nil
+		base, err := item.BasePrice.convert(to, rate)
+		if err != nil {
+			return err
+		}
+		effective, err := item.EffectivePrice.convert(to, rate)
+		if err != nil {
+			return err
+		}
+		item.BasePrice, item.EffectivePrice, item.ConvertedCurrency = base, effective, to
+	}
+	return nil
+}
+
+// categorySubtree resolves id and all of its descendant category IDs.
+func (q *ListProductsQuery) categorySubtree(ctx context.Context, id string) ([]string, error) {
+	all, err := q.categories.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewTaxonomy(all).Subtree(id)
+}
+
+// attributeMatch builds the attribute filter; key and value must be given
+// together, and key must be a valid attribute key.
+func attributeMatch(key, value string) (*contract.AttributeMatch, error) {
+	if key == "" && value == "" {
+		return nil, nil
+	}
+	if key == "" || value == "" {
+		return nil, domain.ErrIncompleteAttribute
+	}
+	if err := domain.ValidateAttributeKey(key); err != nil {
+		return nil, err
+	}
+	return &contract.AttributeMatch{Key: key, Value: value}, nil
 }
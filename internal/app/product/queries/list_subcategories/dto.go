@@ -0,0 +1,18 @@
+package listsubcategories
+
+// CategoryDTO is the read model for a taxonomy node.
+type CategoryDTO struct {
+	ID       string
+	Name     string
+	ParentID string // "" for root categories
+}
+
+// ListSubcategoriesRequest names the category whose subtree is listed.
+type ListSubcategoriesRequest struct {
+	CategoryID string
+}
+
+// ListSubcategoriesResponse wraps the descendants, nearest first.
+type ListSubcategoriesResponse struct {
+	Items []*CategoryDTO
+}
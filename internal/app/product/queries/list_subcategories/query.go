@@ -0,0 +1,38 @@
+package listsubcategories
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// ListSubcategoriesQuery lists every descendant of a category in the taxonomy.
+type ListSubcategoriesQuery struct {
+	categories contract.CategoryRepository
+}
+
+func NewListSubcategoriesQuery(categories contract.CategoryRepository) *ListSubcategoriesQuery {
+	return &ListSubcategoriesQuery{categories: categories}
+}
+
+func (q *ListSubcategoriesQuery) Execute(ctx context.Context, req *ListSubcategoriesRequest) (*ListSubcategoriesResponse, error) {
+	if req.CategoryID == "" {
+		return nil, domain.ErrCategoryIDRequired
+	}
+
+	all, err := q.categories.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := domain.NewTaxonomy(all).Descendants(req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*CategoryDTO, 0, len(descendants))
+	for _, c := range descendants {
+		items = append(items, &CategoryDTO{ID: c.ID(), Name: c.Name(), ParentID: c.ParentID()})
+	}
+	return &ListSubcategoriesResponse{Items: items}, nil
+}
@@ -0,0 +1,32 @@
+package pricepreview
+
+// PricePreviewRequest asks what buying Quantity units of a product would cost.
+type PricePreviewRequest struct {
+	ProductID string
+	Quantity  int64 // must be at least 1
+}
+
+// PricePreviewDTO is the price of Quantity units at the time of the request.
+// The time-windowed discount and quantity tiers do not stack: the lower unit
+// price wins.
+type PricePreviewDTO struct {
+	ProductID   string
+	Quantity    int64
+	BasePrice   MoneyDTO // undiscounted unit price
+	UnitPrice   MoneyDTO
+	TotalPrice  MoneyDTO         // UnitPrice × Quantity
+	AppliedTier *QuantityTierDTO // nil when no quantity tier won
+	Discounted  bool             // the time-windowed discount won
+}
+
+// MoneyDTO is a flat representation of a monetary amount.
+type MoneyDTO struct {
+	Amount   int64
+	Currency string
+}
+
+// QuantityTierDTO describes a volume discount.
+type QuantityTierDTO struct {
+	MinQuantity int64
+	Percentage  string
+}
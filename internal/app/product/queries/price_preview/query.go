@@ -0,0 +1,74 @@
+package pricepreview
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+)
+
+// PricePreviewQuery prices a quantity of one product, applying the better of
+// its current discount and its best quantity tier.
+type PricePreviewQuery struct {
+	queryRepo contract.QueryRepository
+	pricing   *services.PricingCalculator
+	ticker    common.Ticker
+}
+
+func NewPricePreviewQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *PricePreviewQuery {
+	return &PricePreviewQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+}
+
+func (q *PricePreviewQuery) Execute(ctx context.Context, req *PricePreviewRequest) (*PricePreviewDTO, error) {
+	if req.Quantity < 1 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	product, err := q.queryRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsArchived() {
+		return nil, domain.ErrProductArchived
+	}
+
+	now := common.NowFromContext(ctx, q.ticker)
+	base := product.BasePrice()
+
+	discounted, err := q.pricing.EffectivePrice(base, product.Discount(), now)
+	if err != nil {
+		return nil, err
+	}
+	tiered, err := q.pricing.EffectiveUnitPrice(base, product.QuantityTiers(), req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &PricePreviewDTO{
+		ProductID: product.ID(),
+		Quantity:  req.Quantity,
+		BasePrice: toMoneyDTO(base),
+	}
+	unit := discounted
+	if tiered.Amount() < discounted.Amount() {
+		unit = tiered
+		tier, _ := q.pricing.BestQuantityTier(product.QuantityTiers(), req.Quantity)
+		dto.AppliedTier = &QuantityTierDTO{MinQuantity: tier.MinQuantity(), Percentage: tier.Percentage()}
+	} else {
+		dto.Discounted = q.pricing.IsDiscounted(product.Discount(), now)
+	}
+
+	total, err := unit.Multiply(float64(req.Quantity))
+	if err != nil {
+		return nil, err
+	}
+	dto.UnitPrice = toMoneyDTO(unit)
+	dto.TotalPrice = toMoneyDTO(total)
+	return dto, nil
+}
+
+func toMoneyDTO(m *domain.Money) MoneyDTO {
+	return MoneyDTO{Amount: m.Amount(), Currency: m.Currency()}
+}
@@ -0,0 +1,23 @@
+package pricestats
+
+import "github.com/product-catalog-service/internal/app/product/queries/analytics"
+
+// CategoryPriceStatsDTO summarises the effective prices of the active products
+// in one category. Products priced in different currencies are reported in
+// separate entries.
+type CategoryPriceStatsDTO struct {
+	Category string             `json:"category"`
+	Currency string             `json:"currency"`
+	Count    int                `json:"count"`
+	Min      analytics.MoneyDTO `json:"min"`
+	Max      analytics.MoneyDTO `json:"max"`
+	Avg      analytics.MoneyDTO `json:"avg"` // rounded half-up to the smallest currency unit
+}
+
+// PriceStatsResponse lists the statistics ordered by category, then currency.
+type PriceStatsResponse struct {
+	Items []*CategoryPriceStatsDTO `json:"items"`
+	// Truncated reports that the scan stopped early to answer within the
+	// request's deadline, so the statistics cover only part of the catalog.
+	Truncated bool `json:"truncated,omitempty"`
+}
@@ -0,0 +1,136 @@
+package pricestats
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+	"github.com/product-catalog-service/internal/app/product/queries/analytics"
+)
+
+// pageSize is the number of products read per repository call.
+const pageSize = 500
+
+// PriceStatsQuery computes min/avg/max effective prices per category over all
+// active products.
+//
+// Discount validity depends on the current time, so effective prices are
+// computed in Go with the pricing calculator after fetching rather than
+// approximated in SQL from base prices.
+type PriceStatsQuery struct {
+	queryRepo contract.QueryRepository
+	pricing   *services.PricingCalculator
+	ticker    common.Ticker
+}
+
+func NewPriceStatsQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker) *PriceStatsQuery {
+	return &PriceStatsQuery{queryRepo: queryRepo, pricing: pricing, ticker: ticker}
+}
+
+type statsKey struct {
+	category string
+	currency string
+}
+
+type accumulator struct {
+	dto *CategoryPriceStatsDTO
+	sum *domain.Money
+}
+
+// Execute scans every active product. When ctx has a deadline, the scan stops
+// before a page it is unlikely to finish in time, and the response is marked
+// Truncated instead of failing.
+func (q *PriceStatsQuery) Execute(ctx context.Context) (*PriceStatsResponse, error) {
+	now := common.NowFromContext(ctx, q.ticker)
+	stats := make(map[statsKey]*accumulator)
+	truncated := false
+
+	// Offset pages follow the repository's stable ORDER BY; products written
+	// while the scan runs may be counted twice or missed.
+	var slowest time.Duration // longest page read so far, the estimate for the next
+	for offset := 0; ; offset += pageSize {
+		if offset > 0 && !timeLeft(ctx, slowest) {
+			truncated = true
+			break
+		}
+		start := time.Now()
+		products, err := q.queryRepo.ListActiveSummaries(ctx, contract.ListProductsFilter{}, contract.Page{Limit: pageSize, Offset: offset})
+		if offset > 0 && errors.Is(err, context.DeadlineExceeded) {
+			truncated = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		slowest = max(slowest, time.Since(start))
+		for _, p := range products {
+			effective, err := q.pricing.EffectivePrice(p.BasePrice(), p.Discount(), now)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(stats, p.Category(), effective); err != nil {
+				return nil, err
+			}
+		}
+		if len(products) < pageSize {
+			break
+		}
+	}
+
+	items := make([]*CategoryPriceStatsDTO, 0, len(stats))
+	for _, acc := range stats {
+		n := int64(acc.dto.Count)
+		acc.dto.Avg = analytics.MoneyDTO{Amount: (acc.sum.Amount() + n/2) / n, Currency: acc.dto.Currency}
+		items = append(items, acc.dto)
+	}
+	slices.SortFunc(items, func(a, b *CategoryPriceStatsDTO) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Currency, b.Currency))
+	})
+	return &PriceStatsResponse{Items: items, Truncated: truncated}, nil
+}
+
+// timeLeft reports whether ctx leaves at least need before its deadline;
+// without a deadline there is always time.
+func timeLeft(ctx context.Context, need time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) >= need
+}
+
+// add folds price into the statistics for category.
+func add(stats map[statsKey]*accumulator, category string, price *domain.Money) error {
+	key := statsKey{category: category, currency: price.Currency()}
+	acc, ok := stats[key]
+	if !ok {
+		stats[key] = &accumulator{
+			dto: &CategoryPriceStatsDTO{
+				Category: category,
+				Currency: price.Currency(),
+				Count:    1,
+				Min:      analytics.NewMoneyDTO(price),
+				Max:      analytics.NewMoneyDTO(price),
+			},
+			sum: price,
+		}
+		return nil
+	}
+
+	sum, err := acc.sum.Add(price)
+	if err != nil {
+		return err
+	}
+	acc.sum = sum
+	acc.dto.Count++
+	if price.Amount() < acc.dto.Min.Amount {
+		acc.dto.Min = analytics.NewMoneyDTO(price)
+	}
+	if price.Amount() > acc.dto.Max.Amount {
+		acc.dto.Max = analytics.NewMoneyDTO(price)
+	}
+	return nil
+}
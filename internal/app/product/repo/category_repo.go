@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/models/m_category"
+)
+
+type CategoryRepo struct {
+	db *spanner.Client
+}
+
+func NewCategoryRepo(db *spanner.Client) *CategoryRepo {
+	return &CategoryRepo{db: db}
+}
+
+// ListAll loads the whole taxonomy. It is small enough to resolve subtrees in memory.
+func (r *CategoryRepo) ListAll(ctx context.Context) ([]*domain.Category, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + m_category.CategoryID + `, ` + m_category.Name + `, ` + m_category.ParentID +
+			` FROM ` + m_category.Table,
+	}
+
+	var categories []*domain.Category
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var cr m_category.CategoryRow
+		if err := row.ToStruct(&cr); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+		c, err := cr.ToDomain()
+		if err != nil {
+			return err
+		}
+		categories = append(categories, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListAll: %w", err)
+	}
+	return categories, nil
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/google/uuid"
@@ -19,27 +20,28 @@ func NewEventRepo() *EventRepo {
 }
 
 // InsertMut converts a DomainEvent into an outbox_events INSERT mutation.
-// Returns nil when the event payload cannot be serialised (should never happen in practice).
-func (r *EventRepo) InsertMut(event domain.DomainEvent) *spanner.Mutation {
+// It fails when the event payload cannot be serialised, e.g. for an event type
+// marshalPayload does not know.
+func (r *EventRepo) InsertMut(event domain.DomainEvent) (*spanner.Mutation, error) {
 	aggregateID := aggregateIDOf(event)
 
 	payload, err := marshalPayload(event)
 	if err != nil {
-		// Payload serialisation failure is a programming error; surface as nil
-		// so callers can decide whether to skip or fail hard.
-		return nil
+		return nil, fmt.Errorf("outbox event %s: %w", event.EventName(), err)
 	}
 
 	row := map[string]any{
 		m_outbox.EventID:     uuid.NewString(),
 		m_outbox.EventType:   event.EventName(),
 		m_outbox.AggregateID: aggregateID,
+		m_outbox.Sequence:    event.Sequence(),
 		m_outbox.Payload:     payload,
 		m_outbox.Status:      m_outbox.StatusPending,
+		m_outbox.Attempts:    int64(0),
 		m_outbox.CreatedAt:   spanner.CommitTimestamp,
 	}
 
-	return spanner.InsertMap(m_outbox.Table, row)
+	return spanner.InsertMap(m_outbox.Table, row), nil
 }
 
 // ────────────────────────────────────────────────────────────────────────────
@@ -66,15 +68,19 @@ func marshalPayload(event domain.DomainEvent) (string, error) {
 	switch e := event.(type) {
 	case *domain.ProductCreatedEvent:
 		data = struct {
-			ProductID string `json:"product_id"`
-			Name      string `json:"name"`
-			Category  string `json:"category"`
-			Status    string `json:"status"`
+			ProductID   string       `json:"product_id"`
+			Name        string       `json:"name"`
+			Description string       `json:"description"`
+			Category    string       `json:"category"`
+			BasePrice   moneyPayload `json:"base_price"`
+			Status      string       `json:"status"`
 		}{
-			ProductID: e.ProductID(),
-			Name:      e.Name(),
-			Category:  e.Category(),
-			Status:    string(e.Status()),
+			ProductID:   e.ProductID(),
+			Name:        e.Name(),
+			Description: e.Description(),
+			Category:    e.Category(),
+			BasePrice:   toMoneyPayload(e.BasePrice()),
+			Status:      string(e.Status()),
 		}
 
 	case *domain.ProductUpdatedEvent:
@@ -96,10 +102,95 @@ func marshalPayload(event domain.DomainEvent) (string, error) {
 		}{ProductID: e.ProductID()}
 
 	case *domain.ProductDeactivatedEvent:
+		data = struct {
+			ProductID string                    `json:"product_id"`
+			Reason    domain.DeactivationReason `json:"reason"`
+		}{ProductID: e.ProductID(), Reason: e.Reason()}
+
+	case *domain.ProductRestoredEvent:
 		data = struct {
 			ProductID string `json:"product_id"`
 		}{ProductID: e.ProductID()}
 
+	case *domain.ProductFeaturedChangedEvent:
+		data = struct {
+			ProductID string `json:"product_id"`
+			Featured  bool   `json:"featured"`
+		}{ProductID: e.ProductID(), Featured: e.Featured()}
+
+	case *domain.ProductMediaChangedEvent:
+		data = struct {
+			ProductID string   `json:"product_id"`
+			MediaURLs []string `json:"media_urls"`
+		}{ProductID: e.ProductID(), MediaURLs: e.MediaURLs()}
+
+	case *domain.ProductStockChangedEvent:
+		data = struct {
+			ProductID string `json:"product_id"`
+			Quantity  int64  `json:"quantity"`
+		}{ProductID: e.ProductID(), Quantity: e.Quantity()}
+
+	case *domain.ProductCategoriesChangedEvent:
+		data = struct {
+			ProductID  string   `json:"product_id"`
+			Categories []string `json:"categories"`
+		}{ProductID: e.ProductID(), Categories: e.Categories()}
+
+	case *domain.ProductAttributesChangedEvent:
+		data = struct {
+			ProductID  string            `json:"product_id"`
+			Attributes map[string]string `json:"attributes"`
+		}{ProductID: e.ProductID(), Attributes: e.Attributes()}
+
+	case *domain.ProductScheduledEvent:
+		data = struct {
+			ProductID   string     `json:"product_id"`
+			PublishAt   *time.Time `json:"publish_at"`   // null when no launch is scheduled
+			UnpublishAt *time.Time `json:"unpublish_at"` // null when no takedown is scheduled
+		}{ProductID: e.ProductID(), PublishAt: e.PublishAt(), UnpublishAt: e.UnpublishAt()}
+
+	case *domain.ProductQuantityTiersChangedEvent:
+		type tier struct {
+			MinQuantity int64  `json:"min_quantity"`
+			Percentage  string `json:"percentage"`
+		}
+		tiers := make([]tier, 0, len(e.Tiers()))
+		for _, t := range e.Tiers() {
+			tiers = append(tiers, tier{MinQuantity: t.MinQuantity(), Percentage: t.Percentage()})
+		}
+		data = struct {
+			ProductID string `json:"product_id"`
+			Tiers     []tier `json:"tiers"`
+		}{ProductID: e.ProductID(), Tiers: tiers}
+
+	case *domain.ProductCategoryChangedEvent:
+		data = struct {
+			ProductID string `json:"product_id"`
+			From      string `json:"from"`
+			To        string `json:"to"`
+		}{ProductID: e.ProductID(), From: e.From(), To: e.To()}
+
+	case *domain.ProductNameChangedEvent:
+		data = struct {
+			ProductID string `json:"product_id"`
+			From      string `json:"from"`
+			To        string `json:"to"`
+		}{ProductID: e.ProductID(), From: e.From(), To: e.To()}
+
+	case *domain.ProductDescriptionChangedEvent:
+		data = struct {
+			ProductID string `json:"product_id"`
+			From      string `json:"from"`
+			To        string `json:"to"`
+		}{ProductID: e.ProductID(), From: e.From(), To: e.To()}
+
+	case *domain.ProductPriceChangedEvent:
+		data = struct {
+			ProductID string       `json:"product_id"`
+			From      moneyPayload `json:"from"`
+			To        moneyPayload `json:"to"`
+		}{ProductID: e.ProductID(), From: toMoneyPayload(e.From()), To: toMoneyPayload(e.To())}
+
 	case *domain.DiscountAppliedEvent:
 		data = struct {
 			ProductID  string `json:"product_id"`
@@ -128,3 +219,16 @@ func marshalPayload(event domain.DomainEvent) (string, error) {
 	}
 	return string(b), nil
 }
+
+// moneyPayload is the stable JSON shape of a Money value inside event payloads.
+type moneyPayload struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func toMoneyPayload(m *domain.Money) moneyPayload {
+	if m == nil {
+		return moneyPayload{}
+	}
+	return moneyPayload{Amount: m.Amount(), Currency: m.Currency()}
+}
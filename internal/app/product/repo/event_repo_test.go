@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+func TestMarshalPayload_ProductCreatedCarriesSummaryFields(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	p, err := domain.NewProduct("Laptop", "High-end laptop", "electronics", domain.MustNewMoney(129900, "USD"), now)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	payload, err := marshalPayload(p.Events()[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got struct {
+		ProductID   string `json:"product_id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Category    string `json:"category"`
+		Status      string `json:"status"`
+		BasePrice   struct {
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
+		} `json:"base_price"`
+	}
+	if err := json.Unmarshal([]byte(payload), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	// A projector must be able to rebuild the summary row from the event alone.
+	rebuilt, err := domain.Reconstitute(domain.ProductState{
+		ID:          got.ProductID,
+		Name:        got.Name,
+		Description: got.Description,
+		Category:    got.Category,
+		BasePrice:   domain.MustNewMoney(got.BasePrice.Amount, got.BasePrice.Currency),
+		Status:      domain.ProductStatus(got.Status),
+	})
+	if err != nil {
+		t.Fatalf("reconstitute from payload: %v", err)
+	}
+	if rebuilt.ID() != p.ID() || rebuilt.Name() != p.Name() || rebuilt.Description() != p.Description() ||
+		rebuilt.Category() != p.Category() || rebuilt.Status() != p.Status() || !rebuilt.BasePrice().Equals(p.BasePrice()) {
+		t.Fatalf("payload %s does not match product", payload)
+	}
+}
+
+// unknownEvent is a DomainEvent marshalPayload has no case for.
+type unknownEvent struct{}
+
+func (unknownEvent) EventName() string     { return "product.unknown" }
+func (unknownEvent) OccurredAt() time.Time { return time.Time{} }
+func (unknownEvent) Sequence() int64       { return 1 }
+
+func TestInsertMut_FailsForUnserialisableEvent(t *testing.T) {
+	mut, err := NewEventRepo().InsertMut(unknownEvent{})
+	if err == nil || mut != nil {
+		t.Fatalf("expected an error and no mutation, got %v, %v", mut, err)
+	}
+}
@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// StaticExchangeRates serves conversion rates from a fixed table loaded at
+// startup. A pair configured one way is also served inverted.
+type StaticExchangeRates struct {
+	rates map[[2]string]float64
+}
+
+// NewStaticExchangeRates keys rates by [from, to] currency pair; every rate must be positive.
+func NewStaticExchangeRates(rates map[[2]string]float64) *StaticExchangeRates {
+	return &StaticExchangeRates{rates: rates}
+}
+
+func (r *StaticExchangeRates) Rate(_ context.Context, from, to string) (float64, error) {
+	if rate, ok := r.rates[[2]string{from, to}]; ok {
+		return rate, nil
+	}
+	if rate, ok := r.rates[[2]string{to, from}]; ok {
+		return 1 / rate, nil
+	}
+	return 0, domain.ErrUnsupportedCurrency
+}
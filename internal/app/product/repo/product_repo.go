@@ -4,30 +4,39 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
+	"time"
 
 	"cloud.google.com/go/spanner"
+	"go.uber.org/zap"
+
 	"github.com/product-catalog-service/internal/app/product/contract"
 	"github.com/product-catalog-service/internal/app/product/domain"
 	"github.com/product-catalog-service/internal/models/m_product"
 )
 
 type ProductRepo struct {
-	db *spanner.Client
+	db   *spanner.Client
+	slow slowQueryLog
 }
 
-func NewProductRepo(db *spanner.Client) *ProductRepo {
-	return &ProductRepo{db: db}
+// NewProductRepo creates a ProductRepo. Reads slower than slowThreshold are
+// logged as warnings; slowThreshold <= 0 disables the log.
+func NewProductRepo(db *spanner.Client, log *zap.Logger, slowThreshold time.Duration) *ProductRepo {
+	return &ProductRepo{db: db, slow: newSlowQueryLog(log, slowThreshold)}
 }
 
 // GetByID loads a product from Spanner by its ID.
 func (r *ProductRepo) GetByID(ctx context.Context, id string) (*domain.Product, error) {
-	row, err := r.db.Single().ReadRow(ctx, m_product.Table,
-		spanner.Key{id},
-		[]string{
+	var row *spanner.Row
+	err := r.slow.track("GetByID", map[string]any{"id": id}, func() (err error) {
+		row, err = r.db.Single().ReadRow(ctx, m_product.Table, spanner.Key{id}, []string{
 			m_product.ProductID,
 			m_product.Name,
+			m_product.Slug,
 			m_product.Description,
 			m_product.Category,
+			m_product.AdditionalCategories,
 			m_product.BasePriceNumerator,
 			m_product.BasePriceDenominator,
 			m_product.DiscountPercent,
@@ -37,8 +46,20 @@ func (r *ProductRepo) GetByID(ctx context.Context, id string) (*domain.Product,
 			m_product.CreatedAt,
 			m_product.UpdatedAt,
 			m_product.ArchivedAt,
-		},
-	)
+			m_product.Featured,
+			m_product.MediaURLs,
+			m_product.StockQuantity,
+			m_product.Attributes,
+			m_product.PublishAt,
+			m_product.UnpublishAt,
+			m_product.QuantityTierMinQuantities,
+			m_product.QuantityTierPercents,
+			m_product.PreviousDiscountPercent,
+			m_product.PreviousDiscountStartDate,
+			m_product.PreviousDiscountEndDate,
+		})
+		return err
+	})
 	if err != nil {
 		if spanner.ErrCode(err) == 5 { // codes.NotFound
 			return nil, domain.ErrProductNotFound
@@ -56,14 +77,26 @@ func (r *ProductRepo) GetByID(ctx context.Context, id string) (*domain.Product,
 
 // InsertMut returns a Spanner Mutation for a full INSERT of a new product.
 func (r *ProductRepo) InsertMut(p *domain.Product) *spanner.Mutation {
+	return spanner.InsertMap(m_product.Table, insertColumns(p))
+}
+
+// insertColumns maps every stored field of p to its column.
+func insertColumns(p *domain.Product) map[string]any {
 	row := map[string]any{
 		m_product.ProductID:            p.ID(),
 		m_product.Name:                 p.Name(),
+		m_product.Slug:                 nullString(p.Slug()),
 		m_product.Description:          p.Description(),
 		m_product.Category:             p.Category(),
+		m_product.AdditionalCategories: p.AdditionalCategories(),
 		m_product.BasePriceNumerator:   p.BasePrice().Amount(),
 		m_product.BasePriceDenominator: int64(1),
+		m_product.BasePriceCurrency:    p.BasePrice().Currency(),
 		m_product.Status:               string(p.Status()),
+		m_product.Featured:             p.IsFeatured(),
+		m_product.MediaURLs:            p.MediaURLs(),
+		m_product.StockQuantity:        p.StockQuantity(),
+		m_product.Attributes:           m_product.AttributesJSON(p.Attributes()),
 		m_product.CreatedAt:            spanner.CommitTimestamp,
 		m_product.UpdatedAt:            spanner.CommitTimestamp,
 	}
@@ -75,13 +108,32 @@ func (r *ProductRepo) InsertMut(p *domain.Product) *spanner.Mutation {
 		row[m_product.DiscountStartDate] = d.StartsAt()
 		row[m_product.DiscountEndDate] = d.EndsAt()
 	}
+	if at := p.PublishAt(); at != nil {
+		row[m_product.PublishAt] = *at
+	}
+	if at := p.UnpublishAt(); at != nil {
+		row[m_product.UnpublishAt] = *at
+	}
+	if tiers := p.QuantityTiers(); len(tiers) > 0 {
+		row[m_product.QuantityTierMinQuantities], row[m_product.QuantityTierPercents] = tierColumns(tiers)
+	}
 
-	return spanner.InsertMap(m_product.Table, row)
+	return row
 }
 
 // UpdateMut returns a Spanner Mutation containing only the dirty fields of a product.
 // Returns nil when nothing has changed.
 func (r *ProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
+	updates := updateColumns(p)
+	if updates == nil {
+		return nil
+	}
+	return spanner.UpdateMap(m_product.Table, updates)
+}
+
+// updateColumns maps the dirty fields of p to their columns, keyed by product
+// ID. Returns nil when nothing has changed.
+func updateColumns(p *domain.Product) map[string]any {
 	updates := map[string]any{
 		m_product.ProductID: p.ID(),
 		m_product.UpdatedAt: spanner.CommitTimestamp,
@@ -92,19 +144,62 @@ func (r *ProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
 	if c.Dirty(domain.FieldName) {
 		updates[m_product.Name] = p.Name()
 	}
+	if c.Dirty(domain.FieldSlug) {
+		updates[m_product.Slug] = nullString(p.Slug())
+	}
 	if c.Dirty(domain.FieldDescription) {
 		updates[m_product.Description] = p.Description()
 	}
 	if c.Dirty(domain.FieldCategory) {
 		updates[m_product.Category] = p.Category()
 	}
+	if c.Dirty(domain.FieldCategories) {
+		updates[m_product.AdditionalCategories] = p.AdditionalCategories()
+	}
 	if c.Dirty(domain.FieldBasePrice) {
 		updates[m_product.BasePriceNumerator] = p.BasePrice().Amount()
 		updates[m_product.BasePriceDenominator] = int64(1)
+		updates[m_product.BasePriceCurrency] = p.BasePrice().Currency()
 	}
 	if c.Dirty(domain.FieldStatus) {
 		updates[m_product.Status] = string(p.Status())
 	}
+	if c.Dirty(domain.FieldFeatured) {
+		updates[m_product.Featured] = p.IsFeatured()
+	}
+	if c.Dirty(domain.FieldMedia) {
+		updates[m_product.MediaURLs] = p.MediaURLs()
+	}
+	if c.Dirty(domain.FieldStock) {
+		updates[m_product.StockQuantity] = p.StockQuantity()
+	}
+	if c.Dirty(domain.FieldAttributes) {
+		updates[m_product.Attributes] = m_product.AttributesJSON(p.Attributes())
+	}
+	if c.Dirty(domain.FieldQuantityTiers) {
+		updates[m_product.QuantityTierMinQuantities], updates[m_product.QuantityTierPercents] = tierColumns(p.QuantityTiers())
+	}
+	if c.Dirty(domain.FieldArchivedAt) {
+		if at := p.ArchivedAt(); at != nil {
+			updates[m_product.ArchivedAt] = *at
+		} else {
+			updates[m_product.ArchivedAt] = nil
+		}
+	}
+	if c.Dirty(domain.FieldPublishAt) {
+		if at := p.PublishAt(); at != nil {
+			updates[m_product.PublishAt] = *at
+		} else {
+			updates[m_product.PublishAt] = nil
+		}
+	}
+	if c.Dirty(domain.FieldUnpublishAt) {
+		if at := p.UnpublishAt(); at != nil {
+			updates[m_product.UnpublishAt] = *at
+		} else {
+			updates[m_product.UnpublishAt] = nil
+		}
+	}
 	if c.Dirty(domain.FieldDiscount) {
 		if d := p.Discount(); d != nil {
 			var rat big.Rat
@@ -119,38 +214,374 @@ func (r *ProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
 			updates[m_product.DiscountEndDate] = nil
 		}
 	}
+	if c.Dirty(domain.FieldPreviousDiscount) {
+		if d := p.PreviousDiscount(); d != nil {
+			var rat big.Rat
+			rat.SetFloat64(d.PercentageFloat64())
+			updates[m_product.PreviousDiscountPercent] = rat
+			updates[m_product.PreviousDiscountStartDate] = d.StartsAt()
+			updates[m_product.PreviousDiscountEndDate] = d.EndsAt()
+		} else {
+			updates[m_product.PreviousDiscountPercent] = nil
+			updates[m_product.PreviousDiscountStartDate] = nil
+			updates[m_product.PreviousDiscountEndDate] = nil
+		}
+	}
 
 	// Only ProductID + UpdatedAt → nothing actually changed
 	if len(updates) == 2 {
 		return nil
 	}
 
-	return spanner.UpdateMap(m_product.Table, updates)
+	return updates
 }
 
-// ListActive returns all active products, optionally filtered by category, with pagination.
-func (r *ProductRepo) ListActive(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+// ForceClearDiscountMut returns a Spanner Mutation that nulls every discount
+// column of the product, bypassing domain validation of the stored values.
+func (r *ProductRepo) ForceClearDiscountMut(id string) *spanner.Mutation {
+	return spanner.UpdateMap(m_product.Table, map[string]any{
+		m_product.ProductID:                 id,
+		m_product.DiscountPercent:           nil,
+		m_product.DiscountStartDate:         nil,
+		m_product.DiscountEndDate:           nil,
+		m_product.PreviousDiscountPercent:   nil,
+		m_product.PreviousDiscountStartDate: nil,
+		m_product.PreviousDiscountEndDate:   nil,
+		m_product.UpdatedAt:                 spanner.CommitTimestamp,
+	})
+}
+
+// GetBySlug loads the product whose slug is slug.
+func (r *ProductRepo) GetBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.Slug + ` = @slug`,
+		Params: map[string]any{"slug": slug},
+	}
+	var products []*domain.Product
+	err := r.slow.track("GetBySlug", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetBySlug: %w", err)
+	}
+	if len(products) == 0 {
+		return nil, domain.ErrProductNotFound
+	}
+	return products[0], nil
+}
+
+// SlugsWithPrefix returns the stored slugs equal to base or of the form base-N.
+func (r *ProductRepo) SlugsWithPrefix(ctx context.Context, base string) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + m_product.Slug + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.Slug + ` = @base OR STARTS_WITH(` + m_product.Slug + `, @prefix)`,
+		Params: map[string]any{"base": base, "prefix": base + "-"},
+	}
+	var slugs []string
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var slug string
+		if err := row.Column(0, &slug); err != nil {
+			return err
+		}
+		slugs = append(slugs, slug)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SlugsWithPrefix: %w", err)
+	}
+	return slugs, nil
+}
+
+// ListDiscountedByCategory loads every active product listed in category with
+// a stored discount.
+func (r *ProductRepo) ListDiscountedByCategory(ctx context.Context, category string) ([]*domain.Product, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.Status + ` = @status
+		        AND ` + inCategory + `
+		        AND ` + m_product.DiscountPercent + ` IS NOT NULL`,
+		Params: map[string]any{
+			"status":   string(domain.ProductStatusActive),
+			"category": category,
+		},
+	}
+	products, err := r.queryProducts(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("ListDiscountedByCategory: %w", err)
+	}
+	return products, nil
+}
+
+// ListByCategory loads every product listed in category, as its primary or an
+// additional category, whatever its status.
+func (r *ProductRepo) ListByCategory(ctx context.Context, category string) ([]*domain.Product, error) {
 	stmt := spanner.Statement{
 		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
-		      WHERE ` + m_product.Status + ` = 'active'`,
+		      WHERE ` + inCategory,
+		Params: map[string]any{"category": category},
+	}
+	products, err := r.queryProducts(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("ListByCategory: %w", err)
+	}
+	return products, nil
+}
+
+// ListDuePublish loads up to limit staged products whose publish time is at or
+// before now, earliest first.
+func (r *ProductRepo) ListDuePublish(ctx context.Context, now time.Time, limit int) ([]*domain.Product, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.PublishAt + ` <= @now
+		        AND ` + m_product.Status + ` = @status
+		        AND ` + m_product.ArchivedAt + ` IS NULL
+		      ORDER BY ` + m_product.PublishAt + `, ` + m_product.ProductID + `
+		      LIMIT @limit`,
+		Params: map[string]any{
+			"now":    now,
+			"status": string(domain.ProductStatusInactive),
+			"limit":  int64(limit),
+		},
+	}
+	var products []*domain.Product
+	err := r.slow.track("ListDuePublish", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListDuePublish: %w", err)
+	}
+	return products, nil
+}
+
+// ListDueUnpublish loads up to limit live products whose unpublish time is at
+// or before now, earliest first.
+func (r *ProductRepo) ListDueUnpublish(ctx context.Context, now time.Time, limit int) ([]*domain.Product, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.UnpublishAt + ` <= @now
+		        AND ` + m_product.Status + ` = @status
+		        AND ` + m_product.ArchivedAt + ` IS NULL
+		      ORDER BY ` + m_product.UnpublishAt + `, ` + m_product.ProductID + `
+		      LIMIT @limit`,
+		Params: map[string]any{
+			"now":    now,
+			"status": string(domain.ProductStatusActive),
+			"limit":  int64(limit),
+		},
+	}
+	var products []*domain.Product
+	err := r.slow.track("ListDueUnpublish", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListDueUnpublish: %w", err)
+	}
+	return products, nil
+}
+
+// ListActive returns active products (or those in filter.Statuses when set), optionally
+// filtered by category and creation date range, with pagination.
+func (r *ProductRepo) ListActive(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+	stmt := listStatement(allColumns, filter, page)
+
+	var products []*domain.Product
+	err := r.slow.track("ListActive", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListActive: %w", err)
+	}
+	return products, nil
+}
+
+// ListActiveSummaries is like ListActive but only reads the columns needed for
+// list summaries. The returned products have no description.
+func (r *ProductRepo) ListActiveSummaries(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+	stmt := listStatement(summaryColumns, filter, page)
+
+	var products []*domain.Product
+	err := r.slow.track("ListActiveSummaries", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListActiveSummaries: %w", err)
+	}
+	return products, nil
+}
+
+// ListActiveSummariesByDiscount is like ListActiveSummaries but orders by the
+// discount percentage valid at at, so the store pages the deal ordering.
+func (r *ProductRepo) ListActiveSummariesByDiscount(ctx context.Context, filter contract.ListProductsFilter, at time.Time, page contract.Page) ([]*domain.Product, error) {
+	stmt := listByDiscountStatement(summaryColumns, filter, at, page)
+
+	var products []*domain.Product
+	err := r.slow.track("ListActiveSummariesByDiscount", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListActiveSummariesByDiscount: %w", err)
+	}
+	return products, nil
+}
+
+// CountActive returns the number of products matching filter.
+func (r *ProductRepo) CountActive(ctx context.Context, filter contract.ListProductsFilter) (int, error) {
+	stmt := filterStatement(`SELECT COUNT(*) FROM `+m_product.Table, filter)
+
+	var count int64
+	err := r.slow.track("CountActive", stmt.Params, func() error {
+		return r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+			return row.Column(0, &count)
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("CountActive: %w", err)
+	}
+	return int(count), nil
+}
+
+// ExistsBatch reports whether each of ids is a stored product, without loading
+// the products themselves.
+func (r *ProductRepo) ExistsBatch(ctx context.Context, ids []string) (map[string]bool, error) {
+	stmt := existsStatement(ids)
+
+	exists := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		exists[id] = false
+	}
+	err := r.slow.track("ExistsBatch", stmt.Params, func() error {
+		return r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+			var id string
+			if err := row.Column(0, &id); err != nil {
+				return err
+			}
+			exists[id] = true
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ExistsBatch: %w", err)
+	}
+	return exists, nil
+}
+
+// existsStatement selects the ids among ids that exist, passing each id once.
+func existsStatement(ids []string) spanner.Statement {
+	unique := slices.Clone(ids)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+
+	return spanner.Statement{
+		SQL: `SELECT ` + m_product.ProductID + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.ProductID + ` IN UNNEST(@ids)`,
+		Params: map[string]any{"ids": unique},
+	}
+}
+
+// listStatement builds the filtered, paginated SELECT used by the list methods.
+// Featured products come first; product_id breaks ties so offset pages are
+// stable across calls.
+func listStatement(columns string, filter contract.ListProductsFilter, page contract.Page) spanner.Statement {
+	return listStatementFrom(m_product.Table, columns, filter, page)
+}
+
+// listStatementFrom is listStatement against table, which must share the
+// products column names used by the filter and ordering.
+func listStatementFrom(table, columns string, filter contract.ListProductsFilter, page contract.Page) spanner.Statement {
+	stmt := filterStatement(`SELECT `+columns+` FROM `+table, filter)
+	stmt.SQL += " ORDER BY " + m_product.Featured + " DESC, " + m_product.ProductID
+	stmt.SQL += fmt.Sprintf(" LIMIT %d OFFSET %d", page.Limit, page.Offset)
+	return stmt
+}
+
+// listByDiscountStatement is listStatement ordered by the discount percentage
+// valid at @discount_at. Upcoming and expired discounts, like missing ones,
+// count as 0; ties fall back to the default ordering.
+func listByDiscountStatement(columns string, filter contract.ListProductsFilter, at time.Time, page contract.Page) spanner.Statement {
+	stmt := filterStatement(`SELECT `+columns+` FROM `+m_product.Table, filter)
+	stmt.SQL += ` ORDER BY CASE WHEN ` + m_product.DiscountStartDate + ` <= @discount_at AND ` + m_product.DiscountEndDate + ` > @discount_at
+		      THEN ` + m_product.DiscountPercent + ` ELSE 0 END DESC, ` + m_product.Featured + " DESC, " + m_product.ProductID
+	stmt.SQL += fmt.Sprintf(" LIMIT %d OFFSET %d", page.Limit, page.Offset)
+	stmt.Params["discount_at"] = at
+	return stmt
+}
+
+// inCategory matches products listed in @category, as their primary or an
+// additional category. UNNEST of a NULL array yields no rows.
+const inCategory = `(` + m_product.Category + ` = @category OR @category IN UNNEST(` + m_product.AdditionalCategories + `))`
+
+// inAnyCategory is inCategory for any of @categories.
+const inAnyCategory = `(` + m_product.Category + ` IN UNNEST(@categories) OR EXISTS (
+		      SELECT 1 FROM UNNEST(` + m_product.AdditionalCategories + `) AS c WHERE c IN UNNEST(@categories)))`
+
+// filterStatement appends the WHERE clause for filter to selectFrom.
+func filterStatement(selectFrom string, filter contract.ListProductsFilter) spanner.Statement {
+	statuses := []string{string(domain.ProductStatusActive)}
+	if len(filter.Statuses) > 0 {
+		statuses = statuses[:0]
+		for _, s := range filter.Statuses {
+			statuses = append(statuses, string(s))
+		}
 	}
 
-	if filter.Category != nil {
-		stmt.SQL += " AND " + m_product.Category + " = @category"
-		stmt.Params = map[string]any{"category": *filter.Category}
+	stmt := spanner.Statement{
+		SQL: selectFrom + `
+		      WHERE ` + m_product.Status + ` IN UNNEST(@statuses)`,
+		Params: map[string]any{"statuses": statuses},
 	}
 
-	limit := page.Limit
-	if limit <= 0 {
-		limit = 20
+	if len(filter.Categories) > 0 {
+		stmt.SQL += " AND " + inAnyCategory
+		stmt.Params["categories"] = filter.Categories
+	} else if filter.Category != nil {
+		stmt.SQL += " AND " + inCategory
+		stmt.Params["category"] = *filter.Category
+	}
+	if filter.Featured != nil {
+		stmt.SQL += " AND " + m_product.Featured + " = @featured"
+		stmt.Params["featured"] = *filter.Featured
+	}
+	if filter.InStockOnly {
+		stmt.SQL += " AND " + m_product.StockQuantity + " > 0"
+	}
+	if filter.Attribute != nil {
+		// The key is bound as a parameter rather than spliced into a JSON path.
+		stmt.SQL += " AND JSON_VALUE(" + m_product.Attributes + "[@attribute_key]) = @attribute_value"
+		stmt.Params["attribute_key"] = filter.Attribute.Key
+		stmt.Params["attribute_value"] = filter.Attribute.Value
 	}
-	stmt.SQL += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, page.Offset)
+	if filter.OnSaleAt != nil {
+		// Valid at the instant rather than merely stored: upcoming and
+		// expired discounts keep their columns but are not on sale.
+		stmt.SQL += " AND " + m_product.DiscountStartDate + " <= @on_sale_at AND " + m_product.DiscountEndDate + " > @on_sale_at"
+		stmt.Params["on_sale_at"] = *filter.OnSaleAt
+	}
+	if filter.CreatedAfter != nil {
+		stmt.SQL += " AND " + m_product.CreatedAt + " >= @created_after"
+		stmt.Params["created_after"] = *filter.CreatedAfter
+	}
+	if filter.CreatedBefore != nil {
+		stmt.SQL += " AND " + m_product.CreatedAt + " < @created_before"
+		stmt.Params["created_before"] = *filter.CreatedBefore
+	}
+	return stmt
+}
 
+// queryProducts runs stmt and decodes every row into a Product.
+// Columns absent from the statement are left at their zero value.
+func (r *ProductRepo) queryProducts(ctx context.Context, stmt spanner.Statement) ([]*domain.Product, error) {
 	var products []*domain.Product
 	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
 		var pr m_product.ProductRow
 		if err := row.ToStruct(&pr); err != nil {
-			return fmt.Errorf("ListActive decode: %w", err)
+			return fmt.Errorf("decode: %w", err)
 		}
 		p, err := pr.ToDomain()
 		if err != nil {
@@ -160,23 +591,106 @@ func (r *ProductRepo) ListActive(ctx context.Context, filter contract.ListProduc
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("ListActive: %w", err)
+		return nil, err
 	}
 	return products, nil
 }
 
+// GetByIDs loads the products among ids that exist in a single query.
+func (r *ProductRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Product, error) {
+	stmt := getByIDsStatement(ids)
+
+	var products []*domain.Product
+	err := r.slow.track("GetByIDs", stmt.Params, func() (err error) {
+		products, err = r.queryProducts(ctx, stmt)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetByIDs: %w", err)
+	}
+	return products, nil
+}
+
+// getByIDsStatement selects every column of the products among ids, passing
+// each id once.
+func getByIDsStatement(ids []string) spanner.Statement {
+	return spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_product.Table + `
+		      WHERE ` + m_product.ProductID + ` IN UNNEST(@ids)`,
+		Params: map[string]any{"ids": uniqueIDs(ids)},
+	}
+}
+
+// uniqueIDs returns ids sorted with duplicates removed.
+func uniqueIDs(ids []string) []string {
+	unique := slices.Clone(ids)
+	slices.Sort(unique)
+	return slices.Compact(unique)
+}
+
 // allColumns is the full column list for SELECT queries.
 const allColumns = `` +
 	m_product.ProductID + `, ` +
 	m_product.Name + `, ` +
+	m_product.Slug + `, ` +
 	m_product.Description + `, ` +
 	m_product.Category + `, ` +
+	m_product.AdditionalCategories + `, ` +
 	m_product.BasePriceNumerator + `, ` +
 	m_product.BasePriceDenominator + `, ` +
+	m_product.BasePriceCurrency + `, ` +
 	m_product.DiscountPercent + `, ` +
 	m_product.DiscountStartDate + `, ` +
 	m_product.DiscountEndDate + `, ` +
 	m_product.Status + `, ` +
 	m_product.CreatedAt + `, ` +
 	m_product.UpdatedAt + `, ` +
-	m_product.ArchivedAt
+	m_product.ArchivedAt + `, ` +
+	m_product.Featured + `, ` +
+	m_product.MediaURLs + `, ` +
+	m_product.StockQuantity + `, ` +
+	m_product.Attributes + `, ` +
+	m_product.PublishAt + `, ` +
+	m_product.UnpublishAt + `, ` +
+	m_product.QuantityTierMinQuantities + `, ` +
+	m_product.QuantityTierPercents + `, ` +
+	m_product.PreviousDiscountPercent + `, ` +
+	m_product.PreviousDiscountStartDate + `, ` +
+	m_product.PreviousDiscountEndDate
+
+// summaryColumns is the reduced column list for list summaries: it omits the
+// description and the created/updated timestamps.
+const summaryColumns = `` +
+	m_product.ProductID + `, ` +
+	m_product.Name + `, ` +
+	m_product.Category + `, ` +
+	m_product.BasePriceNumerator + `, ` +
+	m_product.BasePriceDenominator + `, ` +
+	m_product.BasePriceCurrency + `, ` +
+	m_product.DiscountPercent + `, ` +
+	m_product.DiscountStartDate + `, ` +
+	m_product.DiscountEndDate + `, ` +
+	m_product.Status + `, ` +
+	m_product.ArchivedAt + `, ` +
+	m_product.Featured + `, ` +
+	m_product.StockQuantity
+
+// tierColumns splits tiers into the parallel min-quantity and percentage
+// arrays; both are NULL when there are no tiers.
+func tierColumns(tiers []domain.QuantityTier) (any, any) {
+	if len(tiers) == 0 {
+		return []int64(nil), []big.Rat(nil)
+	}
+	minQuantities := make([]int64, len(tiers))
+	percents := make([]big.Rat, len(tiers))
+	for i, t := range tiers {
+		minQuantities[i] = t.MinQuantity()
+		percents[i].SetFloat64(t.PercentageFloat64())
+	}
+	return minQuantities, percents
+}
+
+// nullString stores "" as NULL, e.g. for products created before slugs.
+func nullString(s string) spanner.NullString {
+	return spanner.NullString{StringVal: s, Valid: s != ""}
+}
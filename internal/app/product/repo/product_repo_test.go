@@ -0,0 +1,255 @@
+package repo
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+	"github.com/product-catalog-service/internal/models/m_product"
+)
+
+func TestSummaryColumns_SubsetWithoutHeavyFields(t *testing.T) {
+	all := strings.Split(allColumns, ", ")
+	summary := strings.Split(summaryColumns, ", ")
+
+	if len(summary) >= len(all) {
+		t.Fatalf("expected fewer summary columns than full columns, got %d vs %d", len(summary), len(all))
+	}
+	for _, col := range summary {
+		if !slices.Contains(all, col) {
+			t.Fatalf("summary column %q is not a product column", col)
+		}
+	}
+	for _, excluded := range []string{m_product.Description, m_product.CreatedAt, m_product.UpdatedAt} {
+		if slices.Contains(summary, excluded) {
+			t.Fatalf("summary columns must not include %q", excluded)
+		}
+	}
+}
+
+func TestListStatement_ProjectsRequestedColumns(t *testing.T) {
+	stmt := listStatement(summaryColumns, contract.ListProductsFilter{}, contract.Page{Limit: 10})
+
+	if !strings.HasPrefix(stmt.SQL, "SELECT "+summaryColumns+" FROM ") {
+		t.Fatalf("unexpected projection: %s", stmt.SQL)
+	}
+	if strings.Contains(stmt.SQL, m_product.Description) {
+		t.Fatalf("summary statement selects description: %s", stmt.SQL)
+	}
+}
+
+func TestListStatement_OrdersFeaturedFirstBeforePaging(t *testing.T) {
+	stmt := listStatement(allColumns, contract.ListProductsFilter{}, contract.Page{Limit: 10, Offset: 20})
+
+	want := " ORDER BY " + m_product.Featured + " DESC, " + m_product.ProductID + " LIMIT 10 OFFSET 20"
+	if !strings.HasSuffix(stmt.SQL, want) {
+		t.Fatalf("expected featured-first ORDER BY before LIMIT/OFFSET: %s", stmt.SQL)
+	}
+}
+
+func TestExistsStatement_DeduplicatesIDs(t *testing.T) {
+	stmt := existsStatement([]string{"p-2", "p-1", "p-2", "p-1"})
+
+	if !strings.Contains(stmt.SQL, m_product.ProductID+" IN UNNEST(@ids)") {
+		t.Fatalf("expected IN UNNEST(@ids), got %s", stmt.SQL)
+	}
+	if got := stmt.Params["ids"].([]string); !slices.Equal(got, []string{"p-1", "p-2"}) {
+		t.Fatalf("expected deduplicated ids, got %v", got)
+	}
+}
+
+func TestGetByIDsStatement_LoadsEachIDOnce(t *testing.T) {
+	stmt := getByIDsStatement([]string{"p-2", "p-1", "p-2"})
+
+	if !strings.HasPrefix(stmt.SQL, "SELECT "+allColumns) || !strings.Contains(stmt.SQL, m_product.ProductID+" IN UNNEST(@ids)") {
+		t.Fatalf("expected every column for IN UNNEST(@ids), got %s", stmt.SQL)
+	}
+	if got := stmt.Params["ids"].([]string); !slices.Equal(got, []string{"p-1", "p-2"}) {
+		t.Fatalf("expected deduplicated ids, got %v", got)
+	}
+}
+
+func TestListStatement_FeaturedFilter(t *testing.T) {
+	featured := true
+	stmt := listStatement(summaryColumns, contract.ListProductsFilter{Featured: &featured}, contract.Page{Limit: 10})
+
+	if !strings.Contains(stmt.SQL, m_product.Featured+" = @featured") || stmt.Params["featured"] != true {
+		t.Fatalf("expected featured filter, got %s %v", stmt.SQL, stmt.Params)
+	}
+}
+
+func TestListStatement_InStockOnlyFilter(t *testing.T) {
+	stmt := listStatement(summaryColumns, contract.ListProductsFilter{InStockOnly: true}, contract.Page{Limit: 10})
+
+	if !strings.Contains(stmt.SQL, m_product.StockQuantity+" > 0") {
+		t.Fatalf("expected in-stock filter, got %s", stmt.SQL)
+	}
+}
+
+func TestListStatement_CategoryFilterMatchesAdditionalCategories(t *testing.T) {
+	category := "laptops"
+	single := listStatement(summaryColumns, contract.ListProductsFilter{Category: &category}, contract.Page{Limit: 10})
+	if !strings.Contains(single.SQL, "@category IN UNNEST("+m_product.AdditionalCategories+")") {
+		t.Fatalf("expected single category to match additional categories, got %s", single.SQL)
+	}
+
+	many := listStatement(summaryColumns, contract.ListProductsFilter{Categories: []string{"laptops", "tablets"}}, contract.Page{Limit: 10})
+	if !strings.Contains(many.SQL, "UNNEST("+m_product.AdditionalCategories+") AS c WHERE c IN UNNEST(@categories)") {
+		t.Fatalf("expected category set to match additional categories, got %s", many.SQL)
+	}
+}
+
+func TestListStatement_AttributeFilterBindsKeyAndValue(t *testing.T) {
+	stmt := listStatement(summaryColumns, contract.ListProductsFilter{
+		Attribute: &contract.AttributeMatch{Key: "color", Value: "red"},
+	}, contract.Page{Limit: 10})
+	if !strings.Contains(stmt.SQL, "JSON_VALUE("+m_product.Attributes+"[@attribute_key]) = @attribute_value") {
+		t.Fatalf("expected attribute filter, got %s", stmt.SQL)
+	}
+	if stmt.Params["attribute_key"] != "color" || stmt.Params["attribute_value"] != "red" {
+		t.Fatalf("expected key and value bound as params, got %v", stmt.Params)
+	}
+}
+
+func TestListStatement_OnSaleRequiresDiscountValidAtInstant(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stmt := listStatement(summaryColumns, contract.ListProductsFilter{OnSaleAt: &at}, contract.Page{Limit: 10})
+	want := m_product.DiscountStartDate + " <= @on_sale_at AND " + m_product.DiscountEndDate + " > @on_sale_at"
+	if !strings.Contains(stmt.SQL, want) {
+		t.Fatalf("expected the started-and-not-ended predicate, got %s", stmt.SQL)
+	}
+	if strings.Contains(stmt.SQL, m_product.DiscountPercent+" IS NOT NULL") {
+		t.Fatalf("a stored discount alone must not count as on sale: %s", stmt.SQL)
+	}
+	if stmt.Params["on_sale_at"] != at {
+		t.Fatalf("expected the instant bound as a param, got %v", stmt.Params)
+	}
+}
+
+func TestListByDiscountStatement_OrdersByValidDiscountBeforePaging(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stmt := listByDiscountStatement(summaryColumns, contract.ListProductsFilter{}, at, contract.Page{Limit: 10, Offset: 20})
+
+	want := "CASE WHEN " + m_product.DiscountStartDate + " <= @discount_at AND " + m_product.DiscountEndDate + " > @discount_at"
+	if !strings.Contains(stmt.SQL, want) {
+		t.Fatalf("expected ordering on the discount valid at the instant, got %s", stmt.SQL)
+	}
+	tail := " ELSE 0 END DESC, " + m_product.Featured + " DESC, " + m_product.ProductID + " LIMIT 10 OFFSET 20"
+	if !strings.HasSuffix(stmt.SQL, tail) {
+		t.Fatalf("expected the featured-first tie-break before LIMIT/OFFSET: %s", stmt.SQL)
+	}
+	if stmt.Params["discount_at"] != at {
+		t.Fatalf("expected the instant bound as a param, got %v", stmt.Params)
+	}
+}
+
+// readBack decodes cols as Spanner returns a row written with them.
+func readBack(t *testing.T, cols map[string]any) *domain.Product {
+	t.Helper()
+	names := make([]string, 0, len(cols))
+	values := make([]any, 0, len(cols))
+	for name, v := range cols {
+		if v == spanner.CommitTimestamp {
+			v = time.Now() // Spanner fills these in at commit
+		}
+		names = append(names, name)
+		values = append(values, v)
+	}
+	row, err := spanner.NewRow(names, values)
+	if err != nil {
+		t.Fatalf("new row: %v", err)
+	}
+	var pr m_product.ProductRow
+	if err := row.ToStruct(&pr); err != nil {
+		t.Fatalf("to struct: %v", err)
+	}
+	p, err := pr.ToDomain()
+	if err != nil {
+		t.Fatalf("to domain: %v", err)
+	}
+	return p
+}
+
+func TestInsertColumns_BasePriceCurrencyRoundTrips(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(1999, "USD"), now)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	got := readBack(t, insertColumns(p)).BasePrice()
+	if !got.Equals(p.BasePrice()) {
+		t.Fatalf("expected %s after the round trip, got %s", p.BasePrice(), got)
+	}
+}
+
+func TestUpdateColumns_WritesCorrectedCurrency(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	created, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(1999, "USD"), now)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	stored := insertColumns(created)
+
+	p := readBack(t, stored)
+	if err := p.CorrectBasePrice(domain.MustNewMoney(45000000, "VND")); err != nil {
+		t.Fatalf("correct base price: %v", err)
+	}
+	maps.Copy(stored, updateColumns(p))
+
+	got := readBack(t, stored).BasePrice()
+	if got.Amount() != 45000000 || got.Currency() != "VND" {
+		t.Fatalf("expected 45000000 VND after the update, got %s", got)
+	}
+}
+
+// rowProductRepo keeps one product as its written columns, so every load and
+// update goes through the Spanner row mapping.
+type rowProductRepo struct {
+	*ProductRepo
+	t   *testing.T
+	row map[string]any
+}
+
+func (r *rowProductRepo) GetByID(context.Context, string) (*domain.Product, error) {
+	return readBack(r.t, r.row), nil
+}
+
+func (r *rowProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
+	maps.Copy(r.row, updateColumns(p))
+	return r.ProductRepo.UpdateMut(p)
+}
+
+type noopApplier struct{}
+
+func (noopApplier) Apply(context.Context, *commitplanner.Plan) error { return nil }
+
+func TestUpdateProduct_PriceWithoutCurrencyKeepsStoredCurrency(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(1999, "EUR"), time.Now())
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	repo := &rowProductRepo{ProductRepo: &ProductRepo{}, t: t, row: insertColumns(p)}
+	it := updateproduct.NewUpdateProductInteractor(noopApplier{}, repo, NewEventRepo(), common.NewRealTicker())
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: p.ID(),
+		BasePrice: &updateproduct.PriceUpdate{Price: "24.99"},
+	})
+	if err != nil {
+		t.Fatalf("expected an amount-only update to succeed, got %v", err)
+	}
+	if got := readBack(t, repo.row).BasePrice(); got.Amount() != 2499 || got.Currency() != "EUR" {
+		t.Fatalf("expected 2499 EUR to be stored, got %s", got)
+	}
+}
@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/models/m_product"
+	"github.com/product-catalog-service/internal/models/m_read_model"
+)
+
+// ReadModelRepo implements contract.ReadModelRepository against the
+// product_read_model table.
+type ReadModelRepo struct {
+	db *spanner.Client
+}
+
+func NewReadModelRepo(db *spanner.Client) *ReadModelRepo {
+	return &ReadModelRepo{db: db}
+}
+
+// ListListings returns projected rows matching filter, featured first.
+func (r *ReadModelRepo) ListListings(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*contract.ProductListing, error) {
+	stmt := listStatementFrom(m_read_model.Table, readModelColumns, filter, page)
+
+	var listings []*contract.ProductListing
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var rm m_read_model.ReadModelRow
+		if err := row.ToStruct(&rm); err != nil {
+			return err
+		}
+		l, err := toListing(rm)
+		if err != nil {
+			return err
+		}
+		listings = append(listings, l)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListListings: %w", err)
+	}
+	return listings, nil
+}
+
+// Upsert writes l outside any business transaction; projections are rebuilt
+// from the products table, so a lost write is repaired by the next event.
+func (r *ReadModelRepo) Upsert(ctx context.Context, l *contract.ProductListing) error {
+	if _, err := r.db.Apply(ctx, []*spanner.Mutation{upsertMut(l)}); err != nil {
+		return fmt.Errorf("Upsert: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns the ids of rows whose effective price may have changed by now.
+func (r *ReadModelRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + m_read_model.ProductID + ` FROM ` + m_read_model.Table + `
+		      WHERE ` + m_read_model.NextPriceChangeAt + ` <= @now
+		      ORDER BY ` + m_read_model.NextPriceChangeAt + `
+		      LIMIT @limit`,
+		Params: map[string]any{"now": now, "limit": int64(limit)},
+	}
+
+	var ids []string
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var id string
+		if err := row.Columns(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListDue: %w", err)
+	}
+	return ids, nil
+}
+
+// upsertMut builds the InsertOrUpdate for l. created_at is only written when
+// known so re-projections never clear it.
+func upsertMut(l *contract.ProductListing) *spanner.Mutation {
+	row := map[string]any{
+		m_read_model.ProductID:            l.ProductID,
+		m_read_model.Name:                 l.Name,
+		m_read_model.Category:             l.Category,
+		m_read_model.AdditionalCategories: l.AdditionalCategories,
+		m_read_model.Status:               string(l.Status),
+		m_read_model.Featured:             l.Featured,
+		m_read_model.StockQuantity:        l.StockQuantity,
+		m_read_model.Attributes:           m_product.AttributesJSON(l.Attributes),
+		m_read_model.BasePriceAmount:      l.BasePrice.Amount(),
+		m_read_model.Currency:             l.BasePrice.Currency(),
+		m_read_model.EffectivePriceAmount: l.EffectivePrice.Amount(),
+		m_read_model.IsDiscounted:         l.IsDiscounted,
+		m_read_model.DiscountEndsAt:       nullTime(l.DiscountEndsAt),
+		m_read_model.NextPriceChangeAt:    nullTime(l.NextPriceChangeAt),
+		m_read_model.ProjectedAt:          spanner.CommitTimestamp,
+	}
+	if l.CreatedAt != nil {
+		row[m_read_model.CreatedAt] = *l.CreatedAt
+	}
+	return spanner.InsertOrUpdateMap(m_read_model.Table, row)
+}
+
+func toListing(rm m_read_model.ReadModelRow) (*contract.ProductListing, error) {
+	base, err := domain.NewMoney(rm.BasePriceAmount, rm.Currency)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := domain.NewMoney(rm.EffectivePriceAmount, rm.Currency)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := m_product.AttributesFromJSON(rm.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &contract.ProductListing{
+		ProductID:            rm.ProductID,
+		Name:                 rm.Name,
+		Category:             rm.Category,
+		AdditionalCategories: rm.AdditionalCategories,
+		Status:               domain.ProductStatus(rm.Status),
+		Featured:             rm.Featured,
+		StockQuantity:        rm.StockQuantity,
+		Attributes:           attributes,
+		CreatedAt:            timePtr(rm.CreatedAt),
+		BasePrice:            base,
+		EffectivePrice:       effective,
+		IsDiscounted:         rm.IsDiscounted,
+		DiscountEndsAt:       timePtr(rm.DiscountEndsAt),
+		NextPriceChangeAt:    timePtr(rm.NextPriceChangeAt),
+	}, nil
+}
+
+func nullTime(t *time.Time) spanner.NullTime {
+	if t == nil {
+		return spanner.NullTime{}
+	}
+	return spanner.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t spanner.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+const readModelColumns = `` +
+	m_read_model.ProductID + `, ` +
+	m_read_model.Name + `, ` +
+	m_read_model.Category + `, ` +
+	m_read_model.AdditionalCategories + `, ` +
+	m_read_model.Status + `, ` +
+	m_read_model.Featured + `, ` +
+	m_read_model.StockQuantity + `, ` +
+	m_read_model.Attributes + `, ` +
+	m_read_model.CreatedAt + `, ` +
+	m_read_model.BasePriceAmount + `, ` +
+	m_read_model.Currency + `, ` +
+	m_read_model.EffectivePriceAmount + `, ` +
+	m_read_model.IsDiscounted + `, ` +
+	m_read_model.DiscountEndsAt + `, ` +
+	m_read_model.NextPriceChangeAt + `, ` +
+	m_read_model.ProjectedAt
@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// slowQueryLog logs repository reads that take longer than threshold.
+// Only the query name and a summary of parameter names and types are logged,
+// never parameter values.
+type slowQueryLog struct {
+	log       *zap.Logger
+	threshold time.Duration
+	now       func() time.Time
+}
+
+func newSlowQueryLog(log *zap.Logger, threshold time.Duration) slowQueryLog {
+	return slowQueryLog{log: log, threshold: threshold, now: time.Now}
+}
+
+// track runs fn and logs a warning when it exceeds the threshold.
+// A nil logger or a threshold <= 0 disables logging.
+func (s slowQueryLog) track(name string, params map[string]any, fn func() error) error {
+	if s.log == nil || s.threshold <= 0 {
+		return fn()
+	}
+
+	start := s.now()
+	err := fn()
+	elapsed := s.now().Sub(start)
+
+	if elapsed > s.threshold {
+		s.log.Warn("slow spanner query",
+			zap.String("query", name),
+			zap.Duration("duration", elapsed),
+			zap.String("params", summarizeParams(params)),
+			zap.Bool("failed", err != nil),
+		)
+	}
+	return err
+}
+
+// summarizeParams renders params as sorted "name:type" pairs.
+func summarizeParams(params map[string]any) string {
+	parts := make([]string, 0, len(params))
+	for name, v := range params {
+		parts = append(parts, fmt.Sprintf("%s:%T", name, v))
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ",")
+}
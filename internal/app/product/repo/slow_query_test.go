@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// steppingClock advances by step on every call.
+func steppingClock(step time.Duration) func() time.Time {
+	t := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+func TestSlowQueryLog_WarnsAboveThreshold(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	s := newSlowQueryLog(zap.New(core), 100*time.Millisecond)
+	s.now = steppingClock(time.Second)
+
+	err := s.track("ListActive", map[string]any{"status": "active", "category": "secret-category"}, func() error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := logs.FilterMessage("slow spanner query").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 slow-query warning, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["query"] != "ListActive" {
+		t.Fatalf("expected query name ListActive, got %v", fields["query"])
+	}
+	params, _ := fields["params"].(string)
+	if params != "category:string,status:string" {
+		t.Fatalf("unexpected params summary %q", params)
+	}
+	if strings.Contains(params, "secret-category") {
+		t.Fatal("params summary must not include raw values")
+	}
+}
+
+func TestSlowQueryLog_QuietBelowThreshold(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	s := newSlowQueryLog(zap.New(core), time.Minute)
+	s.now = steppingClock(time.Second)
+
+	wantErr := errors.New("boom")
+	if err := s.track("CountActive", nil, func() error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Fatalf("expected fn error to pass through, got %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warnings, got %d", logs.Len())
+	}
+}
@@ -20,7 +20,8 @@ func NewActivateProductInteractor(committer commitplanner.Applier, repo contract
 }
 
 type ActivateProductRequest struct {
-	ProductID string
+	ProductID       string
+	RestoreDiscount bool // re-apply the discount dropped by the last deactivation if still valid
 }
 
 func (it *ActivateProductInteractor) Execute(ctx context.Context, req *ActivateProductRequest) error {
@@ -29,7 +30,7 @@ func (it *ActivateProductInteractor) Execute(ctx context.Context, req *ActivateP
 		return err
 	}
 
-	if err := product.Activate(it.ticker.Now()); err != nil {
+	if err := product.Activate(it.ticker.Now(), req.RestoreDiscount); err != nil {
 		return err
 	}
 
@@ -40,10 +41,16 @@ func (it *ActivateProductInteractor) Execute(ctx context.Context, req *ActivateP
 	}
 
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return err
 		}
+		plan.AddEvent(mut, event)
 	}
 
-	return it.committer.Apply(ctx, plan)
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	product.MarkPersisted()
+	return nil
 }
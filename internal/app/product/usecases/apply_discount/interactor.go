@@ -11,21 +11,77 @@ import (
 )
 
 type ApplyDiscountInteractor struct {
-	committer commitplanner.Applier
-	repo      contract.ProductRepository
-	eventRepo contract.EventRepository
-	ticker    common.Ticker
+	committer     commitplanner.Applier
+	repo          contract.ProductRepository
+	eventRepo     contract.EventRepository
+	ticker        common.Ticker
+	maxBackdating time.Duration // how far a requested start may be behind now; 0 = any
+	skewTolerance time.Duration // how far a requested start may be ahead of now
 }
 
-func NewApplyDiscountInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *ApplyDiscountInteractor {
-	return &ApplyDiscountInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+// Option customises an ApplyDiscountInteractor.
+type Option func(*ApplyDiscountInteractor)
+
+// WithMaxBackdating refuses discounts starting more than window before the
+// server's now with domain.ErrDiscountBackdatedTooFar. The default of zero
+// accepts any past start, as data migrations need.
+func WithMaxBackdating(window time.Duration) Option {
+	return func(it *ApplyDiscountInteractor) { it.maxBackdating = window }
+}
+
+// WithClockSkewTolerance accepts discounts starting up to tolerance after the
+// server's now, absorbing clock differences with clients that ask for a
+// discount starting "now". The default of zero refuses any future start.
+func WithClockSkewTolerance(tolerance time.Duration) Option {
+	return func(it *ApplyDiscountInteractor) { it.skewTolerance = tolerance }
+}
+
+func NewApplyDiscountInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker, opts ...Option) *ApplyDiscountInteractor {
+	it := &ApplyDiscountInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it
 }
 
 type ApplyDiscountRequest struct {
 	ProductID  string
 	Percentage string
-	StartsAt   time.Time
+	StartsAt   time.Time // zero with a Duration = starts now
 	EndsAt     time.Time
+	Duration   *time.Duration // when set, the discount ends at StartsAt+Duration and EndsAt is ignored
+}
+
+// startsAt resolves when the requested discount starts; a zero StartsAt with
+// a Duration means now.
+func (req *ApplyDiscountRequest) startsAt(now time.Time) time.Time {
+	if req.Duration != nil && req.StartsAt.IsZero() {
+		return now
+	}
+	return req.StartsAt
+}
+
+// Validate runs the checks Execute would make for req without applying or
+// persisting anything. It returns a *domain.ValidationError listing every
+// violation, nil when Execute would succeed, or the error that prevented
+// validation, e.g. domain.ErrProductNotFound.
+func (it *ApplyDiscountInteractor) Validate(ctx context.Context, req *ApplyDiscountRequest) error {
+	product, err := it.repo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return err
+	}
+
+	now := it.ticker.Now()
+	startsAt, endsAt := req.startsAt(now), req.EndsAt
+	if req.Duration != nil {
+		if *req.Duration <= 0 {
+			var verr domain.ValidationError
+			verr.Add("duration", domain.ErrDiscountInvalidDuration)
+			return &verr
+		}
+		endsAt = startsAt.Add(*req.Duration)
+	}
+	return product.ValidateDiscount(req.Percentage, startsAt, endsAt, now, it.skewTolerance, it.maxBackdating)
 }
 
 func (it *ApplyDiscountInteractor) Execute(ctx context.Context, req *ApplyDiscountRequest) error {
@@ -34,12 +90,19 @@ func (it *ApplyDiscountInteractor) Execute(ctx context.Context, req *ApplyDiscou
 		return err
 	}
 
-	discount, err := domain.NewDiscount(req.Percentage, req.StartsAt, req.EndsAt)
+	now := it.ticker.Now()
+
+	var discount *domain.Discount
+	if req.Duration != nil {
+		discount, err = domain.NewDiscountForDuration(req.Percentage, req.startsAt(now), *req.Duration)
+	} else {
+		discount, err = domain.NewDiscount(req.Percentage, req.StartsAt, req.EndsAt)
+	}
 	if err != nil {
 		return err
 	}
 
-	if err := product.ApplyDiscount(discount, it.ticker.Now()); err != nil {
+	if err := product.ApplyDiscount(discount.WithMaxBackdating(it.maxBackdating).WithSkewTolerance(it.skewTolerance), now); err != nil {
 		return err
 	}
 
@@ -50,10 +113,16 @@ func (it *ApplyDiscountInteractor) Execute(ctx context.Context, req *ApplyDiscou
 	}
 
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return err
 		}
+		plan.AddEvent(mut, event)
 	}
 
-	return it.committer.Apply(ctx, plan)
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	product.MarkPersisted()
+	return nil
 }
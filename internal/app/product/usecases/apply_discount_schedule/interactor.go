@@ -0,0 +1,149 @@
+package applydiscountschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// BatchSize is the number of schedule entries committed per plan. Each entry
+// produces a product update and an event insert, which keeps a batch well
+// below Spanner's per-commit mutation limit.
+const BatchSize = 200
+
+// ApplyDiscountScheduleInteractor applies a list of current or upcoming
+// discounts prepared ahead of a campaign.
+type ApplyDiscountScheduleInteractor struct {
+	committer commitplanner.Applier
+	repo      contract.ProductRepository
+	eventRepo contract.EventRepository
+	ticker    common.Ticker
+}
+
+func NewApplyDiscountScheduleInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *ApplyDiscountScheduleInteractor {
+	return &ApplyDiscountScheduleInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+}
+
+type ScheduleEntry struct {
+	ProductID  string
+	Percentage string
+	StartsAt   time.Time
+	EndsAt     time.Time
+}
+
+type ApplyDiscountScheduleRequest struct {
+	Entries []ScheduleEntry
+}
+
+// EntryResult reports the outcome of one schedule entry; Err is nil when the
+// discount was committed.
+type EntryResult struct {
+	ProductID string
+	Err       error
+}
+
+// Execute validates and applies every entry, returning one result per entry in
+// request order. Invalid entries are skipped without affecting the rest of
+// their batch; a failed commit marks every entry of that batch as failed.
+// A schedule naming a product more than once is rejected whole with
+// ErrDuplicateIDInBatch before anything is committed.
+func (it *ApplyDiscountScheduleInteractor) Execute(ctx context.Context, req *ApplyDiscountScheduleRequest) ([]EntryResult, error) {
+	if err := checkDistinctProducts(req.Entries); err != nil {
+		return nil, err
+	}
+
+	results := make([]EntryResult, len(req.Entries))
+	for i, e := range req.Entries {
+		results[i].ProductID = e.ProductID
+	}
+
+	for start := 0; start < len(req.Entries); start += BatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+BatchSize, len(req.Entries))
+		it.applyBatch(ctx, req.Entries[start:end], results[start:end])
+	}
+	return results, nil
+}
+
+// checkDistinctProducts rejects entries naming a product twice. A product
+// holds a single discount, so a later entry would silently replace an earlier
+// one already reported as applied.
+func checkDistinctProducts(entries []ScheduleEntry) error {
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if seen[e.ProductID] {
+			return fmt.Errorf("%w: %s", domain.ErrDuplicateIDInBatch, e.ProductID)
+		}
+		seen[e.ProductID] = true
+	}
+	return nil
+}
+
+// applyBatch loads and updates each entry's product, then commits all accepted
+// entries together.
+func (it *ApplyDiscountScheduleInteractor) applyBatch(ctx context.Context, entries []ScheduleEntry, results []EntryResult) {
+	now := it.ticker.Now()
+	var order []*domain.Product
+	var applied []int
+
+	for i, e := range entries {
+		product, err := it.repo.GetByID(ctx, e.ProductID)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		order = append(order, product)
+
+		discount, err := domain.NewDiscount(e.Percentage, e.StartsAt, e.EndsAt)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := product.ScheduleDiscount(discount, now); err != nil {
+			results[i].Err = err
+			continue
+		}
+		applied = append(applied, i)
+	}
+
+	if len(applied) == 0 {
+		return
+	}
+
+	plan := commitplanner.NewPlan()
+	for _, product := range order {
+		if mut := it.repo.UpdateMut(product); mut != nil {
+			plan.Add(mut)
+		}
+		for _, event := range product.Events() {
+			mut, err := it.eventRepo.InsertMut(event)
+			if err != nil {
+				failAll(results, applied, err)
+				return
+			}
+			plan.AddEvent(mut, event)
+		}
+	}
+
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		failAll(results, applied, err)
+		return
+	}
+	for _, product := range order {
+		product.MarkPersisted()
+	}
+}
+
+// failAll marks every applied entry of a batch as failed with err.
+func failAll(results []EntryResult, applied []int, err error) {
+	for _, i := range applied {
+		results[i].Err = err
+	}
+}
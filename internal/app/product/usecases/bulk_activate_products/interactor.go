@@ -0,0 +1,55 @@
+package bulkactivateproducts
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	chunkedcommit "github.com/product-catalog-service/internal/app/product/usecases/chunked_commit"
+)
+
+// BulkActivateProductsInteractor reactivates every inactive product in a
+// category, e.g. to recover from a category-wide deactivation.
+type BulkActivateProductsInteractor struct {
+	repo   contract.ProductRepository
+	chunks *chunkedcommit.Committer
+	ticker common.Ticker
+}
+
+func NewBulkActivateProductsInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *BulkActivateProductsInteractor {
+	return &BulkActivateProductsInteractor{repo: repo, chunks: chunkedcommit.NewCommitter(committer, repo, eventRepo), ticker: ticker}
+}
+
+type BulkActivateProductsRequest struct {
+	Category        string
+	RestoreDiscount bool // re-apply each product's discount dropped by its last deactivation if still valid
+}
+
+// Execute returns the number of products activated. Active and archived
+// products are skipped. Products are committed in chunks; on error the count
+// covers the chunks already committed, and retrying picks up the rest since
+// activated products are skipped.
+func (it *BulkActivateProductsInteractor) Execute(ctx context.Context, req *BulkActivateProductsRequest) (int, error) {
+	if req.Category == "" {
+		return 0, domain.ErrProductCategoryRequired
+	}
+
+	products, err := it.repo.ListByCategory(ctx, req.Category)
+	if err != nil {
+		return 0, err
+	}
+
+	var inactive []*domain.Product
+	for _, product := range products {
+		if product.Status() == domain.ProductStatusInactive && !product.IsArchived() {
+			inactive = append(inactive, product)
+		}
+	}
+
+	now := it.ticker.Now()
+	return it.chunks.Commit(ctx, inactive, func(p *domain.Product) error {
+		return p.Activate(now, req.RestoreDiscount)
+	})
+}
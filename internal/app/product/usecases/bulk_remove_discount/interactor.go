@@ -0,0 +1,95 @@
+package bulkremovediscount
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	chunkedcommit "github.com/product-catalog-service/internal/app/product/usecases/chunked_commit"
+)
+
+// BulkRemoveDiscountInteractor ends a campaign by removing the discount from
+// every active product in a category.
+type BulkRemoveDiscountInteractor struct {
+	repo   contract.ProductRepository
+	chunks *chunkedcommit.Committer
+	ticker common.Ticker
+}
+
+func NewBulkRemoveDiscountInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *BulkRemoveDiscountInteractor {
+	return &BulkRemoveDiscountInteractor{repo: repo, chunks: chunkedcommit.NewCommitter(committer, repo, eventRepo), ticker: ticker}
+}
+
+type BulkRemoveDiscountRequest struct {
+	Category string
+}
+
+// Outcome is what the bulk removal did to one product.
+type Outcome string
+
+const (
+	OutcomeRemoved Outcome = "removed" // the product's discount was removed
+	OutcomeSkipped Outcome = "skipped" // the product had no discount
+)
+
+// ProductOutcome reports the outcome for one product.
+type ProductOutcome struct {
+	ProductID string
+	Outcome   Outcome
+}
+
+// BulkRemoveDiscountResult summarises a bulk removal so an admin can confirm
+// the campaign fully ended.
+type BulkRemoveDiscountResult struct {
+	Removed  int
+	Skipped  int
+	Products []ProductOutcome // every active product in the category, by ID
+}
+
+// Execute removes the discount from every active product in the category and
+// reports each product's outcome. Removals are committed in chunks; on error
+// the result covers the chunks already committed, and retrying picks up the
+// rest since products without a discount are skipped.
+func (it *BulkRemoveDiscountInteractor) Execute(ctx context.Context, req *BulkRemoveDiscountRequest) (*BulkRemoveDiscountResult, error) {
+	if req.Category == "" {
+		return nil, domain.ErrProductCategoryRequired
+	}
+
+	products, err := it.repo.ListByCategory(ctx, req.Category)
+	if err != nil {
+		return nil, err
+	}
+	products = slices.DeleteFunc(products, func(p *domain.Product) bool {
+		return !p.IsActive() || p.IsArchived()
+	})
+	slices.SortFunc(products, func(a, b *domain.Product) int { return strings.Compare(a.ID(), b.ID()) })
+
+	res := &BulkRemoveDiscountResult{}
+	var discounted []*domain.Product
+	for _, product := range products {
+		if product.Discount() == nil {
+			res.Skipped++
+			res.Products = append(res.Products, ProductOutcome{ProductID: product.ID(), Outcome: OutcomeSkipped})
+		} else {
+			discounted = append(discounted, product)
+		}
+	}
+
+	now := it.ticker.Now()
+	removed, err := it.chunks.Commit(ctx, discounted, func(p *domain.Product) error {
+		return p.RemoveDiscount(now)
+	})
+	for _, product := range discounted[:removed] {
+		res.Removed++
+		res.Products = append(res.Products, ProductOutcome{ProductID: product.ID(), Outcome: OutcomeRemoved})
+	}
+	if err != nil {
+		return res, err
+	}
+	slices.SortFunc(res.Products, func(a, b ProductOutcome) int { return strings.Compare(a.ProductID, b.ProductID) })
+	return res, nil
+}
@@ -0,0 +1,69 @@
+// Package chunkedcommit writes a change to many products across several
+// commits, for the category-wide use cases.
+package chunkedcommit
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// chunkSize caps the products written per commit so a large category stays
+// well under Spanner's per-transaction mutation limit.
+const chunkSize = 200
+
+// Committer applies mutate to products and commits them chunkSize at a time,
+// each chunk with its events in one plan.
+type Committer struct {
+	committer commitplanner.Applier
+	repo      contract.ProductRepository
+	eventRepo contract.EventRepository
+}
+
+func NewCommitter(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository) *Committer {
+	return &Committer{committer: committer, repo: repo, eventRepo: eventRepo}
+}
+
+// Commit returns the number of products committed, always a prefix of
+// products. On error it stops and the count covers the chunks already
+// committed; the failing chunk is not written.
+func (c *Committer) Commit(ctx context.Context, products []*domain.Product, mutate func(*domain.Product) error) (int, error) {
+	committed := 0
+	for start := 0; start < len(products); start += chunkSize {
+		chunk := products[start:min(start+chunkSize, len(products))]
+		if err := c.commitChunk(ctx, chunk, mutate); err != nil {
+			return committed, err
+		}
+		committed += len(chunk)
+	}
+	return committed, nil
+}
+
+func (c *Committer) commitChunk(ctx context.Context, products []*domain.Product, mutate func(*domain.Product) error) error {
+	plan := commitplanner.NewPlan()
+	for _, product := range products {
+		if err := mutate(product); err != nil {
+			return err
+		}
+		if mut := c.repo.UpdateMut(product); mut != nil {
+			plan.Add(mut)
+		}
+		for _, event := range product.Events() {
+			mut, err := c.eventRepo.InsertMut(event)
+			if err != nil {
+				return err
+			}
+			plan.AddEvent(mut, event)
+		}
+	}
+
+	if err := c.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	for _, product := range products {
+		product.MarkPersisted()
+	}
+	return nil
+}
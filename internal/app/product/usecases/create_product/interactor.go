@@ -2,6 +2,12 @@ package createproduct
 
 import (
 	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
 
 	"github.com/product-catalog-service/common"
 	"github.com/product-catalog-service/common/commitplanner"
@@ -9,34 +15,93 @@ import (
 	"github.com/product-catalog-service/internal/app/product/contract"
 )
 
-const basePrice = 100
+// Config resolves the currency of new products whose request omits one.
+type Config struct {
+	DefaultCurrency    string            // used for categories without an entry
+	CategoryCurrencies map[string]string // category → currency code
+}
+
+// DefaultConfig prices every category in USD.
+func DefaultConfig() Config {
+	return Config{DefaultCurrency: "USD"}
+}
+
+// Validate reports the first configured currency that is not a valid code.
+func (c Config) Validate() error {
+	if _, err := domain.NewMoney(0, c.DefaultCurrency); err != nil {
+		return fmt.Errorf("default currency %q: %w", c.DefaultCurrency, err)
+	}
+	for category, currency := range c.CategoryCurrencies {
+		if _, err := domain.NewMoney(0, currency); err != nil {
+			return fmt.Errorf("currency %q for category %q: %w", currency, category, err)
+		}
+	}
+	return nil
+}
+
+func (c Config) currencyFor(category string) string {
+	if currency, ok := c.CategoryCurrencies[category]; ok {
+		return currency
+	}
+	return c.DefaultCurrency
+}
 
 type CreateProductInteractor struct {
 	committer commitplanner.Applier
 	repo      contract.ProductRepository
 	eventRepo contract.EventRepository
 	ticker    common.Ticker
+	cfg       Config
 }
 
-func NewCreateProductInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *CreateProductInteractor {
-	return &CreateProductInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+func NewCreateProductInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker, cfg Config) *CreateProductInteractor {
+	return &CreateProductInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker, cfg: cfg}
 }
 
 type CreateProductRequest struct {
 	Name        string
 	Description string
 	Category    string
+	Price       string // decimal such as "19.99"; "" = Amount, one of which is required
+	Currency    string // "" = the category's configured currency
+	Amount      *int64 // smallest currency unit, e.g. cents; ignored when Price is set
+	// PublishAt stages the product: it is created inactive and goes live at
+	// this time. nil = active immediately.
+	PublishAt *time.Time
+	// UnpublishAt takes the product down at this time; it must be after
+	// PublishAt. nil = no scheduled takedown.
+	UnpublishAt *time.Time
 }
 
 func (it *CreateProductInteractor) Execute(ctx context.Context, req *CreateProductRequest) (string, error) {
-	money, err := domain.NewMoney(basePrice, "USD")
+	if err := validate(req); err != nil {
+		return "", err
+	}
+
+	money, err := it.price(req)
 	if err != nil {
 		return "", err
 	}
-	product, err := domain.NewProduct(req.Name, req.Description, req.Category, money, it.ticker.Now())
+	now := it.ticker.Now()
+	var product *domain.Product
+	if req.PublishAt != nil {
+		product, err = domain.NewStagedProduct(req.Name, req.Description, req.Category, money, *req.PublishAt, now)
+	} else {
+		product, err = domain.NewProduct(req.Name, req.Description, req.Category, money, now)
+	}
 	if err != nil {
 		return "", err
 	}
+	if req.UnpublishAt != nil {
+		if err := product.SetUnpublishAt(req.UnpublishAt, now); err != nil {
+			return "", err
+		}
+	}
+	taken, err := it.repo.SlugsWithPrefix(ctx, product.Slug())
+	if err != nil {
+		return "", err
+	}
+	product.SetSlug(domain.UniqueSlug(product.Slug(), taken))
 
 	plan := commitplanner.NewPlan()
 
@@ -45,14 +110,66 @@ func (it *CreateProductInteractor) Execute(ctx context.Context, req *CreateProdu
 	}
 
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return "", err
 		}
+		plan.AddEvent(mut, event)
 	}
 
 	if err := it.committer.Apply(ctx, plan); err != nil {
+		// A concurrent create took the same slug between the lookup and the commit.
+		if spanner.ErrCode(err) == codes.AlreadyExists {
+			return "", domain.ErrSlugConflict
+		}
 		return "", err
 	}
+	product.MarkPersisted()
 
 	return product.ID(), nil
 }
+
+// price builds the base price from the request, resolving an omitted currency
+// from the category.
+func (it *CreateProductInteractor) price(req *CreateProductRequest) (*domain.Money, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = it.cfg.currencyFor(req.Category)
+	}
+	switch {
+	case req.Price != "":
+		return domain.NewMoneyFromDecimalString(req.Price, currency)
+	case req.Amount != nil:
+		return domain.NewMoney(*req.Amount, currency)
+	default:
+		return nil, domain.ErrProductBasePriceRequired
+	}
+}
+
+// validate reports every invalid field at once; NewProduct still enforces the
+// invariants as a backstop.
+func validate(req *CreateProductRequest) error {
+	var verr domain.ValidationError
+	switch n := utf8.RuneCountInString(req.Name); {
+	case n == 0:
+		verr.Add("name", domain.ErrProductNameRequired)
+	case n > domain.MaxNameLength:
+		verr.Add("name", domain.ErrProductFieldTooLong)
+	}
+	switch n := utf8.RuneCountInString(req.Category); {
+	case n == 0:
+		verr.Add("category", domain.ErrProductCategoryRequired)
+	case n > domain.MaxCategoryLength:
+		verr.Add("category", domain.ErrProductFieldTooLong)
+	}
+	if utf8.RuneCountInString(req.Description) > domain.MaxDescriptionLength {
+		verr.Add("description", domain.ErrProductFieldTooLong)
+	}
+	if req.Price == "" && req.Amount == nil {
+		verr.Add("price", domain.ErrProductBasePriceRequired)
+	}
+	if req.PublishAt != nil && req.UnpublishAt != nil && !req.UnpublishAt.After(*req.PublishAt) {
+		verr.Add("unpublish_at", domain.ErrUnpublishBeforePublish)
+	}
+	return verr.OrNil()
+}
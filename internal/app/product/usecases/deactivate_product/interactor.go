@@ -21,6 +21,7 @@ func NewDeactivateProductInteractor(committer commitplanner.Applier, repo contra
 
 type DeactivateProductRequest struct {
 	ProductID string
+	Force     bool // drop a scheduled, not-yet-started discount instead of failing
 }
 
 func (it *DeactivateProductInteractor) Execute(ctx context.Context, req *DeactivateProductRequest) error {
@@ -29,7 +30,7 @@ func (it *DeactivateProductInteractor) Execute(ctx context.Context, req *Deactiv
 		return err
 	}
 
-	if err := product.Deactivate(it.ticker.Now()); err != nil {
+	if err := product.Deactivate(it.ticker.Now(), req.Force); err != nil {
 		return err
 	}
 
@@ -39,10 +40,16 @@ func (it *DeactivateProductInteractor) Execute(ctx context.Context, req *Deactiv
 		plan.Add(mut)
 	}
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return err
 		}
+		plan.AddEvent(mut, event)
 	}
 
-	return it.committer.Apply(ctx, plan)
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	product.MarkPersisted()
+	return nil
 }
@@ -0,0 +1,54 @@
+package forcecleardiscount
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// ForceClearDiscountInteractor removes a product's discount without loading
+// the aggregate. It is an operator escape hatch for rows whose stored discount
+// fails domain validation, which makes the regular remove path unusable.
+type ForceClearDiscountInteractor struct {
+	committer commitplanner.Applier
+	repo      contract.ProductRepository
+	queryRepo contract.QueryRepository
+	eventRepo contract.EventRepository
+	ticker    common.Ticker
+}
+
+func NewForceClearDiscountInteractor(committer commitplanner.Applier, repo contract.ProductRepository, queryRepo contract.QueryRepository, eventRepo contract.EventRepository, ticker common.Ticker) *ForceClearDiscountInteractor {
+	return &ForceClearDiscountInteractor{committer: committer, repo: repo, queryRepo: queryRepo, eventRepo: eventRepo, ticker: ticker}
+}
+
+type ForceClearDiscountRequest struct {
+	ProductID string
+}
+
+// Execute nulls the discount columns and records a DiscountRemovedEvent,
+// whatever state the stored discount is in.
+func (it *ForceClearDiscountInteractor) Execute(ctx context.Context, req *ForceClearDiscountRequest) error {
+	// Existence is checked without decoding the row, which may be malformed.
+	exists, err := it.queryRepo.ExistsBatch(ctx, []string{req.ProductID})
+	if err != nil {
+		return err
+	}
+	if !exists[req.ProductID] {
+		return domain.ErrProductNotFound
+	}
+
+	event := domain.NewDiscountRemovedEvent(req.ProductID, it.ticker.Now())
+	eventMut, err := it.eventRepo.InsertMut(event)
+	if err != nil {
+		return err
+	}
+
+	plan := commitplanner.NewPlan()
+	plan.Add(it.repo.ForceClearDiscountMut(req.ProductID))
+	plan.AddEvent(eventMut, event)
+
+	return it.committer.Apply(ctx, plan)
+}
@@ -21,6 +21,9 @@ func NewRemoveDiscountInteractor(committer commitplanner.Applier, repo contract.
 
 type RemoveDiscountRequest struct {
 	ProductID string
+	// Idempotent makes removing a non-existent discount a successful no-op
+	// instead of returning domain.ErrNoActiveDiscount.
+	Idempotent bool
 }
 
 func (it *RemoveDiscountInteractor) Execute(ctx context.Context, req *RemoveDiscountRequest) error {
@@ -29,6 +32,10 @@ func (it *RemoveDiscountInteractor) Execute(ctx context.Context, req *RemoveDisc
 		return err
 	}
 
+	if req.Idempotent && product.Discount() == nil {
+		return nil
+	}
+
 	if err := product.RemoveDiscount(it.ticker.Now()); err != nil {
 		return err
 	}
@@ -39,10 +46,16 @@ func (it *RemoveDiscountInteractor) Execute(ctx context.Context, req *RemoveDisc
 		plan.Add(mut)
 	}
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return err
 		}
+		plan.AddEvent(mut, event)
 	}
 
-	return it.committer.Apply(ctx, plan)
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+	product.MarkPersisted()
+	return nil
 }
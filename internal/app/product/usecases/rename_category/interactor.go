@@ -0,0 +1,53 @@
+package renamecategory
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	chunkedcommit "github.com/product-catalog-service/internal/app/product/usecases/chunked_commit"
+)
+
+// RenameCategoryInteractor moves every product in one category to another.
+type RenameCategoryInteractor struct {
+	repo   contract.ProductRepository
+	chunks *chunkedcommit.Committer
+	ticker common.Ticker
+}
+
+func NewRenameCategoryInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *RenameCategoryInteractor {
+	return &RenameCategoryInteractor{repo: repo, chunks: chunkedcommit.NewCommitter(committer, repo, eventRepo), ticker: ticker}
+}
+
+type RenameCategoryRequest struct {
+	OldName string
+	NewName string
+}
+
+// Execute returns the number of products moved to NewName. Products are
+// committed in chunks; on error the count covers the chunks already committed,
+// and retrying picks up the rest since moved products no longer match OldName.
+func (it *RenameCategoryInteractor) Execute(ctx context.Context, req *RenameCategoryRequest) (int, error) {
+	if req.OldName == "" || req.NewName == "" {
+		return 0, domain.ErrProductCategoryRequired
+	}
+	if utf8.RuneCountInString(req.NewName) > domain.MaxCategoryLength {
+		return 0, domain.ErrProductFieldTooLong
+	}
+	if req.OldName == req.NewName {
+		return 0, nil
+	}
+
+	products, err := it.repo.ListByCategory(ctx, req.OldName)
+	if err != nil {
+		return 0, err
+	}
+
+	now := it.ticker.Now()
+	return it.chunks.Commit(ctx, products, func(p *domain.Product) error {
+		return p.RenameCategory(req.OldName, req.NewName, now)
+	})
+}
@@ -0,0 +1,61 @@
+package unpublishscheduled
+
+import (
+	"context"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/contract"
+)
+
+// UnpublishScheduledInteractor deactivates live products whose unpublish time
+// has come, so time-limited products come down without a manual deactivation.
+type UnpublishScheduledInteractor struct {
+	committer commitplanner.Applier
+	repo      contract.ProductRepository
+	eventRepo contract.EventRepository
+	ticker    common.Ticker
+}
+
+func NewUnpublishScheduledInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *UnpublishScheduledInteractor {
+	return &UnpublishScheduledInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+}
+
+// Execute deactivates up to limit due products in one commit and returns how
+// many it deactivated. Callers run it periodically, repeating while it returns
+// limit; limit should keep a run under Spanner's per-transaction mutation limit.
+func (it *UnpublishScheduledInteractor) Execute(ctx context.Context, limit int) (int, error) {
+	now := it.ticker.Now()
+	products, err := it.repo.ListDueUnpublish(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(products) == 0 {
+		return 0, nil
+	}
+
+	plan := commitplanner.NewPlan()
+	for _, product := range products {
+		if err := product.Unpublish(now); err != nil {
+			return 0, err
+		}
+		if mut := it.repo.UpdateMut(product); mut != nil {
+			plan.Add(mut)
+		}
+		for _, event := range product.Events() {
+			mut, err := it.eventRepo.InsertMut(event)
+			if err != nil {
+				return 0, err
+			}
+			plan.AddEvent(mut, event)
+		}
+	}
+
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return 0, err
+	}
+	for _, product := range products {
+		product.MarkPersisted()
+	}
+	return len(products), nil
+}
@@ -2,10 +2,18 @@ package updateproduct
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
 
 	"github.com/product-catalog-service/common"
 	"github.com/product-catalog-service/common/commitplanner"
 	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
 )
 
 type UpdateProductInteractor struct {
@@ -13,10 +21,58 @@ type UpdateProductInteractor struct {
 	repo      contract.ProductRepository
 	eventRepo contract.EventRepository
 	ticker    common.Ticker
+	// slugFollowsName regenerates the slug on rename; off keeps product URLs stable.
+	slugFollowsName bool
+	maxCategories   int // cap on categories per product, the primary one included
+	// fieldChangeEvents raises one event per changed field instead of a single
+	// product.updated event.
+	fieldChangeEvents bool
+	maxBackdating     time.Duration // how far a discount's start may be behind now; 0 = any
+	skewTolerance     time.Duration // how far a discount's start may be ahead of now
+}
+
+// Option customises an UpdateProductInteractor.
+type Option func(*UpdateProductInteractor)
+
+// WithSlugFollowsName regenerates a product's slug from its new name whenever
+// it is renamed. Old slugs then stop resolving.
+func WithSlugFollowsName() Option {
+	return func(it *UpdateProductInteractor) { it.slugFollowsName = true }
+}
+
+// WithFieldChangeEvents raises a dedicated event for each changed field, such as
+// product.name_changed or product.price_changed, instead of one
+// product.updated event listing them, for consumers that route by field.
+func WithFieldChangeEvents() Option {
+	return func(it *UpdateProductInteractor) { it.fieldChangeEvents = true }
+}
+
+// WithMaxBackdating refuses discounts starting more than window before the
+// server's now with domain.ErrDiscountBackdatedTooFar, as ApplyDiscountInteractor
+// does. The default of zero accepts any past start.
+func WithMaxBackdating(window time.Duration) Option {
+	return func(it *UpdateProductInteractor) { it.maxBackdating = window }
 }
 
-func NewUpdateProductInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker) *UpdateProductInteractor {
-	return &UpdateProductInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker}
+// WithClockSkewTolerance accepts discounts starting up to tolerance after the
+// server's now, as ApplyDiscountInteractor does. The default of zero refuses
+// any future start.
+func WithClockSkewTolerance(tolerance time.Duration) Option {
+	return func(it *UpdateProductInteractor) { it.skewTolerance = tolerance }
+}
+
+// WithMaxCategories caps how many categories, the primary one included, a
+// product can be listed in. The default is domain.DefaultMaxCategories.
+func WithMaxCategories(n int) Option {
+	return func(it *UpdateProductInteractor) { it.maxCategories = n }
+}
+
+func NewUpdateProductInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker, opts ...Option) *UpdateProductInteractor {
+	it := &UpdateProductInteractor{committer: committer, repo: repo, eventRepo: eventRepo, ticker: ticker, maxCategories: domain.DefaultMaxCategories}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it
 }
 
 type UpdateProductRequest struct {
@@ -24,27 +80,206 @@ type UpdateProductRequest struct {
 	Name        *string
 	Description *string
 	Category    *string
+	BasePrice   *PriceUpdate    // nil = leave price untouched
+	Discount    *DiscountUpdate // nil = leave discount untouched
+	Featured    *bool           // nil = leave featured flag untouched
+	MediaURLs   *[]string       // nil = leave media untouched; empty = remove all media
+	Stock       *int64          // nil = leave stock untouched; otherwise the new units on hand
+	// QuantityTiers replaces the volume discounts; nil = leave them untouched,
+	// empty = remove them all.
+	QuantityTiers *[]QuantityTierUpdate
+	// AddCategories and RemoveCategories list the product in or unlist it from
+	// additional categories. Removals are applied first.
+	AddCategories    []string
+	RemoveCategories []string
+	// SetAttributes and RemoveAttributes set or delete product attributes by
+	// key. Removals are applied first.
+	SetAttributes    map[string]string
+	RemoveAttributes []string
+	PublishAt        *ScheduleUpdate // nil = leave the launch schedule untouched
+	UnpublishAt      *ScheduleUpdate // nil = leave the takedown schedule untouched
+	// AllowCurrencyChange lets BasePrice change the product's currency, for
+	// admin corrections; otherwise that fails with ErrCurrencyChangeNotAllowed.
+	AllowCurrencyChange bool
+}
+
+// PriceUpdate sets the base price as part of an update. Currency defaults to
+// the product's own, so an amount-only update never trips the currency guard.
+type PriceUpdate struct {
+	Price    string // decimal such as "19.99"; "" = Amount
+	Amount   int64  // smallest currency unit, e.g. cents; ignored when Price is set
+	Currency string // "" = the product's current currency
 }
 
-func (it *UpdateProductInteractor) Execute(ctx context.Context, req *UpdateProductRequest) error {
+// money builds the new base price, taking an omitted currency from current.
+func (upd *PriceUpdate) money(current *domain.Money) (*domain.Money, error) {
+	currency := upd.Currency
+	if currency == "" {
+		currency = current.Currency()
+	}
+	if upd.Price != "" {
+		return domain.NewMoneyFromDecimalString(upd.Price, currency)
+	}
+	return domain.NewMoney(upd.Amount, currency)
+}
+
+// validate checks the price as far as it can without the product's currency.
+func (upd *PriceUpdate) validate() error {
+	if upd.Currency != "" {
+		_, err := upd.money(nil)
+		return err
+	}
+	if upd.Price == "" && upd.Amount < 0 {
+		return domain.ErrNegativeAmount
+	}
+	return nil
+}
+
+// QuantityTierUpdate is one volume discount: Percentage off the unit price
+// when buying at least MinQuantity units.
+type QuantityTierUpdate struct {
+	MinQuantity int64
+	Percentage  string
+}
+
+// DiscountUpdate sets or clears the product discount as part of an update.
+// When Clear is true the current discount (if any) is removed and the other fields are ignored.
+type DiscountUpdate struct {
+	Clear      bool
+	Percentage string
+	StartsAt   time.Time
+	EndsAt     time.Time
+}
+
+// ScheduleUpdate schedules or cancels the product's launch or takedown as part
+// of an update. When Clear is true any schedule is cancelled and At is ignored.
+type ScheduleUpdate struct {
+	Clear bool
+	At    time.Time
+}
+
+// UpdateProductResult reports what an update changed.
+type UpdateProductResult struct {
+	Changed       bool
+	ChangedFields []domain.Field // sorted; every changed field, side effects included
+}
+
+// Execute applies the update. When nothing changes no commit is made and the
+// result has Changed == false.
+func (it *UpdateProductInteractor) Execute(ctx context.Context, req *UpdateProductRequest) (*UpdateProductResult, error) {
+	// An empty update cannot change anything; reject it before loading the product.
+	if req.empty() {
+		return nil, domain.ErrNoFieldsToUpdate
+	}
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
 	product, err := it.repo.GetByID(ctx, req.ProductID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var before *domain.Product
+	if it.fieldChangeEvents {
+		before = product.Snapshot()
 	}
 
 	if req.Name != nil {
 		if err := product.SetName(*req.Name); err != nil {
-			return err
+			return nil, err
+		}
+		if it.slugFollowsName && product.Changes().Dirty(domain.FieldName) {
+			if err := it.regenerateSlug(ctx, product); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if req.Description != nil {
 		product.SetDescription(*req.Description)
 	}
 	if req.Category != nil {
-		product.SetCategory(*req.Category)
+		if err := product.SetCategory(*req.Category); err != nil {
+			return nil, err
+		}
+	}
+	if req.BasePrice != nil {
+		price, err := req.BasePrice.money(product.BasePrice())
+		if err != nil {
+			return nil, err
+		}
+		setBasePrice := product.SetBasePrice
+		if req.AllowCurrencyChange {
+			setBasePrice = product.CorrectBasePrice
+		}
+		if !price.Equals(product.BasePrice()) {
+			if err := setBasePrice(price); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := it.ticker.Now()
+
+	// Record the plain-field update first; side effects below raise their own events.
+	if it.fieldChangeEvents {
+		product.RecordFieldChanges(before, now)
+	} else {
+		product.RecordUpdate(now)
+	}
+
+	if req.Discount != nil {
+		if err := it.applyDiscountUpdate(product, req.Discount, now); err != nil {
+			return nil, err
+		}
+	}
+	if req.Featured != nil {
+		product.SetFeatured(*req.Featured, now)
+	}
+	if req.MediaURLs != nil {
+		if err := product.SetMedia(*req.MediaURLs, now); err != nil {
+			return nil, err
+		}
+	}
+	if req.Stock != nil {
+		if err := product.SetStock(*req.Stock, now); err != nil {
+			return nil, err
+		}
+	}
+	if req.QuantityTiers != nil {
+		tiers, err := toQuantityTiers(*req.QuantityTiers)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.SetQuantityTiers(tiers, now); err != nil {
+			return nil, err
+		}
+	}
+	for _, category := range req.RemoveCategories {
+		if err := product.RemoveCategory(category, now); err != nil {
+			return nil, err
+		}
+	}
+	for _, category := range req.AddCategories {
+		if err := product.AddCategory(category, it.maxCategories, now); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range req.RemoveAttributes {
+		product.RemoveAttribute(key, now)
+	}
+	for _, key := range slices.Sorted(maps.Keys(req.SetAttributes)) {
+		if err := product.SetAttribute(key, req.SetAttributes[key], now); err != nil {
+			return nil, err
+		}
+	}
+	if err := applySchedule(product, req.PublishAt, req.UnpublishAt, now); err != nil {
+		return nil, err
 	}
 
-	product.RecordUpdate(it.ticker.Now())
+	changed := product.Changes().Fields()
+	if len(changed) == 0 {
+		return &UpdateProductResult{}, nil
+	}
 
 	plan := commitplanner.NewPlan()
 
@@ -53,10 +288,167 @@ func (it *UpdateProductInteractor) Execute(ctx context.Context, req *UpdateProdu
 	}
 
 	for _, event := range product.Events() {
-		if mut := it.eventRepo.InsertMut(event); mut != nil {
-			plan.Add(mut)
+		mut, err := it.eventRepo.InsertMut(event)
+		if err != nil {
+			return nil, err
+		}
+		plan.AddEvent(mut, event)
+	}
+
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		// A concurrent write took the regenerated slug after it was picked.
+		if spanner.ErrCode(err) == codes.AlreadyExists && slices.Contains(changed, domain.FieldSlug) {
+			return nil, domain.ErrSlugConflict
 		}
+		return nil, err
+	}
+	product.MarkPersisted()
+	return &UpdateProductResult{Changed: true, ChangedFields: changed}, nil
+}
+
+// regenerateSlug derives the slug from the product's current name, suffixing
+// it when another product already uses it.
+func (it *UpdateProductInteractor) regenerateSlug(ctx context.Context, product *domain.Product) error {
+	base := domain.Slugify(product.Name())
+	taken, err := it.repo.SlugsWithPrefix(ctx, base)
+	if err != nil {
+		return err
 	}
+	own := product.Slug()
+	taken = slices.DeleteFunc(taken, func(s string) bool { return s == own })
+	product.SetSlug(domain.UniqueSlug(base, taken))
+	return nil
+}
 
-	return it.committer.Apply(ctx, plan)
+func toQuantityTiers(upds []QuantityTierUpdate) ([]domain.QuantityTier, error) {
+	tiers := make([]domain.QuantityTier, 0, len(upds))
+	for _, u := range upds {
+		tier, err := domain.NewQuantityTier(u.MinQuantity, u.Percentage)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers, nil
+}
+
+// applySchedule applies the launch and takedown updates in an order that keeps
+// the launch before the takedown at every step: the takedown goes first when
+// it is cancelled or the new launch is not before the stored takedown.
+func applySchedule(product *domain.Product, publish, unpublish *ScheduleUpdate, now time.Time) error {
+	stored := product.UnpublishAt()
+	unpublishFirst := unpublish != nil && (unpublish.Clear ||
+		publish != nil && !publish.Clear && stored != nil && !publish.At.Before(*stored))
+
+	if unpublishFirst {
+		if err := product.SetUnpublishAt(scheduleTime(unpublish), now); err != nil {
+			return err
+		}
+	}
+	if publish != nil {
+		if err := product.SetPublishAt(scheduleTime(publish), now); err != nil {
+			return err
+		}
+	}
+	if unpublish != nil && !unpublishFirst {
+		return product.SetUnpublishAt(scheduleTime(unpublish), now)
+	}
+	return nil
+}
+
+// scheduleTime is the time upd sets, or nil when it cancels the schedule.
+func scheduleTime(upd *ScheduleUpdate) *time.Time {
+	if upd.Clear {
+		return nil
+	}
+	return &upd.At
+}
+
+func (it *UpdateProductInteractor) applyDiscountUpdate(product *domain.Product, upd *DiscountUpdate, now time.Time) error {
+	if upd.Clear {
+		if product.Discount() == nil {
+			return nil
+		}
+		return product.RemoveDiscount(now)
+	}
+
+	discount, err := domain.NewDiscount(upd.Percentage, upd.StartsAt, upd.EndsAt)
+	if err != nil {
+		return err
+	}
+	return product.ApplyDiscount(discount.WithMaxBackdating(it.maxBackdating).WithSkewTolerance(it.skewTolerance), now)
+}
+
+// empty reports whether the request leaves every field untouched.
+func (req *UpdateProductRequest) empty() bool {
+	return req.Name == nil && req.Description == nil && req.Category == nil &&
+		req.BasePrice == nil && req.Discount == nil && req.Featured == nil && req.MediaURLs == nil &&
+		req.Stock == nil && req.QuantityTiers == nil &&
+		len(req.AddCategories) == 0 && len(req.RemoveCategories) == 0 &&
+		len(req.SetAttributes) == 0 && len(req.RemoveAttributes) == 0 &&
+		req.PublishAt == nil && req.UnpublishAt == nil
+}
+
+// validate reports every invalid field that the request sets; the Product
+// setters still enforce the invariants as a backstop.
+func validate(req *UpdateProductRequest) error {
+	var verr domain.ValidationError
+	if req.Name != nil {
+		switch n := utf8.RuneCountInString(*req.Name); {
+		case n == 0:
+			verr.Add("name", domain.ErrProductNameRequired)
+		case n > domain.MaxNameLength:
+			verr.Add("name", domain.ErrProductFieldTooLong)
+		}
+	}
+	if req.Category != nil {
+		switch n := utf8.RuneCountInString(*req.Category); {
+		case n == 0:
+			verr.Add("category", domain.ErrProductCategoryRequired)
+		case n > domain.MaxCategoryLength:
+			verr.Add("category", domain.ErrProductFieldTooLong)
+		}
+	}
+	if req.Description != nil && utf8.RuneCountInString(*req.Description) > domain.MaxDescriptionLength {
+		verr.Add("description", domain.ErrProductFieldTooLong)
+	}
+	if req.MediaURLs != nil {
+		if err := domain.ValidateMediaURLs(*req.MediaURLs); err != nil {
+			verr.Add("media_urls", err)
+		}
+	}
+	if req.BasePrice != nil {
+		if err := req.BasePrice.validate(); err != nil {
+			verr.Add("base_price", err)
+		}
+	}
+	if req.Stock != nil && *req.Stock < 0 {
+		verr.Add("stock_quantity", domain.ErrNegativeStock)
+	}
+	for _, category := range req.AddCategories {
+		switch n := utf8.RuneCountInString(category); {
+		case n == 0:
+			verr.Add("add_categories", domain.ErrProductCategoryRequired)
+		case n > domain.MaxCategoryLength:
+			verr.Add("add_categories", domain.ErrProductFieldTooLong)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(req.SetAttributes)) {
+		if err := domain.ValidateAttributeKey(key); err != nil {
+			verr.Add("set_attributes", err)
+		} else if err := domain.ValidateAttributeValue(req.SetAttributes[key]); err != nil {
+			verr.Add("set_attributes", err)
+		}
+	}
+	if p, u := req.PublishAt, req.UnpublishAt; p != nil && u != nil && !p.Clear && !u.Clear && !u.At.After(p.At) {
+		verr.Add("unpublish_at", domain.ErrUnpublishBeforePublish)
+	}
+	if req.QuantityTiers != nil {
+		if tiers, err := toQuantityTiers(*req.QuantityTiers); err != nil {
+			verr.Add("quantity_tiers", err)
+		} else if err := domain.ValidateQuantityTiers(tiers); err != nil {
+			verr.Add("quantity_tiers", err)
+		}
+	}
+	return verr.OrNil()
 }
@@ -0,0 +1,89 @@
+// Package eventbus delivers committed domain events to in-process
+// subscribers, e.g. to invalidate a local cache, without the outbox relay.
+// Delivery is best effort; the outbox remains the durable channel.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// Handler reacts to one committed event. It runs on the request's goroutine,
+// so it should be quick.
+type Handler func(ctx context.Context, event domain.DomainEvent)
+
+// Bus fans committed events out to handlers by event name. Without
+// subscribers publishing is a no-op.
+type Bus struct {
+	log  *zap.Logger
+	mu   sync.RWMutex
+	subs map[string][]Handler
+}
+
+func New(log *zap.Logger) *Bus {
+	return &Bus{log: log, subs: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for events named eventType, such as
+// "product.created". Handlers of an event run in subscription order.
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[eventType] = append(b.subs[eventType], handler)
+}
+
+// Publish delivers events synchronously and in order. A panicking handler is
+// recovered and logged, and delivery continues with the next one.
+func (b *Bus) Publish(ctx context.Context, events []domain.DomainEvent) {
+	for _, event := range events {
+		b.mu.RLock()
+		handlers := b.subs[event.EventName()]
+		b.mu.RUnlock()
+		for _, h := range handlers {
+			b.deliver(ctx, h, event)
+		}
+	}
+}
+
+func (b *Bus) deliver(ctx context.Context, h Handler, event domain.DomainEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.Error("event handler panicked",
+				zap.String("event", event.EventName()),
+				zap.Any("panic", r),
+				zap.Stack("stack"),
+			)
+		}
+	}()
+	h(ctx, event)
+}
+
+// Applier decorates an Applier to publish each plan's events on the bus once
+// it has committed. A failed commit publishes nothing.
+type Applier struct {
+	next commitplanner.Applier
+	bus  *Bus
+}
+
+func NewApplier(next commitplanner.Applier, bus *Bus) *Applier {
+	return &Applier{next: next, bus: bus}
+}
+
+func (a *Applier) Apply(ctx context.Context, p *commitplanner.Plan) error {
+	if err := a.next.Apply(ctx, p); err != nil {
+		return err
+	}
+	events := make([]domain.DomainEvent, 0, len(p.Events()))
+	for _, e := range p.Events() {
+		if event, ok := e.(domain.DomainEvent); ok {
+			events = append(events, event)
+		}
+	}
+	a.bus.Publish(ctx, events)
+	return nil
+}
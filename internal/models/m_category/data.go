@@ -0,0 +1,20 @@
+package m_category
+
+import (
+	"cloud.google.com/go/spanner"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// CategoryRow is the Spanner row representation of a taxonomy node.
+// It mirrors the categories table schema 1-to-1.
+type CategoryRow struct {
+	CategoryID string             `spanner:"category_id"`
+	Name       string             `spanner:"name"`
+	ParentID   spanner.NullString `spanner:"parent_id"` // null for root categories
+}
+
+// ToDomain converts a CategoryRow (from Spanner) to a domain.Category.
+func (r *CategoryRow) ToDomain() (*domain.Category, error) {
+	return domain.NewCategory(r.CategoryID, r.Name, r.ParentID.StringVal)
+}
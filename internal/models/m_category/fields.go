@@ -0,0 +1,8 @@
+package m_category
+
+const Table = "categories"
+const (
+	CategoryID string = "category_id"
+	Name       string = "name"
+	ParentID   string = "parent_id"
+)
@@ -5,10 +5,16 @@ const (
 	EventID     string = "event_id"
 	EventType   string = "event_type"
 	AggregateID string = "aggregate_id"
+	Sequence    string = "sequence"
 	Payload     string = "payload"
 	Status      string = "status"
+	Attempts    string = "attempts"
 	CreatedAt   string = "created_at"
 	ProcessedAt string = "processed_at"
 )
 
-const StatusPending = "pending"
+const (
+	StatusPending   = "pending"
+	StatusProcessed = "processed"
+	StatusDead      = "dead"
+)
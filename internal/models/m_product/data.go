@@ -2,6 +2,7 @@ package m_product
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -16,10 +17,13 @@ const Table = "products"
 type ProductRow struct {
 	ProductID            string              `spanner:"product_id"`
 	Name                 string              `spanner:"name"`
+	Slug                 spanner.NullString  `spanner:"slug"` // NULL for products created before slugs
 	Description          string              `spanner:"description"`
 	Category             string              `spanner:"category"`
+	AdditionalCategories []string            `spanner:"additional_categories"` // NULL → nil
 	BasePriceNumerator   int64               `spanner:"base_price_numerator"`
 	BasePriceDenominator int64               `spanner:"base_price_denominator"`
+	BasePriceCurrency    string              `spanner:"base_price_currency"`
 	DiscountPercent      spanner.NullNumeric `spanner:"discount_percent"` // nullable → zero value when absent
 	DiscountStartDate    spanner.NullTime    `spanner:"discount_start_date"`
 	DiscountEndDate      spanner.NullTime    `spanner:"discount_end_date"`
@@ -27,37 +31,142 @@ type ProductRow struct {
 	CreatedAt            time.Time           `spanner:"created_at"`
 	UpdatedAt            time.Time           `spanner:"updated_at"`
 	ArchivedAt           spanner.NullTime    `spanner:"archived_at"`
+	Featured             bool                `spanner:"featured"`
+	MediaURLs            []string            `spanner:"media_urls"` // NULL → nil
+	StockQuantity        int64               `spanner:"stock_quantity"`
+	Attributes           spanner.NullJSON    `spanner:"attributes"` // JSON object of strings; NULL → nil
+	PublishAt            spanner.NullTime    `spanner:"publish_at"`
+	UnpublishAt          spanner.NullTime    `spanner:"unpublish_at"`
+
+	// Quantity tiers as parallel arrays; absent from summary reads.
+	QuantityTierMinQuantities []int64               `spanner:"quantity_tier_min_quantities"`
+	QuantityTierPercents      []spanner.NullNumeric `spanner:"quantity_tier_percents"`
+
+	// Discount dropped by the last deactivation; absent from summary reads.
+	PreviousDiscountPercent   spanner.NullNumeric `spanner:"previous_discount_percent"`
+	PreviousDiscountStartDate spanner.NullTime    `spanner:"previous_discount_start_date"`
+	PreviousDiscountEndDate   spanner.NullTime    `spanner:"previous_discount_end_date"`
 }
 
 // ToDomain converts a ProductRow (from Spanner) to a domain.Product aggregate.
 func (r *ProductRow) ToDomain() (*domain.Product, error) {
-	basePrice, err := domain.NewMoney(r.BasePriceNumerator, "VND") // currency stored implicitly
+	basePrice, err := domain.NewMoney(r.BasePriceNumerator, r.BasePriceCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	discount, err := toDiscount(r.DiscountPercent, r.DiscountStartDate, r.DiscountEndDate)
+	if err != nil {
+		return nil, err
+	}
+	previousDiscount, err := toDiscount(r.PreviousDiscountPercent, r.PreviousDiscountStartDate, r.PreviousDiscountEndDate)
 	if err != nil {
 		return nil, err
 	}
 
-	var discount *domain.Discount
-	if r.DiscountPercent.Valid && r.DiscountStartDate.Valid && r.DiscountEndDate.Valid {
-		f, _ := r.DiscountPercent.Numeric.Float64()
-		pct := formatDecimal(f)
-		discount, err = domain.NewDiscount(pct, r.DiscountStartDate.Time, r.DiscountEndDate.Time)
+	attributes, err := AttributesFromJSON(r.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	quantityTiers, err := toQuantityTiers(r.QuantityTierMinQuantities, r.QuantityTierPercents)
+	if err != nil {
+		return nil, err
+	}
+
+	var archivedAt *time.Time
+	if r.ArchivedAt.Valid {
+		archivedAt = &r.ArchivedAt.Time
+	}
+	var publishAt *time.Time
+	if r.PublishAt.Valid {
+		publishAt = &r.PublishAt.Time
+	}
+	var unpublishAt *time.Time
+	if r.UnpublishAt.Valid {
+		unpublishAt = &r.UnpublishAt.Time
+	}
+
+	return domain.Reconstitute(domain.ProductState{
+		ID:                   r.ProductID,
+		Name:                 r.Name,
+		Description:          r.Description,
+		Category:             r.Category,
+		BasePrice:            basePrice,
+		Discount:             discount,
+		PreviousDiscount:     previousDiscount,
+		Status:               domain.ProductStatus(r.Status),
+		ArchivedAt:           archivedAt,
+		Featured:             r.Featured,
+		MediaURLs:            r.MediaURLs,
+		Slug:                 r.Slug.StringVal,
+		StockQuantity:        r.StockQuantity,
+		QuantityTiers:        quantityTiers,
+		AdditionalCategories: r.AdditionalCategories,
+		Attributes:           attributes,
+		PublishAt:            publishAt,
+		UnpublishAt:          unpublishAt,
+	})
+}
+
+// AttributesFromJSON decodes an attributes column, which holds a JSON object
+// of string values; NULL and {} decode to nil.
+func AttributesFromJSON(j spanner.NullJSON) (map[string]string, error) {
+	if !j.Valid || j.Value == nil {
+		return nil, nil
+	}
+	obj, ok := j.Value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("attributes: expected a JSON object, got %T", j.Value)
+	}
+	if len(obj) == 0 {
+		return nil, nil
+	}
+	attributes := make(map[string]string, len(obj))
+	for k, v := range obj {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("attributes: %q holds %T, not a string", k, v)
+		}
+		attributes[k] = s
+	}
+	return attributes, nil
+}
+
+// AttributesJSON encodes attributes for an attributes column; none is NULL.
+func AttributesJSON(attributes map[string]string) spanner.NullJSON {
+	return spanner.NullJSON{Value: attributes, Valid: len(attributes) > 0}
+}
+
+// toQuantityTiers zips the parallel tier columns; a length mismatch means the
+// row is corrupt.
+func toQuantityTiers(minQuantities []int64, percents []spanner.NullNumeric) ([]domain.QuantityTier, error) {
+	if len(minQuantities) != len(percents) {
+		return nil, fmt.Errorf("quantity tiers: %d minimum quantities but %d percentages", len(minQuantities), len(percents))
+	}
+	tiers := make([]domain.QuantityTier, 0, len(minQuantities))
+	for i, minQuantity := range minQuantities {
+		f, _ := percents[i].Numeric.Float64()
+		tier, err := domain.NewQuantityTier(minQuantity, formatDecimal(f))
 		if err != nil {
 			return nil, err
 		}
+		tiers = append(tiers, tier)
 	}
+	return tiers, nil
+}
 
-	return domain.Reconstitute(
-		r.ProductID,
-		r.Name,
-		r.Description,
-		r.Category,
-		basePrice,
-		discount,
-		domain.ProductStatus(r.Status),
-	)
+// toDiscount rebuilds a discount from its nullable columns; nil when any is NULL.
+func toDiscount(pct spanner.NullNumeric, startsAt, endsAt spanner.NullTime) (*domain.Discount, error) {
+	if !pct.Valid || !startsAt.Valid || !endsAt.Valid {
+		return nil, nil
+	}
+	f, _ := pct.Numeric.Float64()
+	return domain.NewDiscount(formatDecimal(f), startsAt.Time, endsAt.Time)
 }
 
-// formatDecimal converts a float64 percentage to its string representation.
+// formatDecimal converts a float64 percentage to a plain decimal string;
+// domain.NewDiscount then brings it into canonical form.
 func formatDecimal(f float64) string {
-	return fmt.Sprintf("%g", f)
+	return strconv.FormatFloat(f, 'f', -1, 64)
 }
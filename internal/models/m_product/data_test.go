@@ -0,0 +1,166 @@
+package m_product
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+func TestToDomain_PercentageRoundTripsInCanonicalForm(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	for in, want := range map[string]string{
+		"10.00": "10.0",
+		"10.50": "10.5",
+		"33.33": "33.33",
+	} {
+		written, err := domain.NewDiscount(in, start, end)
+		if err != nil {
+			t.Fatalf("%s: new discount: %v", in, err)
+		}
+		if written.Percentage() != want {
+			t.Fatalf("%s: expected canonical %q on write, got %q", in, want, written.Percentage())
+		}
+
+		// Mirror the repository write path: the percentage is stored as NUMERIC.
+		var rat big.Rat
+		rat.SetFloat64(written.PercentageFloat64())
+		row := ProductRow{
+			ProductID:          "p-1",
+			Name:               "Laptop",
+			Category:           "electronics",
+			BasePriceNumerator: 1000,
+			BasePriceCurrency:  "USD",
+			DiscountPercent:    spanner.NullNumeric{Numeric: rat, Valid: true},
+			DiscountStartDate:  spanner.NullTime{Time: start, Valid: true},
+			DiscountEndDate:    spanner.NullTime{Time: end, Valid: true},
+			Status:             string(domain.ProductStatusActive),
+		}
+
+		p, err := row.ToDomain()
+		if err != nil {
+			t.Fatalf("%s: to domain: %v", in, err)
+		}
+		if got := p.Discount().Percentage(); got != want {
+			t.Fatalf("%s: expected %q on read, got %q", in, want, got)
+		}
+	}
+}
+
+func TestToDomain_PreviousDiscount(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	row := ProductRow{
+		ProductID:                 "p-1",
+		Name:                      "Laptop",
+		Category:                  "electronics",
+		BasePriceNumerator:        1000,
+		BasePriceCurrency:         "USD",
+		PreviousDiscountPercent:   spanner.NullNumeric{Numeric: *big.NewRat(25, 1), Valid: true},
+		PreviousDiscountStartDate: spanner.NullTime{Time: start, Valid: true},
+		PreviousDiscountEndDate:   spanner.NullTime{Time: end, Valid: true},
+		Status:                    string(domain.ProductStatusInactive),
+	}
+
+	p, err := row.ToDomain()
+	if err != nil {
+		t.Fatalf("to domain: %v", err)
+	}
+	if p.Discount() != nil {
+		t.Fatalf("expected no current discount, got %+v", p.Discount())
+	}
+	prev := p.PreviousDiscount()
+	if prev == nil || prev.Percentage() != "25.0" || !prev.EndsAt().Equal(end) {
+		t.Fatalf("unexpected previous discount %+v", prev)
+	}
+}
+
+func TestToDomain_QuantityTiers(t *testing.T) {
+	row := ProductRow{
+		ProductID:                 "p-1",
+		Name:                      "Laptop",
+		Category:                  "electronics",
+		BasePriceNumerator:        1000,
+		BasePriceCurrency:         "USD",
+		Status:                    string(domain.ProductStatusActive),
+		QuantityTierMinQuantities: []int64{50, 10},
+		QuantityTierPercents: []spanner.NullNumeric{
+			{Numeric: *big.NewRat(10, 1), Valid: true},
+			{Numeric: *big.NewRat(5, 1), Valid: true},
+		},
+	}
+
+	p, err := row.ToDomain()
+	if err != nil {
+		t.Fatalf("to domain: %v", err)
+	}
+	tiers := p.QuantityTiers()
+	if len(tiers) != 2 || tiers[0].MinQuantity() != 10 || tiers[0].Percentage() != "5.0" ||
+		tiers[1].MinQuantity() != 50 || tiers[1].Percentage() != "10.0" {
+		t.Fatalf("expected tiers ordered by minimum quantity, got %+v", tiers)
+	}
+
+	row.QuantityTierPercents = row.QuantityTierPercents[:1]
+	if _, err := row.ToDomain(); err == nil {
+		t.Fatal("expected mismatched tier arrays to be rejected")
+	}
+}
+
+func TestAttributesJSON_RoundTrip(t *testing.T) {
+	if col := AttributesJSON(nil); col.Valid {
+		t.Fatalf("expected no attributes to be stored as NULL, got %+v", col)
+	}
+
+	// Spanner decodes a JSON column into generic values.
+	row := ProductRow{
+		ProductID:          "p-1",
+		Name:               "T-shirt",
+		Category:           "apparel",
+		BasePriceNumerator: 1000,
+		BasePriceCurrency:  "USD",
+		Status:             string(domain.ProductStatusActive),
+		Attributes:         spanner.NullJSON{Value: map[string]any{"color": "red", "size": "m"}, Valid: true},
+	}
+	p, err := row.ToDomain()
+	if err != nil {
+		t.Fatalf("to domain: %v", err)
+	}
+	if v, _ := p.Attribute("color"); v != "red" || len(p.Attributes()) != 2 {
+		t.Fatalf("unexpected attributes %v", p.Attributes())
+	}
+
+	row.Attributes = spanner.NullJSON{Value: map[string]any{"size": 42.0}, Valid: true}
+	if _, err := row.ToDomain(); err == nil {
+		t.Fatal("expected a non-string attribute value to be rejected")
+	}
+}
+
+func TestToDomain_BasePriceCurrencyRoundTrips(t *testing.T) {
+	for _, currency := range []string{"USD", "VND", "EUR"} {
+		// Decode the columns as Spanner returns them for a written product.
+		cols := []string{ProductID, Name, Category, BasePriceNumerator, BasePriceDenominator, BasePriceCurrency, Status}
+		vals := []any{"p-1", "Laptop", "electronics", int64(1999), int64(1), currency, string(domain.ProductStatusActive)}
+		r, err := spanner.NewRow(cols, vals)
+		if err != nil {
+			t.Fatalf("%s: new row: %v", currency, err)
+		}
+		var row ProductRow
+		if err := r.ToStruct(&row); err != nil {
+			t.Fatalf("%s: to struct: %v", currency, err)
+		}
+
+		p, err := row.ToDomain()
+		if err != nil {
+			t.Fatalf("%s: to domain: %v", currency, err)
+		}
+		if got := p.BasePrice(); got.Amount() != 1999 || got.Currency() != currency {
+			t.Fatalf("%s: expected 1999 %s, got %d %s", currency, currency, got.Amount(), got.Currency())
+		}
+	}
+}
@@ -4,10 +4,13 @@ package m_product
 const (
 	ProductID            string = "product_id"
 	Name                 string = "name"
+	Slug                 string = "slug"
 	Description          string = "description"
 	Category             string = "category"
+	AdditionalCategories string = "additional_categories"
 	BasePriceNumerator   string = "base_price_numerator"
 	BasePriceDenominator string = "base_price_denominator"
+	BasePriceCurrency    string = "base_price_currency"
 	DiscountPercent      string = "discount_percent"
 	DiscountStartDate    string = "discount_start_date"
 	DiscountEndDate      string = "discount_end_date"
@@ -15,4 +18,17 @@ const (
 	CreatedAt            string = "created_at"
 	UpdatedAt            string = "updated_at"
 	ArchivedAt           string = "archived_at"
+	Featured             string = "featured"
+	MediaURLs            string = "media_urls"
+	StockQuantity        string = "stock_quantity"
+	Attributes           string = "attributes"
+	PublishAt            string = "publish_at"
+	UnpublishAt          string = "unpublish_at"
+
+	QuantityTierMinQuantities string = "quantity_tier_min_quantities"
+	QuantityTierPercents      string = "quantity_tier_percents"
+
+	PreviousDiscountPercent   string = "previous_discount_percent"
+	PreviousDiscountStartDate string = "previous_discount_start_date"
+	PreviousDiscountEndDate   string = "previous_discount_end_date"
 )
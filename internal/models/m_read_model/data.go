@@ -0,0 +1,28 @@
+package m_read_model
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// ReadModelRow is the Spanner row representation of a projected listing.
+// It mirrors the product_read_model table schema 1-to-1.
+type ReadModelRow struct {
+	ProductID            string           `spanner:"product_id"`
+	Name                 string           `spanner:"name"`
+	Category             string           `spanner:"category"`
+	AdditionalCategories []string         `spanner:"additional_categories"`
+	Status               string           `spanner:"status"`
+	Featured             bool             `spanner:"featured"`
+	StockQuantity        int64            `spanner:"stock_quantity"`
+	Attributes           spanner.NullJSON `spanner:"attributes"`
+	CreatedAt            spanner.NullTime `spanner:"created_at"` // NULL until the product.created event is projected
+	BasePriceAmount      int64            `spanner:"base_price_amount"`
+	Currency             string           `spanner:"currency"`
+	EffectivePriceAmount int64            `spanner:"effective_price_amount"`
+	IsDiscounted         bool             `spanner:"is_discounted"`
+	DiscountEndsAt       spanner.NullTime `spanner:"discount_ends_at"`
+	NextPriceChangeAt    spanner.NullTime `spanner:"next_price_change_at"`
+	ProjectedAt          time.Time        `spanner:"projected_at"`
+}
@@ -0,0 +1,24 @@
+package m_read_model
+
+const Table = "product_read_model"
+
+// Column names of the product_read_model table. Columns shared with products
+// keep the same names so list filters can target either table.
+const (
+	ProductID            string = "product_id"
+	Name                 string = "name"
+	Category             string = "category"
+	AdditionalCategories string = "additional_categories"
+	Status               string = "status"
+	Featured             string = "featured"
+	StockQuantity        string = "stock_quantity"
+	Attributes           string = "attributes"
+	CreatedAt            string = "created_at"
+	BasePriceAmount      string = "base_price_amount"
+	Currency             string = "currency"
+	EffectivePriceAmount string = "effective_price_amount"
+	IsDiscounted         string = "is_discounted"
+	DiscountEndsAt       string = "discount_ends_at"
+	NextPriceChangeAt    string = "next_price_change_at"
+	ProjectedAt          string = "projected_at"
+)
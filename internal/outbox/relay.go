@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// Publisher delivers a single outbox event to the message broker.
+type Publisher interface {
+	Publish(ctx context.Context, event m_outbox.OutboxEventRow) error
+}
+
+// RelayStore is the read/write contract the Relay uses to drain the outbox.
+type RelayStore interface {
+	// FetchPending returns up to limit pending rows, oldest first.
+	FetchPending(ctx context.Context, limit int) ([]m_outbox.OutboxEventRow, error)
+	// MarkProcessed flags a row as delivered.
+	MarkProcessed(ctx context.Context, eventID string) error
+	// RecordFailure stores the new attempt count and status for a row that failed to publish.
+	RecordFailure(ctx context.Context, eventID string, attempts int64, status string) error
+}
+
+// RelayConfig controls batching and dead-lettering.
+type RelayConfig struct {
+	BatchSize   int   // rows fetched per RunOnce
+	MaxAttempts int64 // failed publishes before an event is dead-lettered
+}
+
+// DefaultRelayConfig returns the built-in relay settings.
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{BatchSize: 100, MaxAttempts: 5}
+}
+
+// Relay publishes pending outbox events. Events that keep failing are moved to
+// the dead status after MaxAttempts so a single poison event cannot block the queue.
+type Relay struct {
+	store     RelayStore
+	publisher Publisher
+	cfg       RelayConfig
+	log       *zap.Logger
+}
+
+func NewRelay(store RelayStore, publisher Publisher, cfg RelayConfig, log *zap.Logger) *Relay {
+	return &Relay{store: store, publisher: publisher, cfg: cfg, log: log}
+}
+
+// RunOnce drains one batch of pending events and returns how many were published.
+// Publish failures are recorded per event and do not abort the batch.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	rows, err := r.store.FetchPending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, row := range rows {
+		if err := r.publisher.Publish(ctx, row); err != nil {
+			if err := r.recordFailure(ctx, row, err); err != nil {
+				return published, err
+			}
+			continue
+		}
+		if err := r.store.MarkProcessed(ctx, row.EventID); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+func (r *Relay) recordFailure(ctx context.Context, row m_outbox.OutboxEventRow, cause error) error {
+	attempts := row.Attempts + 1
+	status := m_outbox.StatusPending
+	if attempts >= r.cfg.MaxAttempts {
+		status = m_outbox.StatusDead
+		r.log.Warn("outbox event dead-lettered",
+			zap.String("event_id", row.EventID),
+			zap.String("event_type", row.EventType),
+			zap.Int64("attempts", attempts),
+			zap.Error(cause),
+		)
+	}
+	return r.store.RecordFailure(ctx, row.EventID, attempts, status)
+}
@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// ReplayFilter narrows which events are replayed. Zero values match everything.
+type ReplayFilter struct {
+	EventTypes []string   // empty = all event types
+	Since      *time.Time // only events created at or after this time; nil = from the beginning
+}
+
+// ReplayStore streams outbox rows regardless of their delivery status.
+type ReplayStore interface {
+	// ScanEvents calls fn for every matching row in created_at, event_id order,
+	// stopping at the first error returned by fn.
+	ScanEvents(ctx context.Context, filter ReplayFilter, fn func(m_outbox.OutboxEventRow) error) error
+}
+
+// Replayer re-publishes historical outbox events to bootstrap a new consumer.
+// Unlike Relay it is read-only: row status, attempts and processed_at are left untouched.
+type Replayer struct {
+	store ReplayStore
+}
+
+func NewReplayer(store ReplayStore) *Replayer {
+	return &Replayer{store: store}
+}
+
+// Replay publishes every event matching filter to publisher in outbox order and
+// returns how many were sent. It stops at the first publish error.
+func (r *Replayer) Replay(ctx context.Context, filter ReplayFilter, publisher Publisher) (int, error) {
+	sent := 0
+	err := r.store.ScanEvents(ctx, filter, func(row m_outbox.OutboxEventRow) error {
+		if err := publisher.Publish(ctx, row); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	})
+	return sent, err
+}
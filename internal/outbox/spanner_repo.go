@@ -0,0 +1,159 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// SpannerRepo implements Repository against the outbox_events table.
+type SpannerRepo struct {
+	db *spanner.Client
+}
+
+func NewSpannerRepo(db *spanner.Client) *SpannerRepo {
+	return &SpannerRepo{db: db}
+}
+
+// CountByStatus groups outbox rows by status.
+func (r *SpannerRepo) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + m_outbox.Status + `, COUNT(*) FROM ` + m_outbox.Table + `
+		      GROUP BY ` + m_outbox.Status,
+	}
+
+	counts := make(map[string]int64)
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var status string
+		var n int64
+		if err := row.Columns(&status, &n); err != nil {
+			return fmt.Errorf("CountByStatus decode: %w", err)
+		}
+		counts[status] = n
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CountByStatus: %w", err)
+	}
+	return counts, nil
+}
+
+// OldestPendingAt returns the creation time of the oldest pending event.
+func (r *SpannerRepo) OldestPendingAt(ctx context.Context) (*time.Time, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT MIN(` + m_outbox.CreatedAt + `) FROM ` + m_outbox.Table + `
+		      WHERE ` + m_outbox.Status + ` = @status`,
+		Params: map[string]any{"status": m_outbox.StatusPending},
+	}
+
+	var oldest spanner.NullTime
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		return row.Columns(&oldest)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OldestPendingAt: %w", err)
+	}
+	if !oldest.Valid {
+		return nil, nil
+	}
+	return &oldest.Time, nil
+}
+
+// FetchPending returns up to limit pending events ordered by creation time.
+func (r *SpannerRepo) FetchPending(ctx context.Context, limit int) ([]m_outbox.OutboxEventRow, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + allColumns + ` FROM ` + m_outbox.Table + `
+		      WHERE ` + m_outbox.Status + ` = @status
+		      ORDER BY ` + m_outbox.CreatedAt + `, ` + m_outbox.Sequence + `, ` + m_outbox.EventID + `
+		      LIMIT @limit`,
+		Params: map[string]any{"status": m_outbox.StatusPending, "limit": int64(limit)},
+	}
+
+	var rows []m_outbox.OutboxEventRow
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var er m_outbox.OutboxEventRow
+		if err := row.ToStruct(&er); err != nil {
+			return fmt.Errorf("FetchPending decode: %w", err)
+		}
+		rows = append(rows, er)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("FetchPending: %w", err)
+	}
+	return rows, nil
+}
+
+// MarkProcessed sets the event status to processed and stamps processed_at.
+func (r *SpannerRepo) MarkProcessed(ctx context.Context, eventID string) error {
+	_, err := r.db.Apply(ctx, []*spanner.Mutation{
+		spanner.UpdateMap(m_outbox.Table, map[string]any{
+			m_outbox.EventID:     eventID,
+			m_outbox.Status:      m_outbox.StatusProcessed,
+			m_outbox.ProcessedAt: spanner.CommitTimestamp,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("MarkProcessed: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure persists the attempt count and resulting status of a failed publish.
+func (r *SpannerRepo) RecordFailure(ctx context.Context, eventID string, attempts int64, status string) error {
+	_, err := r.db.Apply(ctx, []*spanner.Mutation{
+		spanner.UpdateMap(m_outbox.Table, map[string]any{
+			m_outbox.EventID:  eventID,
+			m_outbox.Attempts: attempts,
+			m_outbox.Status:   status,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("RecordFailure: %w", err)
+	}
+	return nil
+}
+
+// allColumns is the full column list for SELECT queries.
+const allColumns = `` +
+	m_outbox.EventID + `, ` +
+	m_outbox.EventType + `, ` +
+	m_outbox.AggregateID + `, ` +
+	m_outbox.Sequence + `, ` +
+	m_outbox.Payload + `, ` +
+	m_outbox.Status + `, ` +
+	m_outbox.Attempts + `, ` +
+	m_outbox.CreatedAt + `, ` +
+	m_outbox.ProcessedAt
+
+// ScanEvents streams matching events in created_at, sequence, event_id order.
+func (r *SpannerRepo) ScanEvents(ctx context.Context, filter ReplayFilter, fn func(m_outbox.OutboxEventRow) error) error {
+	stmt := spanner.Statement{
+		SQL:    `SELECT ` + allColumns + ` FROM ` + m_outbox.Table + ` WHERE TRUE`,
+		Params: map[string]any{},
+	}
+	if len(filter.EventTypes) > 0 {
+		stmt.SQL += " AND " + m_outbox.EventType + " IN UNNEST(@event_types)"
+		stmt.Params["event_types"] = filter.EventTypes
+	}
+	if filter.Since != nil {
+		stmt.SQL += " AND " + m_outbox.CreatedAt + " >= @since"
+		stmt.Params["since"] = *filter.Since
+	}
+	stmt.SQL += " ORDER BY " + m_outbox.CreatedAt + ", " + m_outbox.Sequence + ", " + m_outbox.EventID
+
+	err := r.db.Single().Query(ctx, stmt).Do(func(row *spanner.Row) error {
+		var er m_outbox.OutboxEventRow
+		if err := row.ToStruct(&er); err != nil {
+			return fmt.Errorf("ScanEvents decode: %w", err)
+		}
+		return fn(er)
+	})
+	if err != nil {
+		return fmt.Errorf("ScanEvents: %w", err)
+	}
+	return nil
+}
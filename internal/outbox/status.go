@@ -0,0 +1,66 @@
+// Package outbox provides fleet-level operations over the outbox_events table,
+// independent of any single aggregate.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// Repository is the read-only contract used by outbox queries.
+type Repository interface {
+	// CountByStatus returns the number of outbox rows per status value.
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	// OldestPendingAt returns the created_at of the oldest pending row, or nil when none are pending.
+	OldestPendingAt(ctx context.Context) (*time.Time, error)
+}
+
+// StatusDTO is the read model returned by StatusQuery.
+type StatusDTO struct {
+	Pending          int64
+	Processed        int64
+	Dead             int64
+	OldestPendingAge time.Duration // zero when nothing is pending
+}
+
+// Backlogged reports whether the oldest pending event is older than maxAge.
+// maxAge <= 0 disables the check.
+func (d *StatusDTO) Backlogged(maxAge time.Duration) bool {
+	return maxAge > 0 && d.Pending > 0 && d.OldestPendingAge > maxAge
+}
+
+// StatusQuery reports outbox delivery health for operations debugging.
+type StatusQuery struct {
+	repo   Repository
+	ticker common.Ticker
+}
+
+func NewStatusQuery(repo Repository, ticker common.Ticker) *StatusQuery {
+	return &StatusQuery{repo: repo, ticker: ticker}
+}
+
+func (q *StatusQuery) Execute(ctx context.Context) (*StatusDTO, error) {
+	counts, err := q.repo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oldest, err := q.repo.OldestPendingAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &StatusDTO{
+		Pending:   counts[m_outbox.StatusPending],
+		Processed: counts[m_outbox.StatusProcessed],
+		Dead:      counts[m_outbox.StatusDead],
+	}
+	if oldest != nil {
+		dto.OldestPendingAge = q.ticker.Now().Sub(*oldest)
+	}
+
+	return dto, nil
+}
@@ -0,0 +1,91 @@
+// Package scheduler runs the product publish/unpublish schedule in the
+// background.
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job handles up to limit due items in one commit and returns how many it
+// handled. The publish and unpublish interactors satisfy it.
+type Job interface {
+	Execute(ctx context.Context, limit int) (int, error)
+}
+
+// Config controls how often the worker runs and how much each commit takes on.
+type Config struct {
+	Interval  time.Duration // pause between runs; 0 disables the worker
+	BatchSize int           // limit passed to each Execute
+}
+
+// DefaultConfig returns the built-in scheduler settings.
+func DefaultConfig() Config {
+	return Config{Interval: time.Minute, BatchSize: 100}
+}
+
+// Worker drains its jobs every Interval, in the order they were given.
+type Worker struct {
+	cfg  Config
+	log  *zap.Logger
+	jobs []namedJob
+}
+
+type namedJob struct {
+	name string
+	job  Job
+}
+
+func NewWorker(cfg Config, log *zap.Logger) *Worker {
+	return &Worker{cfg: cfg, log: log}
+}
+
+// Add registers job under name, which labels its log entries.
+func (w *Worker) Add(name string, job Job) *Worker {
+	w.jobs = append(w.jobs, namedJob{name: name, job: job})
+	return w
+}
+
+// RunOnce drains every job: Execute is repeated while it returns a full batch.
+// A failing job is logged and does not stop the jobs after it; the first error
+// is returned.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	var firstErr error
+	for _, j := range w.jobs {
+		total := 0
+		for {
+			n, err := j.job.Execute(ctx, w.cfg.BatchSize)
+			total += n
+			if err != nil {
+				w.log.Error("scheduled job failed", zap.String("job", j.name), zap.Int("handled", total), zap.Error(err))
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+			if n < w.cfg.BatchSize {
+				break
+			}
+		}
+		if total > 0 {
+			w.log.Info("scheduled job ran", zap.String("job", j.name), zap.Int("handled", total))
+		}
+	}
+	return firstErr
+}
+
+// Run calls RunOnce immediately and then every Interval until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	t := time.NewTicker(w.cfg.Interval)
+	defer t.Stop()
+	for {
+		_ = w.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
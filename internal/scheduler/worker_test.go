@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// batchJob hands out pending items limit at a time and records each limit.
+type batchJob struct {
+	pending int
+	err     error
+	limits  []int
+}
+
+func (j *batchJob) Execute(_ context.Context, limit int) (int, error) {
+	j.limits = append(j.limits, limit)
+	if j.err != nil {
+		return 0, j.err
+	}
+	n := min(limit, j.pending)
+	j.pending -= n
+	return n, nil
+}
+
+func TestWorker_RunOnceRepeatsUntilShortBatch(t *testing.T) {
+	job := &batchJob{pending: 5}
+	w := NewWorker(Config{Interval: time.Minute, BatchSize: 2}, zap.NewNop()).Add("publish", job)
+
+	if err := w.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if job.pending != 0 {
+		t.Fatalf("expected every item handled, %d left", job.pending)
+	}
+	if want := []int{2, 2, 2}; !slices.Equal(job.limits, want) {
+		t.Fatalf("expected Execute limits %v, got %v", want, job.limits)
+	}
+}
+
+func TestWorker_RunOnceStopsAtExactFullBatchWhenNextIsEmpty(t *testing.T) {
+	job := &batchJob{pending: 4}
+	w := NewWorker(Config{Interval: time.Minute, BatchSize: 2}, zap.NewNop()).Add("publish", job)
+
+	if err := w.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// Two full batches, then an empty one ends the run.
+	if len(job.limits) != 3 {
+		t.Fatalf("expected 3 Execute calls, got %d", len(job.limits))
+	}
+}
+
+func TestWorker_RunOnceFailingJobDoesNotStopOthers(t *testing.T) {
+	boom := errors.New("spanner unavailable")
+	failing := &batchJob{err: boom}
+	next := &batchJob{pending: 1}
+	w := NewWorker(Config{Interval: time.Minute, BatchSize: 2}, zap.NewNop()).
+		Add("publish", failing).
+		Add("unpublish", next)
+
+	if err := w.RunOnce(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected the job's error, got %v", err)
+	}
+	if len(failing.limits) != 1 {
+		t.Fatalf("expected the failing job tried once, got %d", len(failing.limits))
+	}
+	if next.pending != 0 {
+		t.Fatal("expected the next job to run after a failure")
+	}
+}
+
+func TestWorker_RunStopsWhenContextIsDone(t *testing.T) {
+	job := &batchJob{pending: 1}
+	w := NewWorker(Config{Interval: time.Hour, BatchSize: 2}, zap.NewNop()).Add("publish", job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -5,27 +5,54 @@ package appservices
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/spanner"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/buildinfo"
 	"github.com/product-catalog-service/common/commitplanner"
 	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
 	"github.com/product-catalog-service/internal/app/product/domain/services"
+	"github.com/product-catalog-service/internal/app/product/projection"
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
+	discountpreview "github.com/product-catalog-service/internal/app/product/queries/discount_preview"
+	effectivepricebackfill "github.com/product-catalog-service/internal/app/product/queries/effective_price_backfill"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
+	pricepreview "github.com/product-catalog-service/internal/app/product/queries/price_preview"
+	pricestats "github.com/product-catalog-service/internal/app/product/queries/price_stats"
 	"github.com/product-catalog-service/internal/app/product/repo"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
 	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
+	forcecleardiscount "github.com/product-catalog-service/internal/app/product/usecases/force_clear_discount"
+	publishscheduled "github.com/product-catalog-service/internal/app/product/usecases/publish_scheduled"
 	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
+	renamecategory "github.com/product-catalog-service/internal/app/product/usecases/rename_category"
+	unpublishscheduled "github.com/product-catalog-service/internal/app/product/usecases/unpublish_scheduled"
 	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+	"github.com/product-catalog-service/internal/eventbus"
+	"github.com/product-catalog-service/internal/outbox"
+	"github.com/product-catalog-service/internal/scheduler"
 	grpctransport "github.com/product-catalog-service/internal/transport/grpc"
 	"github.com/product-catalog-service/internal/transport/rest"
 )
@@ -37,8 +64,16 @@ var CommonOptions = fx.Options(
 	fx.Provide(
 		newLogger,
 		newSpannerClient,
+		newMeterProvider,
+		eventbus.New,
 		newCommitter,
 		newTicker,
+		newListProductsConfig,
+		newCreateProductConfig,
+		newOutboxRelayConfig,
+		newSchedulerConfig,
+		newDiscountWindow,
+		buildinfo.Get,
 	),
 
 	// ── Repositories ─────────────────────────────────────────────────────────
@@ -48,31 +83,85 @@ var CommonOptions = fx.Options(
 			fx.As(new(contract.ProductRepository)),
 			fx.As(new(contract.QueryRepository)),
 		),
+		fx.Annotate(
+			newCategoryRepo,
+			fx.As(new(contract.CategoryRepository)),
+		),
+		fx.Annotate(
+			newReadModelRepo,
+			fx.As(new(contract.ReadModelRepository)),
+		),
 		fx.Annotate(
 			newEventRepo,
 			fx.As(new(contract.EventRepository)),
 		),
+		fx.Annotate(
+			newExchangeRates,
+			fx.As(new(contract.ExchangeRateProvider)),
+		),
+		fx.Annotate(
+			newOutboxRepo,
+			fx.As(new(outbox.Repository)),
+			fx.As(new(outbox.RelayStore)),
+			fx.As(new(outbox.ReplayStore)),
+		),
 	),
 
 	// ── Domain services ───────────────────────────────────────────────────────
 	fx.Provide(
-		services.NewPricingCalculator,
+		newPricingCalculator,
 	),
 
 	// ── Use cases ─────────────────────────────────────────────────────────────
 	fx.Provide(
 		createproduct.NewCreateProductInteractor,
-		updateproduct.NewUpdateProductInteractor,
-		applydiscount.NewApplyDiscountInteractor,
+		newUpdateProductInteractor,
+		newApplyDiscountInteractor,
 		activateproduct.NewActivateProductInteractor,
 		deactivateproduct.NewDeactivateProductInteractor,
 		removediscount.NewRemoveDiscountInteractor,
+		forcecleardiscount.NewForceClearDiscountInteractor,
+		bulkremovediscount.NewBulkRemoveDiscountInteractor,
+		bulkactivateproducts.NewBulkActivateProductsInteractor,
+		applydiscountschedule.NewApplyDiscountScheduleInteractor,
+		renamecategory.NewRenameCategoryInteractor,
+		// Run periodically by the scheduler worker below.
+		publishscheduled.NewPublishScheduledInteractor,
+		unpublishscheduled.NewUnpublishScheduledInteractor,
 	),
 
 	// ── Queries ───────────────────────────────────────────────────────────────
 	fx.Provide(
-		getproduct.NewGetProductQuery,
-		listproducts.NewListProductsQuery,
+		newGetProductQuery,
+		newListProductsQuery,
+		listsubcategories.NewListSubcategoriesQuery,
+		checkexistence.NewCheckExistenceQuery,
+		pricestats.NewPriceStatsQuery,
+		pricepreview.NewPricePreviewQuery,
+		discountpreview.NewDiscountPreviewQuery,
+		effectivepricebackfill.NewBackfillQuery,
+		outbox.NewStatusQuery,
+	),
+
+	// ── Outbox relay ──────────────────────────────────────────────────────────
+	// Requires an outbox.Publisher to be supplied by the broker integration.
+	fx.Provide(
+		outbox.NewRelay,
+		outbox.NewReplayer,
+	),
+
+	// ── Scheduler ─────────────────────────────────────────────────────────────
+	// Publishes staged products and takes products down on their schedule.
+	fx.Provide(
+		newSchedulerWorker,
+	),
+	fx.Invoke(startScheduler),
+
+	// ── Read model ────────────────────────────────────────────────────────────
+	// The projector is an outbox.Publisher; the integration that runs the relay
+	// also drives it and calls Sweep periodically.
+	fx.Provide(
+		projection.NewProjector,
 	),
 )
 
@@ -80,8 +169,11 @@ var CommonOptions = fx.Options(
 var HTTPOptions = fx.Options(
 	fx.Provide(
 		fx.Annotate(newHTTPAddr, fx.ResultTags(`name:"http_addr"`)),
+		newCORSConfig,
+		newReadinessConfig,
+		newAdminConfig,
 		rest.NewServer,
-		fx.Annotate(rest.NewHTTPServer, fx.ParamTags(``, ``, ``, `name:"http_addr"`)),
+		fx.Annotate(rest.NewHTTPServer, fx.ParamTags(``, ``, ``, ``, `name:"http_addr"`)),
 	),
 	fx.Invoke(func(*http.Server) {}),
 )
@@ -102,6 +194,28 @@ func newLogger() (*zap.Logger, error) {
 	return zap.NewProduction()
 }
 
+// newMeterProvider exports metrics to stdout every METRICS_EXPORT_INTERVAL
+// (Go duration, default 1m); 0 disables metrics entirely. Pending data is
+// flushed when the app stops.
+func newMeterProvider(lc fx.Lifecycle) (metric.MeterProvider, error) {
+	interval := time.Minute
+	if v, err := time.ParseDuration(os.Getenv("METRICS_EXPORT_INTERVAL")); err == nil && v >= 0 {
+		interval = v
+	}
+	if interval == 0 {
+		return noop.NewMeterProvider(), nil
+	}
+	exporter, err := stdoutmetric.New()
+	if err != nil {
+		return nil, fmt.Errorf("create metric exporter: %w", err)
+	}
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
+	)
+	lc.Append(fx.Hook{OnStop: mp.Shutdown})
+	return mp, nil
+}
+
 func newSpannerClient(lc fx.Lifecycle, log *zap.Logger) (*spanner.Client, error) {
 	// Map SPANNER_ENDPOINT to Google's standard SPANNER_EMULATOR_HOST
 	endpoint := os.Getenv("SPANNER_ENDPOINT")
@@ -142,14 +256,234 @@ func newSpannerClient(lc fx.Lifecycle, log *zap.Logger) (*spanner.Client, error)
 	return client, nil
 }
 
-func newCommitter(client *spanner.Client) commitplanner.Applier {
-	return commitplanner.NewCommitter(client)
+// newCommitter instruments every commit; SPANNER_SLOW_COMMIT_THRESHOLD (Go
+// duration, default 500ms, 0 disables) controls slow-commit logging and
+// SPANNER_COMMIT_TIMEOUT (default 10s, 0 disables) bounds each commit, retries
+// included. Aborted commits are retried up to SPANNER_COMMIT_MAX_ATTEMPTS
+// (default 3, 1 disables) times with jittered backoff starting from
+// SPANNER_COMMIT_RETRY_BACKOFF (default 20ms).
+// Committed events are then published on bus for in-process subscribers.
+func newCommitter(client *spanner.Client, bus *eventbus.Bus, meters metric.MeterProvider, log *zap.Logger) (commitplanner.Applier, error) {
+	slow := 500 * time.Millisecond
+	if v, err := time.ParseDuration(os.Getenv("SPANNER_SLOW_COMMIT_THRESHOLD")); err == nil && v >= 0 {
+		slow = v
+	}
+	timeout := 10 * time.Second
+	if v, err := time.ParseDuration(os.Getenv("SPANNER_COMMIT_TIMEOUT")); err == nil && v >= 0 {
+		timeout = v
+	}
+	attempts := 3
+	if v, err := strconv.Atoi(os.Getenv("SPANNER_COMMIT_MAX_ATTEMPTS")); err == nil && v > 0 {
+		attempts = v
+	}
+	backoff := 20 * time.Millisecond
+	if v, err := time.ParseDuration(os.Getenv("SPANNER_COMMIT_RETRY_BACKOFF")); err == nil && v >= 0 {
+		backoff = v
+	}
+	retrying := commitplanner.NewRetryApplier(commitplanner.NewCommitter(client), log, attempts, backoff)
+	instrumented, err := commitplanner.NewInstrumentedApplier(
+		commitplanner.NewTimeoutApplier(retrying, log, timeout),
+		meters.Meter("github.com/product-catalog-service/common/commitplanner"),
+		log,
+		slow,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return eventbus.NewApplier(instrumented, bus), nil
 }
 
 func newTicker() common.Ticker {
 	return common.NewRealTicker()
 }
 
+func newListProductsConfig() listproducts.Config {
+	cfg := listproducts.DefaultConfig()
+	if v, err := strconv.Atoi(os.Getenv("LIST_DEFAULT_LIMIT")); err == nil && v > 0 {
+		cfg.DefaultLimit = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LIST_MAX_LIMIT")); err == nil && v > 0 {
+		cfg.MaxLimit = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("LIST_TOTAL_COUNT_TTL")); err == nil && v >= 0 {
+		cfg.TotalCountTTL = v
+	}
+	if cfg.DefaultLimit > cfg.MaxLimit {
+		cfg.DefaultLimit = cfg.MaxLimit
+	}
+	return cfg
+}
+
+// newListProductsQuery serves listings from the projected read model when
+// READ_MODEL_LISTINGS is true. The projector and its sweep must be running,
+// otherwise listings go stale.
+func newListProductsQuery(queryRepo contract.QueryRepository, categories contract.CategoryRepository, pricing *services.PricingCalculator, ticker common.Ticker, cfg listproducts.Config, readModel contract.ReadModelRepository, rates contract.ExchangeRateProvider, log *zap.Logger) *listproducts.ListProductsQuery {
+	opts := []listproducts.Option{listproducts.WithExchangeRates(rates)}
+	if enabled, _ := strconv.ParseBool(os.Getenv("READ_MODEL_LISTINGS")); enabled {
+		log.Info("serving product listings from the read model")
+		opts = append(opts, listproducts.WithReadModel(readModel))
+	}
+	return listproducts.NewListProductsQuery(queryRepo, categories, pricing, ticker, cfg, opts...)
+}
+
+// newUpdateProductInteractor regenerates slugs on rename when
+// SLUG_FOLLOWS_NAME is true; by default slugs stay stable.
+// MAX_PRODUCT_CATEGORIES overrides how many categories a product can list.
+// FIELD_CHANGE_EVENTS=true emits one event per changed field. Discounts set
+// through an update follow the same discountWindow as applied ones.
+func newUpdateProductInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker, window discountWindow) *updateproduct.UpdateProductInteractor {
+	var opts []updateproduct.Option
+	if follow, _ := strconv.ParseBool(os.Getenv("SLUG_FOLLOWS_NAME")); follow {
+		opts = append(opts, updateproduct.WithSlugFollowsName())
+	}
+	if v, err := strconv.Atoi(os.Getenv("MAX_PRODUCT_CATEGORIES")); err == nil && v > 0 {
+		opts = append(opts, updateproduct.WithMaxCategories(v))
+	}
+	if granular, _ := strconv.ParseBool(os.Getenv("FIELD_CHANGE_EVENTS")); granular {
+		opts = append(opts, updateproduct.WithFieldChangeEvents())
+	}
+	opts = append(opts,
+		updateproduct.WithMaxBackdating(window.maxBackdating),
+		updateproduct.WithClockSkewTolerance(window.skewTolerance),
+	)
+	return updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, opts...)
+}
+
+// newGetProductQuery reports rounding deltas when PRICING_ROUNDING_AUDIT is true.
+func newGetProductQuery(queryRepo contract.QueryRepository, pricing *services.PricingCalculator, ticker common.Ticker, rates contract.ExchangeRateProvider) *getproduct.GetProductQuery {
+	opts := []getproduct.Option{getproduct.WithExchangeRates(rates)}
+	if audit, _ := strconv.ParseBool(os.Getenv("PRICING_ROUNDING_AUDIT")); audit {
+		opts = append(opts, getproduct.WithRoundingAudit())
+	}
+	return getproduct.NewGetProductQuery(queryRepo, pricing, ticker, opts...)
+}
+
+// newCreateProductConfig reads DEFAULT_CURRENCY and CATEGORY_CURRENCIES, a
+// comma-separated category=CURRENCY list such as "electronics=USD,groceries=VND".
+// An invalid entry or currency code fails startup.
+func newCreateProductConfig() (createproduct.Config, error) {
+	cfg := createproduct.DefaultConfig()
+	if v := os.Getenv("DEFAULT_CURRENCY"); v != "" {
+		cfg.DefaultCurrency = v
+	}
+	if entries := splitList(os.Getenv("CATEGORY_CURRENCIES")); len(entries) > 0 {
+		cfg.CategoryCurrencies = make(map[string]string, len(entries))
+		for _, entry := range entries {
+			category, currency, ok := strings.Cut(entry, "=")
+			category, currency = strings.TrimSpace(category), strings.TrimSpace(currency)
+			if !ok || category == "" {
+				return cfg, fmt.Errorf("CATEGORY_CURRENCIES: invalid entry %q, want category=CURRENCY", entry)
+			}
+			cfg.CategoryCurrencies[category] = currency
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("product currency config: %w", err)
+	}
+	return cfg, nil
+}
+
+// newSchedulerConfig reads SCHEDULER_INTERVAL (Go duration, default 1m, 0
+// disables) and SCHEDULER_BATCH_SIZE (default 100).
+func newSchedulerConfig() scheduler.Config {
+	cfg := scheduler.DefaultConfig()
+	if v, err := time.ParseDuration(os.Getenv("SCHEDULER_INTERVAL")); err == nil && v >= 0 {
+		cfg.Interval = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("SCHEDULER_BATCH_SIZE")); err == nil && v > 0 {
+		cfg.BatchSize = v
+	}
+	return cfg
+}
+
+func newSchedulerWorker(cfg scheduler.Config, log *zap.Logger, publish *publishscheduled.PublishScheduledInteractor, unpublish *unpublishscheduled.UnpublishScheduledInteractor) *scheduler.Worker {
+	return scheduler.NewWorker(cfg, log).
+		Add("publish", publish).
+		Add("unpublish", unpublish)
+}
+
+// startScheduler runs worker in the background for the app's lifetime, unless
+// its interval is 0.
+func startScheduler(lc fx.Lifecycle, worker *scheduler.Worker, cfg scheduler.Config) {
+	if cfg.Interval == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				worker.Run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
+
+func newOutboxRelayConfig() outbox.RelayConfig {
+	cfg := outbox.DefaultRelayConfig()
+	if v, err := strconv.ParseInt(os.Getenv("OUTBOX_MAX_ATTEMPTS"), 10, 64); err == nil && v > 0 {
+		cfg.MaxAttempts = v
+	}
+	return cfg
+}
+
+// newPricingCalculator reads PRICING_ROUNDING_MODE and DISCOUNTS_DISABLED, the
+// incident switch that serves base prices without touching stored discounts.
+func newPricingCalculator(log *zap.Logger) (*services.PricingCalculator, error) {
+	var opts []services.Option
+	if mode := os.Getenv("PRICING_ROUNDING_MODE"); mode != "" {
+		rounding, err := domain.ParseRoundingMode(mode)
+		if err != nil {
+			return nil, fmt.Errorf("PRICING_ROUNDING_MODE=%q: %w", mode, err)
+		}
+		opts = append(opts, services.WithRoundingMode(rounding))
+	}
+	if disabled, _ := strconv.ParseBool(os.Getenv("DISCOUNTS_DISABLED")); disabled {
+		log.Warn("DISCOUNTS_DISABLED is set: all discounts are ignored and base prices served")
+		opts = append(opts, services.WithDiscountsDisabled(func() bool { return true }))
+	}
+	return services.NewPricingCalculator(opts...), nil
+}
+
+// discountWindow bounds when a newly applied discount may start relative to
+// the server clock. Every interactor that applies a discount uses it.
+type discountWindow struct {
+	maxBackdating time.Duration // 0 = any past start
+	skewTolerance time.Duration // 0 = no future start
+}
+
+// newDiscountWindow refuses discounts starting more than
+// DISCOUNT_MAX_BACKDATING before the server clock and accepts them starting up
+// to DISCOUNT_CLOCK_SKEW_TOLERANCE after it. Unset or 0 leaves either off.
+func newDiscountWindow() discountWindow {
+	var w discountWindow
+	if v, err := time.ParseDuration(os.Getenv("DISCOUNT_MAX_BACKDATING")); err == nil && v > 0 {
+		w.maxBackdating = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("DISCOUNT_CLOCK_SKEW_TOLERANCE")); err == nil && v > 0 {
+		w.skewTolerance = v
+	}
+	return w
+}
+
+func newApplyDiscountInteractor(committer commitplanner.Applier, repo contract.ProductRepository, eventRepo contract.EventRepository, ticker common.Ticker, window discountWindow) *applydiscount.ApplyDiscountInteractor {
+	return applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker,
+		applydiscount.WithMaxBackdating(window.maxBackdating),
+		applydiscount.WithClockSkewTolerance(window.skewTolerance),
+	)
+}
+
 func newHTTPAddr() string {
 	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
 		return addr
@@ -157,6 +491,53 @@ func newHTTPAddr() string {
 	return ":8080"
 }
 
+// newReadinessConfig enables the /readyz outbox backlog check when
+// OUTBOX_MAX_PENDING_AGE (Go duration) is set; unset leaves it off.
+func newReadinessConfig() rest.ReadinessConfig {
+	var cfg rest.ReadinessConfig
+	if v, err := time.ParseDuration(os.Getenv("OUTBOX_MAX_PENDING_AGE")); err == nil && v > 0 {
+		cfg.OutboxMaxPendingAge = v
+	}
+	return cfg
+}
+
+// newAdminConfig reads ADMIN_TOKEN; unset leaves the /admin routes refusing
+// every request.
+func newAdminConfig(log *zap.Logger) rest.AdminConfig {
+	token := os.Getenv("ADMIN_TOKEN")
+	if token == "" {
+		log.Warn("ADMIN_TOKEN not set; /admin endpoints are disabled")
+	}
+	return rest.AdminConfig{Token: token}
+}
+
+// newCORSConfig reads comma-separated CORS_ALLOWED_* lists; unset origins keep
+// the same-origin default.
+func newCORSConfig() rest.CORSConfig {
+	cfg := rest.DefaultCORSConfig()
+	if v := splitList(os.Getenv("CORS_ALLOWED_ORIGINS")); len(v) > 0 {
+		cfg.AllowedOrigins = v
+	}
+	if v := splitList(os.Getenv("CORS_ALLOWED_METHODS")); len(v) > 0 {
+		cfg.AllowedMethods = v
+	}
+	if v := splitList(os.Getenv("CORS_ALLOWED_HEADERS")); len(v) > 0 {
+		cfg.AllowedHeaders = v
+	}
+	return cfg
+}
+
+// splitList splits a comma-separated env value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
 func newGRPCAddr() string {
 	if addr := os.Getenv("GRPC_ADDR"); addr != "" {
 		return addr
@@ -164,10 +545,47 @@ func newGRPCAddr() string {
 	return ":50051"
 }
 
-func newProductRepo(client *spanner.Client) *repo.ProductRepo {
-	return repo.NewProductRepo(client)
+// newProductRepo logs reads slower than SPANNER_SLOW_QUERY_THRESHOLD (Go
+// duration, default 200ms, 0 disables).
+func newProductRepo(client *spanner.Client, log *zap.Logger) *repo.ProductRepo {
+	slow := 200 * time.Millisecond
+	if v, err := time.ParseDuration(os.Getenv("SPANNER_SLOW_QUERY_THRESHOLD")); err == nil && v >= 0 {
+		slow = v
+	}
+	return repo.NewProductRepo(client, log, slow)
+}
+
+func newCategoryRepo(client *spanner.Client) *repo.CategoryRepo {
+	return repo.NewCategoryRepo(client)
+}
+
+func newReadModelRepo(client *spanner.Client) *repo.ReadModelRepo {
+	return repo.NewReadModelRepo(client)
 }
 
 func newEventRepo() *repo.EventRepo {
 	return repo.NewEventRepo()
 }
+
+// newExchangeRates reads EXCHANGE_RATES, a comma-separated FROM:TO=rate list
+// such as "USD:VND=25000". An invalid entry fails startup; unset serves every
+// price in its stored currency.
+func newExchangeRates() (*repo.StaticExchangeRates, error) {
+	rates := make(map[[2]string]float64)
+	for _, entry := range splitList(os.Getenv("EXCHANGE_RATES")) {
+		pair, value, _ := strings.Cut(entry, "=")
+		from, to, _ := strings.Cut(pair, ":")
+		from, fromOK := common.ParseCurrency(strings.TrimSpace(from))
+		to, toOK := common.ParseCurrency(strings.TrimSpace(to))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !fromOK || !toOK || err != nil || !(rate > 0) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("EXCHANGE_RATES: invalid entry %q, want FROM:TO=rate", entry)
+		}
+		rates[[2]string{from, to}] = rate
+	}
+	return repo.NewStaticExchangeRates(rates), nil
+}
+
+func newOutboxRepo(client *spanner.Client) *outbox.SpannerRepo {
+	return outbox.NewSpannerRepo(client)
+}
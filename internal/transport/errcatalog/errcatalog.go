@@ -0,0 +1,161 @@
+// Package errcatalog turns domain errors into the reason codes and
+// client-facing messages both transports return, in the client's language.
+package errcatalog
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+)
+
+// ReasonValidationFailed is the reason for a *domain.ValidationError; each of
+// its fields carries the reason of its own error.
+const ReasonValidationFailed = "VALIDATION_FAILED"
+
+// reasons maps each domain sentinel to its stable, machine-readable reason.
+// Clients branch on these, so existing entries must never be renamed.
+var reasons = []struct {
+	err    error
+	reason string
+}{
+	{domain.ErrDiscountInvalidPercentage, "DISCOUNT_INVALID_PERCENTAGE"},
+	{domain.ErrDiscountInvalidPeriod, "DISCOUNT_INVALID_PERIOD"},
+	{domain.ErrDiscountInvalidDuration, "DISCOUNT_INVALID_DURATION"},
+	{domain.ErrProductNotActive, "PRODUCT_NOT_ACTIVE"},
+	{domain.ErrProductNotFound, "PRODUCT_NOT_FOUND"},
+	{domain.ErrProductArchived, "PRODUCT_ARCHIVED"},
+	{domain.ErrProductIDRequired, "PRODUCT_ID_REQUIRED"},
+	{domain.ErrProductNameRequired, "PRODUCT_NAME_REQUIRED"},
+	{domain.ErrProductCategoryRequired, "PRODUCT_CATEGORY_REQUIRED"},
+	{domain.ErrProductBasePriceRequired, "PRODUCT_BASE_PRICE_REQUIRED"},
+	{domain.ErrProductFieldTooLong, "PRODUCT_FIELD_TOO_LONG"},
+	{domain.ErrNoFieldsToUpdate, "NO_FIELDS_TO_UPDATE"},
+	{domain.ErrInvalidMediaURL, "INVALID_MEDIA_URL"},
+	{domain.ErrTooManyMediaURLs, "TOO_MANY_MEDIA_URLS"},
+	{domain.ErrSlugConflict, "SLUG_CONFLICT"},
+	{domain.ErrNegativeStock, "NEGATIVE_STOCK"},
+	{domain.ErrStockOverflow, "STOCK_OVERFLOW"},
+	{domain.ErrInvalidAttributeKey, "INVALID_ATTRIBUTE_KEY"},
+	{domain.ErrInvalidAttributeValue, "INVALID_ATTRIBUTE_VALUE"},
+	{domain.ErrTooManyAttributes, "TOO_MANY_ATTRIBUTES"},
+	{domain.ErrProductAlreadyActive, "PRODUCT_ALREADY_ACTIVE"},
+	{domain.ErrPublishAtNotInFuture, "PUBLISH_AT_NOT_IN_FUTURE"},
+	{domain.ErrUnpublishBeforePublish, "UNPUBLISH_BEFORE_PUBLISH"},
+	{domain.ErrCurrencyChangeNotAllowed, "CURRENCY_CHANGE_NOT_ALLOWED"},
+	{domain.ErrCategoryIDRequired, "CATEGORY_ID_REQUIRED"},
+	{domain.ErrCategoryNameRequired, "CATEGORY_NAME_REQUIRED"},
+	{domain.ErrCategoryNotFound, "CATEGORY_NOT_FOUND"},
+	{domain.ErrCategoryCycle, "CATEGORY_CYCLE"},
+	{domain.ErrDuplicateCategory, "DUPLICATE_CATEGORY"},
+	{domain.ErrTooManyCategories, "TOO_MANY_CATEGORIES"},
+	{domain.ErrPrimaryCategoryRemoval, "PRIMARY_CATEGORY_REMOVAL"},
+	{domain.ErrInvalidDiscountPeriod, "INVALID_DISCOUNT_PERIOD"},
+	{domain.ErrNoActiveDiscount, "NO_ACTIVE_DISCOUNT"},
+	{domain.ErrDiscountOverlap, "DISCOUNT_OVERLAP"},
+	{domain.ErrScheduledDiscountPending, "SCHEDULED_DISCOUNT_PENDING"},
+	{domain.ErrDiscountBackdatedTooFar, "DISCOUNT_BACKDATED_TOO_FAR"},
+	{domain.ErrInvalidQuantityTier, "INVALID_QUANTITY_TIER"},
+	{domain.ErrDuplicateQuantityTier, "DUPLICATE_QUANTITY_TIER"},
+	{domain.ErrTooManyQuantityTiers, "TOO_MANY_QUANTITY_TIERS"},
+	{domain.ErrInvalidQuantity, "INVALID_QUANTITY"},
+	{domain.ErrInvalidStatus, "INVALID_STATUS"},
+	{domain.ErrInvalidDiscountState, "INVALID_DISCOUNT_STATE"},
+	{domain.ErrDuplicateIDInBatch, "DUPLICATE_ID_IN_BATCH"},
+	{domain.ErrInvalidCursor, "INVALID_CURSOR"},
+	{domain.ErrInvalidPagination, "INVALID_PAGINATION"},
+	{domain.ErrInvalidSortOrder, "INVALID_SORT_ORDER"},
+	{domain.ErrIncompleteAttribute, "INCOMPLETE_ATTRIBUTE"},
+	{domain.ErrNegativeAmount, "NEGATIVE_AMOUNT"},
+	{domain.ErrCurrencyMismatch, "CURRENCY_MISMATCH"},
+	{domain.ErrInvalidCurrency, "INVALID_CURRENCY"},
+	{domain.ErrUnsupportedCurrency, "UNSUPPORTED_CURRENCY"},
+	{domain.ErrInvalidDecimalAmount, "INVALID_DECIMAL_AMOUNT"},
+	{domain.ErrAmountTooPrecise, "AMOUNT_TOO_PRECISE"},
+	{domain.ErrAmountOverflow, "AMOUNT_OVERFLOW"},
+	{domain.ErrDivisionByZero, "DIVISION_BY_ZERO"},
+	{domain.ErrInvalidDiscountAmount, "INVALID_DISCOUNT_AMOUNT"},
+	{domain.ErrInvalidRoundingMode, "INVALID_ROUNDING_MODE"},
+}
+
+// catalog holds translations by locale and reason. English is the domain's
+// own error text and is not listed; a reason missing from a locale falls back
+// to it.
+var catalog = map[language.Tag]map[string]string{
+	language.Vietnamese: {
+		ReasonValidationFailed:        "dữ liệu không hợp lệ",
+		"PRODUCT_NOT_FOUND":           "không tìm thấy sản phẩm",
+		"PRODUCT_NOT_ACTIVE":          "sản phẩm chưa được kích hoạt",
+		"PRODUCT_ARCHIVED":            "sản phẩm đã được lưu trữ",
+		"CATEGORY_NOT_FOUND":          "không tìm thấy danh mục",
+		"DISCOUNT_INVALID_PERCENTAGE": "phần trăm giảm giá phải nằm trong khoảng từ 0 đến 100",
+		"DISCOUNT_OVERLAP":            "thời gian giảm giá trùng với chương trình giảm giá hiện có",
+		"DISCOUNT_BACKDATED_TOO_FAR":  "thời điểm bắt đầu giảm giá quá xa trong quá khứ",
+	},
+}
+
+// supported lists the catalog's locales; English comes first so that clients
+// asking for nothing we support get it.
+var (
+	supported = []language.Tag{language.English, language.Vietnamese}
+	matcher   = language.NewMatcher(supported)
+)
+
+// Negotiate picks the supported locale best matching an Accept-Language value
+// such as "vi-VN,vi;q=0.9,en;q=0.8". An empty or malformed value yields English.
+func Negotiate(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return language.English
+	}
+	_, i, _ := matcher.Match(tags...)
+	return supported[i]
+}
+
+// Reasons lists every reason code Reason can return, in catalog order.
+func Reasons() []string {
+	out := make([]string, 0, len(reasons)+1)
+	for _, r := range reasons {
+		out = append(out, r.reason)
+	}
+	return append(out, ReasonValidationFailed)
+}
+
+// Reason returns the reason code for err, or "" when err is not a known
+// domain error.
+func Reason(err error) string {
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		return ReasonValidationFailed
+	}
+	for _, r := range reasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+	return ""
+}
+
+// Message returns the client-facing message for err in locale. Without a
+// translation it is err.Error(), which keeps details such as the offending ID
+// that a translated message leaves out.
+func Message(err error, locale language.Tag) string {
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		msg, ok := catalog[locale][ReasonValidationFailed]
+		if !ok {
+			return err.Error()
+		}
+		parts := make([]string, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			parts = append(parts, f.Field+": "+Message(f.Err, locale))
+		}
+		return msg + ": " + strings.Join(parts, "; ")
+	}
+	if msg, ok := catalog[locale][Reason(err)]; ok {
+		return msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,49 @@
+package errcatalog
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+// TestReasons_CoverEveryDomainSentinel keeps the reason table in step with
+// domain_errors.go: every errors.New sentinel declared there needs a reason.
+func TestReasons_CoverEveryDomainSentinel(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "../../app/product/domain/domain_errors.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	known := make(map[string]bool, len(reasons))
+	for _, r := range reasons {
+		known[r.err.Error()] = true
+	}
+	seen := 0
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, v := range spec.Values {
+			call, ok := v.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				continue
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			msg, _ := strconv.Unquote(lit.Value)
+			seen++
+			if !known[msg] {
+				t.Errorf("%s has no reason", spec.Names[i].Name)
+			}
+		}
+		return true
+	})
+	if seen != len(reasons) {
+		t.Fatalf("expected %d sentinels to match the %d reasons", seen, len(reasons))
+	}
+}
@@ -0,0 +1,28 @@
+package grpctransport
+
+import (
+	"context"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/transport/errcatalog"
+)
+
+func (s *ProductServiceServer) GetVersion(ctx context.Context, req *productv1.GetVersionRequest) (*productv1.GetVersionReply, error) {
+	return &productv1.GetVersionReply{
+		Version:   s.p.BuildInfo.Version,
+		Commit:    s.p.BuildInfo.Commit,
+		BuildTime: s.p.BuildInfo.BuildTime,
+	}, nil
+}
+
+func (s *ProductServiceServer) GetMetadata(ctx context.Context, req *productv1.GetMetadataRequest) (*productv1.GetMetadataReply, error) {
+	reply := &productv1.GetMetadataReply{ErrorReasons: errcatalog.Reasons()}
+	for _, st := range domain.ProductStatuses {
+		reply.ProductStatuses = append(reply.ProductStatuses, string(st))
+	}
+	for _, st := range domain.DiscountStates {
+		reply.DiscountStates = append(reply.DiscountStates, string(st))
+	}
+	return reply, nil
+}
@@ -2,12 +2,16 @@ package grpctransport
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	productv1 "github.com/product-catalog-service/gen/product/v1"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
 	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
 	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
@@ -15,13 +19,26 @@ import (
 )
 
 func (s *ProductServiceServer) CreateProduct(ctx context.Context, req *productv1.CreateProductRequest) (*productv1.CreateProductReply, error) {
-	id, err := s.p.CreateProductInteractor.Execute(ctx, &createproduct.CreateProductRequest{
+	ucReq := &createproduct.CreateProductRequest{
 		Name:        req.Name,
 		Description: req.Description,
 		Category:    req.Category,
-	})
+	}
+	if req.BasePrice != nil {
+		ucReq.Amount = &req.BasePrice.Amount
+		ucReq.Currency = req.BasePrice.Currency
+	}
+	if req.PublishAt != nil {
+		at := req.PublishAt.AsTime()
+		ucReq.PublishAt = &at
+	}
+	if req.UnpublishAt != nil {
+		at := req.UnpublishAt.AsTime()
+		ucReq.UnpublishAt = &at
+	}
+	id, err := s.p.CreateProductInteractor.Execute(ctx, ucReq)
 	if err != nil {
-		return nil, toStatusErr(err)
+		return nil, s.toStatusErr(ctx, err)
 	}
 	return &productv1.CreateProductReply{Id: id}, nil
 }
@@ -37,45 +54,150 @@ func (s *ProductServiceServer) UpdateProduct(ctx context.Context, req *productv1
 	if req.Category != "" {
 		ucReq.Category = &req.Category
 	}
+	if p := req.BasePrice; p != nil {
+		ucReq.BasePrice = &updateproduct.PriceUpdate{Amount: p.Amount, Currency: p.Currency}
+	}
+	ucReq.Featured = req.Featured
+	ucReq.Stock = req.StockQuantity
+	ucReq.AddCategories = req.AddCategories
+	ucReq.RemoveCategories = req.RemoveCategories
+	ucReq.SetAttributes = req.SetAttributes
+	ucReq.RemoveAttributes = req.RemoveAttributes
+	if m := req.Media; m != nil {
+		urls := m.GetUrls()
+		ucReq.MediaURLs = &urls
+	}
+	if q := req.QuantityTiers; q != nil {
+		tiers := make([]updateproduct.QuantityTierUpdate, 0, len(q.Tiers))
+		for _, t := range q.Tiers {
+			tiers = append(tiers, updateproduct.QuantityTierUpdate{MinQuantity: t.MinQuantity, Percentage: t.Percentage})
+		}
+		ucReq.QuantityTiers = &tiers
+	}
+	if d := req.Discount; d != nil {
+		ucReq.Discount = &updateproduct.DiscountUpdate{
+			Clear:      d.Clear,
+			Percentage: d.Percentage,
+			StartsAt:   d.StartsAt.AsTime(),
+			EndsAt:     d.EndsAt.AsTime(),
+		}
+	}
+	if pa := req.PublishAt; pa != nil {
+		ucReq.PublishAt = &updateproduct.ScheduleUpdate{Clear: pa.Clear, At: pa.At.AsTime()}
+	}
+	if ua := req.UnpublishAt; ua != nil {
+		ucReq.UnpublishAt = &updateproduct.ScheduleUpdate{Clear: ua.Clear, At: ua.At.AsTime()}
+	}
 
-	if err := s.p.UpdateProductInteractor.Execute(ctx, ucReq); err != nil {
-		return nil, toStatusErr(err)
+	res, err := s.p.UpdateProductInteractor.Execute(ctx, ucReq)
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
-	return &productv1.UpdateProductReply{}, nil
+
+	fields := make([]string, 0, len(res.ChangedFields))
+	for _, f := range res.ChangedFields {
+		fields = append(fields, string(f))
+	}
+	return &productv1.UpdateProductReply{Changed: res.Changed, ChangedFields: fields}, nil
 }
 
 func (s *ProductServiceServer) ActivateProduct(ctx context.Context, req *productv1.ActivateProductRequest) (*productv1.ActivateProductReply, error) {
-	if err := s.p.ActivateProductInteractor.Execute(ctx, &activateproduct.ActivateProductRequest{ProductID: req.Id}); err != nil {
-		return nil, toStatusErr(err)
+	if err := s.p.ActivateProductInteractor.Execute(ctx, &activateproduct.ActivateProductRequest{ProductID: req.Id, RestoreDiscount: req.RestoreDiscount}); err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
 	return &productv1.ActivateProductReply{}, nil
 }
 
 func (s *ProductServiceServer) DeactivateProduct(ctx context.Context, req *productv1.DeactivateProductRequest) (*productv1.DeactivateProductReply, error) {
-	if err := s.p.DeactivateProductInteractor.Execute(ctx, &deactivateproduct.DeactivateProductRequest{ProductID: req.Id}); err != nil {
-		return nil, toStatusErr(err)
+	if err := s.p.DeactivateProductInteractor.Execute(ctx, &deactivateproduct.DeactivateProductRequest{ProductID: req.Id, Force: req.Force}); err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
 	return &productv1.DeactivateProductReply{}, nil
 }
 
 func (s *ProductServiceServer) ApplyDiscount(ctx context.Context, req *productv1.ApplyDiscountRequest) (*productv1.ApplyDiscountReply, error) {
-	if err := s.p.ApplyDiscountInteractor.Execute(ctx, &applydiscount.ApplyDiscountRequest{
+	ucReq := &applydiscount.ApplyDiscountRequest{
 		ProductID:  req.Id,
 		Percentage: req.Percentage,
-		StartsAt:   req.StartsAt.AsTime(),
 		EndsAt:     req.EndsAt.AsTime(),
-	}); err != nil {
-		return nil, toStatusErr(err)
+	}
+	if req.StartsAt != nil { // unset = now when a duration is given
+		ucReq.StartsAt = req.StartsAt.AsTime()
+	}
+	if req.DurationSeconds != 0 {
+		d := time.Duration(req.DurationSeconds) * time.Second
+		ucReq.Duration = &d
+	}
+
+	if err := s.p.ApplyDiscountInteractor.Execute(ctx, ucReq); err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
 	return &productv1.ApplyDiscountReply{}, nil
 }
 
 func (s *ProductServiceServer) RemoveDiscount(ctx context.Context, req *productv1.RemoveDiscountRequest) (*productv1.RemoveDiscountReply, error) {
-	if err := s.p.RemoveDiscountInteractor.Execute(ctx, &removediscount.RemoveDiscountRequest{ProductID: req.Id}); err != nil {
-		return nil, toStatusErr(err)
+	if err := s.p.RemoveDiscountInteractor.Execute(ctx, &removediscount.RemoveDiscountRequest{
+		ProductID:  req.Id,
+		Idempotent: req.Idempotent,
+	}); err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
 	return &productv1.RemoveDiscountReply{}, nil
 }
 
+func (s *ProductServiceServer) BulkRemoveDiscount(ctx context.Context, req *productv1.BulkRemoveDiscountRequest) (*productv1.BulkRemoveDiscountReply, error) {
+	res, err := s.p.BulkRemoveDiscountInteractor.Execute(ctx, &bulkremovediscount.BulkRemoveDiscountRequest{
+		Category: req.Category,
+	})
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
+	}
+	reply := &productv1.BulkRemoveDiscountReply{RemovedCount: int32(res.Removed), SkippedCount: int32(res.Skipped)}
+	for _, o := range res.Products {
+		reply.Products = append(reply.Products, &productv1.BulkRemoveDiscountOutcome{ProductId: o.ProductID, Outcome: string(o.Outcome)})
+	}
+	return reply, nil
+}
+
+func (s *ProductServiceServer) BulkActivateProducts(ctx context.Context, req *productv1.BulkActivateProductsRequest) (*productv1.BulkActivateProductsReply, error) {
+	activated, err := s.p.BulkActivateProductsInteractor.Execute(ctx, &bulkactivateproducts.BulkActivateProductsRequest{
+		Category:        req.Category,
+		RestoreDiscount: req.RestoreDiscount,
+	})
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
+	}
+	return &productv1.BulkActivateProductsReply{ActivatedCount: int32(activated)}, nil
+}
+
 // helper — convert *timestamppb.Timestamp to proto (silences unused import).
 var _ = timestamppb.Now
+
+func (s *ProductServiceServer) ApplyDiscountSchedule(ctx context.Context, req *productv1.ApplyDiscountScheduleRequest) (*productv1.ApplyDiscountScheduleReply, error) {
+	ucReq := &applydiscountschedule.ApplyDiscountScheduleRequest{}
+	for _, e := range req.Entries {
+		ucReq.Entries = append(ucReq.Entries, applydiscountschedule.ScheduleEntry{
+			ProductID:  e.ProductId,
+			Percentage: e.Percentage,
+			StartsAt:   e.StartsAt.AsTime(),
+			EndsAt:     e.EndsAt.AsTime(),
+		})
+	}
+
+	results, err := s.p.ApplyDiscountScheduleInteractor.Execute(ctx, ucReq)
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
+	}
+
+	reply := &productv1.ApplyDiscountScheduleReply{}
+	for _, r := range results {
+		res := &productv1.ScheduleEntryResult{ProductId: r.ProductID, Applied: r.Err == nil}
+		if r.Err != nil {
+			res.Error = r.Err.Error()
+		} else {
+			reply.AppliedCount++
+		}
+		reply.Results = append(reply.Results, res)
+	}
+	return reply, nil
+}
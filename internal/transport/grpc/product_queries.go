@@ -3,27 +3,83 @@ package grpctransport
 import (
 	"context"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	productv1 "github.com/product-catalog-service/gen/product/v1"
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
+	discountpreview "github.com/product-catalog-service/internal/app/product/queries/discount_preview"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
+	"github.com/product-catalog-service/internal/transport/protomap"
 )
 
 func (s *ProductServiceServer) GetProduct(ctx context.Context, req *productv1.GetProductRequest) (*productv1.GetProductReply, error) {
-	dto, err := s.p.GetProductQuery.Execute(ctx, &getproduct.GetProductRequest{ProductID: req.Id})
+	dto, err := s.p.GetProductQuery.Execute(ctx, &getproduct.GetProductRequest{
+		ProductID:             req.Id,
+		IncludeDiscountStates: req.IncludeDiscountStates,
+		PreviewAsScheduled:    req.PreviewAsScheduled,
+	})
 	if err != nil {
-		return nil, toStatusErr(err)
+		return nil, s.toStatusErr(ctx, err)
 	}
-	return &productv1.GetProductReply{Product: toProtoProduct(dto)}, nil
+	return &productv1.GetProductReply{Product: protomap.Product(dto)}, nil
+}
+
+func (s *ProductServiceServer) BatchGetProducts(ctx context.Context, req *productv1.BatchGetProductsRequest) (*productv1.BatchGetProductsReply, error) {
+	ucReq := &getproduct.BatchGetProductsRequest{
+		ProductIDs:            req.Ids,
+		IncludeDiscountStates: req.IncludeDiscountStates,
+	}
+
+	if req.OrderPreserving {
+		dtos, err := s.p.GetProductQuery.ExecuteBatchOrdered(ctx, ucReq)
+		if err != nil {
+			return nil, s.toStatusErr(ctx, err)
+		}
+		results := make([]*productv1.BatchGetProductsResult, len(dtos))
+		for i, dto := range dtos {
+			results[i] = &productv1.BatchGetProductsResult{Id: req.Ids[i]}
+			if dto != nil {
+				results[i].Product = protomap.Product(dto)
+			}
+		}
+		return &productv1.BatchGetProductsReply{Results: results}, nil
+	}
+
+	res, err := s.p.GetProductQuery.ExecuteBatchPartial(ctx, ucReq)
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
+	}
+	products := make(map[string]*productv1.Product, len(res.Found))
+	for id, dto := range res.Found {
+		products[id] = protomap.Product(dto)
+	}
+	return &productv1.BatchGetProductsReply{
+		Products: products,
+		NotFound: res.NotFound,
+		Invalid:  res.Invalid,
+	}, nil
 }
 
 func (s *ProductServiceServer) ListProducts(ctx context.Context, req *productv1.ListProductsRequest) (*productv1.ListProductsReply, error) {
 	ucReq := &listproducts.ListProductsRequest{
-		Limit:  int(req.Limit),
-		Offset: int(req.Offset),
+		Statuses:             req.Statuses,
+		Limit:                int(req.Limit),
+		Offset:               int(req.Offset),
+		Cursor:               req.Cursor,
+		IncludeTotal:         req.IncludeTotal,
+		RefreshTotal:         req.RefreshTotal,
+		IncludeSubcategories: req.IncludeSubcategories,
+		Featured:             req.Featured,
+		InStockOnly:          req.InStockOnly,
+		AttributeKey:         req.AttributeKey,
+		AttributeValue:       req.AttributeValue,
+		OnSale:               req.OnSale,
+		SortBy:               req.SortBy,
 	}
 	if req.Category != "" {
 		ucReq.Category = &req.Category
@@ -31,61 +87,69 @@ func (s *ProductServiceServer) ListProducts(ctx context.Context, req *productv1.
 
 	resp, err := s.p.ListProductsQuery.Execute(ctx, ucReq)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, s.toStatusErr(ctx, err)
 	}
 
 	products := make([]*productv1.Product, 0, len(resp.Items))
 	for _, item := range resp.Items {
-		products = append(products, toProtoProductSummary(item))
+		p, err := protomap.ProductSummary(item)
+		if err != nil {
+			s.p.Log.Error("listProducts: invalid summary", zap.Error(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		products = append(products, p)
 	}
 
 	return &productv1.ListProductsReply{
 		Products:   products,
 		TotalCount: int32(resp.TotalCount),
+		NextCursor: resp.NextCursor,
 	}, nil
 }
 
-// ── Mapping helpers ───────────────────────────────────────────────────────────
-
-func toProtoProduct(dto *getproduct.ProductDTO) *productv1.Product {
-	p := &productv1.Product{
-		Id:             dto.ID,
-		Name:           dto.Name,
-		Description:    dto.Description,
-		Category:       dto.Category,
-		Status:         dto.Status,
-		BasePrice:      toProtoMoney(dto.BasePrice.Amount, dto.BasePrice.Currency),
-		EffectivePrice: toProtoMoney(dto.EffectivePrice.Amount, dto.EffectivePrice.Currency),
+func (s *ProductServiceServer) ListSubcategories(ctx context.Context, req *productv1.ListSubcategoriesRequest) (*productv1.ListSubcategoriesReply, error) {
+	resp, err := s.p.ListSubcategoriesQuery.Execute(ctx, &listsubcategories.ListSubcategoriesRequest{
+		CategoryID: req.CategoryId,
+	})
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
-	if dto.Discount != nil {
-		p.Discount = &productv1.Discount{
-			AmountPercentage: dto.Discount.Percentage,
-			StartsAt:         timestamppb.New(dto.Discount.StartsAt),
-			EndsAt:           timestamppb.New(dto.Discount.EndsAt),
-			IsActive:         dto.Discount.IsActive,
-		}
+
+	categories := make([]*productv1.Category, 0, len(resp.Items))
+	for _, c := range resp.Items {
+		categories = append(categories, &productv1.Category{Id: c.ID, Name: c.Name, ParentId: c.ParentID})
 	}
-	return p
+	return &productv1.ListSubcategoriesReply{Categories: categories}, nil
 }
 
-func toProtoProductSummary(dto *listproducts.ProductSummaryDTO) *productv1.Product {
-	p := &productv1.Product{
-		Id:             dto.ID,
-		Name:           dto.Name,
-		Category:       dto.Category,
-		Status:         dto.Status,
-		BasePrice:      toProtoMoney(dto.BasePrice.Amount, dto.BasePrice.Currency),
-		EffectivePrice: toProtoMoney(dto.EffectivePrice.Amount, dto.EffectivePrice.Currency),
-	}
-	if dto.DiscountEndsAt != nil {
-		p.Discount = &productv1.Discount{
-			IsActive: dto.IsDiscounted,
-			EndsAt:   timestamppb.New(*dto.DiscountEndsAt),
-		}
+func (s *ProductServiceServer) CheckProductsExist(ctx context.Context, req *productv1.CheckProductsExistRequest) (*productv1.CheckProductsExistReply, error) {
+	resp, err := s.p.CheckExistenceQuery.Execute(ctx, &checkexistence.CheckExistenceRequest{
+		ProductIDs: req.ProductIds,
+	})
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
 	}
-	return p
+	return &productv1.CheckProductsExistReply{Exists: resp.Exists}, nil
 }
 
-func toProtoMoney(amount int64, currency string) *productv1.Money {
-	return &productv1.Money{Amount: amount, Currency: currency}
+func (s *ProductServiceServer) PreviewDiscount(ctx context.Context, req *productv1.PreviewDiscountRequest) (*productv1.PreviewDiscountReply, error) {
+	ucReq := &discountpreview.DiscountPreviewRequest{
+		ProductID:  req.Id,
+		Percentage: req.Percentage,
+	}
+	if req.At != nil {
+		ucReq.At = req.At.AsTime()
+	}
+	dto, err := s.p.DiscountPreviewQuery.Execute(ctx, ucReq)
+	if err != nil {
+		return nil, s.toStatusErr(ctx, err)
+	}
+	return &productv1.PreviewDiscountReply{
+		Id:             dto.ProductID,
+		Percentage:     dto.Percentage,
+		At:             timestamppb.New(dto.At),
+		BasePrice:      protomap.Money(dto.BasePrice.Amount, dto.BasePrice.Currency),
+		EffectivePrice: protomap.Money(dto.EffectivePrice.Amount, dto.EffectivePrice.Currency),
+		Saved:          protomap.Money(dto.Saved.Amount, dto.Saved.Currency),
+	}, nil
 }
@@ -5,38 +5,58 @@ import (
 	"errors"
 	"net"
 
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/buildinfo"
 	productv1 "github.com/product-catalog-service/gen/product/v1"
 	"github.com/product-catalog-service/internal/app/product/domain"
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
+	discountpreview "github.com/product-catalog-service/internal/app/product/queries/discount_preview"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
 	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
 	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
 	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+	"github.com/product-catalog-service/internal/transport/errcatalog"
 )
 
 // Params bundles all handler dependencies injected by FX.
 type Params struct {
 	fx.In
 
-	Log                         *zap.Logger
-	CreateProductInteractor     *createproduct.CreateProductInteractor
-	UpdateProductInteractor     *updateproduct.UpdateProductInteractor
-	ActivateProductInteractor   *activateproduct.ActivateProductInteractor
-	DeactivateProductInteractor *deactivateproduct.DeactivateProductInteractor
-	ApplyDiscountInteractor     *applydiscount.ApplyDiscountInteractor
-	RemoveDiscountInteractor    *removediscount.RemoveDiscountInteractor
-	GetProductQuery             *getproduct.GetProductQuery
-	ListProductsQuery           *listproducts.ListProductsQuery
+	Log                             *zap.Logger
+	BuildInfo                       buildinfo.Info
+	CreateProductInteractor         *createproduct.CreateProductInteractor
+	UpdateProductInteractor         *updateproduct.UpdateProductInteractor
+	ActivateProductInteractor       *activateproduct.ActivateProductInteractor
+	DeactivateProductInteractor     *deactivateproduct.DeactivateProductInteractor
+	ApplyDiscountInteractor         *applydiscount.ApplyDiscountInteractor
+	RemoveDiscountInteractor        *removediscount.RemoveDiscountInteractor
+	BulkRemoveDiscountInteractor    *bulkremovediscount.BulkRemoveDiscountInteractor
+	BulkActivateProductsInteractor  *bulkactivateproducts.BulkActivateProductsInteractor
+	ApplyDiscountScheduleInteractor *applydiscountschedule.ApplyDiscountScheduleInteractor
+	GetProductQuery                 *getproduct.GetProductQuery
+	ListProductsQuery               *listproducts.ListProductsQuery
+	ListSubcategoriesQuery          *listsubcategories.ListSubcategoriesQuery
+	CheckExistenceQuery             *checkexistence.CheckExistenceQuery
+	DiscountPreviewQuery            *discountpreview.DiscountPreviewQuery
 }
 
 // ProductServiceServer implements productv1.ProductServiceServer.
@@ -50,9 +70,20 @@ func NewProductServiceServer(p Params) *ProductServiceServer {
 	return &ProductServiceServer{p: p}
 }
 
+// acceptCurrencyInterceptor passes a well-formed accept-currency metadata value
+// on to the read queries, mirroring the REST Accept-Currency header.
+func acceptCurrencyInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, "accept-currency"); len(values) > 0 {
+		if currency, ok := common.ParseCurrency(values[0]); ok {
+			ctx = common.WithCurrency(ctx, currency)
+		}
+	}
+	return handler(ctx, req)
+}
+
 // NewGRPCServer starts a gRPC server with FX lifecycle management.
 func NewGRPCServer(lc fx.Lifecycle, svc *ProductServiceServer, log *zap.Logger, addr string) *grpc.Server {
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(acceptCurrencyInterceptor))
 	productv1.RegisterProductServiceServer(srv, svc)
 	reflection.Register(srv)
 
@@ -80,25 +111,115 @@ func NewGRPCServer(lc fx.Lifecycle, svc *ProductServiceServer, log *zap.Logger,
 	return srv
 }
 
+// errorDomain is the ErrorInfo domain qualifying this service's error reasons.
+const errorDomain = "product-catalog-service"
+
 // domainErrToCode maps domain sentinel errors to gRPC status codes.
 func domainErrToCode(err error) codes.Code {
 	switch {
-	case errors.Is(err, domain.ErrProductNotFound):
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, domain.ErrProductNotFound),
+		errors.Is(err, domain.ErrCategoryNotFound):
 		return codes.NotFound
 	case errors.Is(err, domain.ErrProductNameRequired),
+		errors.Is(err, domain.ErrProductCategoryRequired),
+		errors.Is(err, domain.ErrProductFieldTooLong),
+		errors.Is(err, domain.ErrInvalidMediaURL),
+		errors.Is(err, domain.ErrTooManyMediaURLs),
+		errors.Is(err, domain.ErrNegativeStock),
+		errors.Is(err, domain.ErrStockOverflow),
+		errors.Is(err, domain.ErrInvalidAttributeKey),
+		errors.Is(err, domain.ErrInvalidAttributeValue),
+		errors.Is(err, domain.ErrTooManyAttributes),
+		errors.Is(err, domain.ErrPublishAtNotInFuture),
+		errors.Is(err, domain.ErrUnpublishBeforePublish),
+		errors.Is(err, domain.ErrDuplicateCategory),
+		errors.Is(err, domain.ErrTooManyCategories),
+		errors.Is(err, domain.ErrPrimaryCategoryRemoval),
+		errors.Is(err, domain.ErrInvalidQuantityTier),
+		errors.Is(err, domain.ErrDuplicateQuantityTier),
+		errors.Is(err, domain.ErrTooManyQuantityTiers),
+		errors.Is(err, domain.ErrInvalidDecimalAmount),
+		errors.Is(err, domain.ErrAmountTooPrecise),
+		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrNegativeAmount),
+		errors.Is(err, domain.ErrCategoryIDRequired),
 		errors.Is(err, domain.ErrProductBasePriceRequired),
 		errors.Is(err, domain.ErrDiscountInvalidPercentage),
 		errors.Is(err, domain.ErrDiscountInvalidPeriod),
+		errors.Is(err, domain.ErrDiscountInvalidDuration),
 		errors.Is(err, domain.ErrInvalidDiscountPeriod),
-		errors.Is(err, domain.ErrNoActiveDiscount):
+		errors.Is(err, domain.ErrDiscountBackdatedTooFar),
+		errors.Is(err, domain.ErrNoActiveDiscount),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrInvalidDiscountState),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidPagination),
+		errors.Is(err, domain.ErrInvalidSortOrder),
+		errors.Is(err, domain.ErrIncompleteAttribute),
+		errors.Is(err, domain.ErrNoFieldsToUpdate),
+		errors.Is(err, domain.ErrDuplicateIDInBatch):
 		return codes.InvalidArgument
-	case errors.Is(err, domain.ErrProductNotActive):
+	case errors.Is(err, domain.ErrProductNotActive),
+		errors.Is(err, domain.ErrProductArchived),
+		errors.Is(err, domain.ErrScheduledDiscountPending),
+		errors.Is(err, domain.ErrDiscountOverlap),
+		errors.Is(err, domain.ErrProductAlreadyActive),
+		errors.Is(err, domain.ErrCurrencyChangeNotAllowed):
 		return codes.FailedPrecondition
+	case errors.Is(err, domain.ErrSlugConflict):
+		return codes.AlreadyExists
 	default:
 		return codes.Internal
 	}
 }
 
-func toStatusErr(err error) error {
-	return status.Error(domainErrToCode(err), err.Error())
+// toStatusErr converts err to a gRPC status. Unmapped errors may carry storage
+// details, so clients only get a generic message and a RequestInfo detail whose
+// request_id matches the server-side log entry. Domain errors carry their
+// reason in an ErrorInfo detail and a message in the language asked for by the
+// accept-language metadata.
+func (s *ProductServiceServer) toStatusErr(ctx context.Context, err error) error {
+	code := domainErrToCode(err)
+	if code == codes.Internal {
+		id := uuid.NewString()
+		s.p.Log.Error("internal error", zap.String("correlation_id", id), zap.Error(err))
+		st := status.New(codes.Internal, "internal error")
+		if withDetails, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id}); detailErr == nil {
+			st = withDetails
+		}
+		return st.Err()
+	}
+
+	locale := language.English
+	if values := metadata.ValueFromIncomingContext(ctx, "accept-language"); len(values) > 0 {
+		locale = errcatalog.Negotiate(values[0])
+	}
+	st := status.New(code, errcatalog.Message(err, locale))
+	if reason := errcatalog.Reason(err); reason != "" {
+		if withDetails, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); detailErr == nil {
+			st = withDetails
+		}
+	}
+
+	// Validation failures list every invalid field as BadRequest details.
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		br := &errdetails.BadRequest{}
+		for _, f := range verr.Fields {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: errcatalog.Message(f.Err, locale),
+				Reason:      errcatalog.Reason(f.Err),
+			})
+		}
+		if withDetails, detailErr := st.WithDetails(br); detailErr == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
 }
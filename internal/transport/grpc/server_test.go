@@ -0,0 +1,137 @@
+package grpctransport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
+	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+)
+
+func TestDomainErrToCode_ContextErrors(t *testing.T) {
+	if got := domainErrToCode(fmt.Errorf("get product: %w", context.Canceled)); got != codes.Canceled {
+		t.Fatalf("expected Canceled, got %v", got)
+	}
+	if got := domainErrToCode(fmt.Errorf("commit: %w", context.DeadlineExceeded)); got != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", got)
+	}
+}
+
+func TestToStatusErr_HidesInternalDetails(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	s := NewProductServiceServer(Params{Log: zap.New(core)})
+	raw := errors.New(`spanner: code = "InvalidArgument", desc = "Syntax error at [1:8]: SELECT product_id FROM products"`)
+
+	st := status.Convert(s.toStatusErr(context.Background(), fmt.Errorf("GetByID: %w", raw)))
+
+	if st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Fatalf("expected a generic Internal status, got %v: %q", st.Code(), st.Message())
+	}
+	var id string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RequestInfo); ok {
+			id = info.RequestId
+		}
+	}
+	if id == "" {
+		t.Fatal("expected a RequestInfo detail carrying the correlation id")
+	}
+
+	entries := logs.FilterField(zap.String("correlation_id", id)).All()
+	if len(entries) != 1 || !strings.Contains(entries[0].ContextMap()["error"].(string), "Syntax error") {
+		t.Fatalf("expected the raw error logged under the correlation id, got %v", logs.All())
+	}
+}
+
+func TestToStatusErr_LocalizesMessage(t *testing.T) {
+	s := NewProductServiceServer(Params{Log: zap.NewNop()})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "vi-VN,vi;q=0.9"))
+
+	st := status.Convert(s.toStatusErr(ctx, fmt.Errorf("GetByID: %w", domain.ErrProductNotFound)))
+
+	if st.Code() != codes.NotFound || st.Message() != "không tìm thấy sản phẩm" {
+		t.Fatalf("expected a Vietnamese NotFound, got %v: %q", st.Code(), st.Message())
+	}
+	var reason string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			reason = info.Reason
+		}
+	}
+	if reason != "PRODUCT_NOT_FOUND" {
+		t.Fatalf("expected an ErrorInfo with reason PRODUCT_NOT_FOUND, got %q", reason)
+	}
+}
+
+type fixedTicker struct{}
+
+func (fixedTicker) Now() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+func TestListProducts_RejectsNegativePagination(t *testing.T) {
+	// The query must reject the request before touching its (nil) repositories.
+	s := NewProductServiceServer(Params{
+		Log:               zap.NewNop(),
+		ListProductsQuery: listproducts.NewListProductsQuery(nil, nil, nil, fixedTicker{}, listproducts.DefaultConfig()),
+	})
+
+	for _, req := range []*productv1.ListProductsRequest{{Offset: -5}, {Limit: -1}} {
+		_, err := s.ListProducts(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("limit %d offset %d: expected InvalidArgument, got %v", req.Limit, req.Offset, err)
+		}
+	}
+}
+
+func TestCreateProduct_InvalidBasePriceIsInvalidArgument(t *testing.T) {
+	// The price is rejected before the interactor touches its (nil) repositories.
+	s := NewProductServiceServer(Params{
+		Log:                     zap.NewNop(),
+		CreateProductInteractor: createproduct.NewCreateProductInteractor(nil, nil, nil, fixedTicker{}, createproduct.DefaultConfig()),
+	})
+
+	for _, price := range []*productv1.Money{{Amount: -1, Currency: "USD"}, {Amount: 100, Currency: "dollars"}} {
+		_, err := s.CreateProduct(context.Background(), &productv1.CreateProductRequest{Name: "Phone", Category: "electronics", BasePrice: price})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%d %s: expected InvalidArgument, got %v", price.Amount, price.Currency, err)
+		}
+	}
+}
+
+func TestCreateProduct_MissingBasePriceIsInvalidArgument(t *testing.T) {
+	s := NewProductServiceServer(Params{
+		Log:                     zap.NewNop(),
+		CreateProductInteractor: createproduct.NewCreateProductInteractor(nil, nil, nil, fixedTicker{}, createproduct.DefaultConfig()),
+	})
+
+	_, err := s.CreateProduct(context.Background(), &productv1.CreateProductRequest{Name: "Phone", Category: "electronics"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestUpdateProduct_InvalidBasePriceIsInvalidArgument(t *testing.T) {
+	// The price is rejected before the interactor touches its (nil) repositories.
+	s := NewProductServiceServer(Params{
+		Log:                     zap.NewNop(),
+		UpdateProductInteractor: updateproduct.NewUpdateProductInteractor(nil, nil, nil, fixedTicker{}),
+	})
+
+	_, err := s.UpdateProduct(context.Background(), &productv1.UpdateProductRequest{Id: "p1", BasePrice: &productv1.Money{Amount: -1, Currency: "USD"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
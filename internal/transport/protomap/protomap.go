@@ -0,0 +1,91 @@
+// Package protomap maps query results to the productv1 messages. The gRPC
+// server and the proto-shaped REST endpoints share it, so both transports
+// expose the same fields.
+package protomap
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
+	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+)
+
+// Product maps a GetProduct result to its proto message.
+func Product(dto *getproduct.ProductDTO) *productv1.Product {
+	p := &productv1.Product{
+		Id:                dto.ID,
+		Name:              dto.Name,
+		Description:       dto.Description,
+		Category:          dto.Category,
+		Status:            dto.Status,
+		BasePrice:         Money(dto.BasePrice.Amount, dto.BasePrice.Currency),
+		EffectivePrice:    Money(dto.EffectivePrice.Amount, dto.EffectivePrice.Currency),
+		Featured:          dto.Featured,
+		ConvertedCurrency: dto.ConvertedCurrency,
+		MediaUrls:         dto.MediaURLs,
+		Slug:              dto.Slug,
+		StockQuantity:     dto.StockQuantity,
+		Categories:        dto.Categories,
+		Attributes:        dto.Attributes,
+		RoundingDelta:     dto.RoundingDelta,
+	}
+	for _, t := range dto.QuantityTiers {
+		p.QuantityTiers = append(p.QuantityTiers, &productv1.QuantityTier{MinQuantity: t.MinQuantity, Percentage: t.Percentage})
+	}
+	if dto.Discount != nil {
+		p.Discount = &productv1.Discount{
+			AmountPercentage: dto.Discount.Percentage,
+			StartsAt:         timestamppb.New(dto.Discount.StartsAt),
+			EndsAt:           timestamppb.New(dto.Discount.EndsAt),
+			IsActive:         dto.Discount.IsActive,
+			State:            dto.Discount.State,
+			Progress:         dto.Discount.Progress,
+		}
+	}
+	if dto.PreviewAt != nil {
+		p.PreviewAt = timestamppb.New(*dto.PreviewAt)
+	}
+	if dto.PublishAt != nil {
+		p.PublishAt = timestamppb.New(*dto.PublishAt)
+	}
+	if dto.UnpublishAt != nil {
+		p.UnpublishAt = timestamppb.New(*dto.UnpublishAt)
+	}
+	return p
+}
+
+// ProductSummary maps a ListProducts item to a proto Product. It fails when either price lacks a 3-letter currency, so a
+// pricing bug surfaces as an error instead of Money clients cannot interpret.
+func ProductSummary(dto *listproducts.ProductSummaryDTO) (*productv1.Product, error) {
+	if len(dto.BasePrice.Currency) != 3 || len(dto.EffectivePrice.Currency) != 3 {
+		return nil, fmt.Errorf("product %s: price currency must be a 3-letter code, got base=%q effective=%q",
+			dto.ID, dto.BasePrice.Currency, dto.EffectivePrice.Currency)
+	}
+
+	p := &productv1.Product{
+		Id:                dto.ID,
+		Name:              dto.Name,
+		Category:          dto.Category,
+		Status:            dto.Status,
+		BasePrice:         Money(dto.BasePrice.Amount, dto.BasePrice.Currency),
+		EffectivePrice:    Money(dto.EffectivePrice.Amount, dto.EffectivePrice.Currency),
+		Featured:          dto.Featured,
+		ConvertedCurrency: dto.ConvertedCurrency,
+		StockQuantity:     dto.StockQuantity,
+	}
+	if dto.DiscountEndsAt != nil {
+		p.Discount = &productv1.Discount{
+			IsActive: dto.IsDiscounted,
+			EndsAt:   timestamppb.New(*dto.DiscountEndsAt),
+		}
+	}
+	return p, nil
+}
+
+// Money maps an amount in minor units to its proto message.
+func Money(amount int64, currency string) *productv1.Money {
+	return &productv1.Money{Amount: amount, Currency: currency}
+}
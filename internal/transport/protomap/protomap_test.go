@@ -0,0 +1,131 @@
+package protomap
+
+import (
+	"testing"
+	"time"
+
+	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
+	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+)
+
+var (
+	mapStartsAt = time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	mapEndsAt   = time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+)
+
+func TestProduct_WithDiscount(t *testing.T) {
+	dto := &getproduct.ProductDTO{
+		ID:             "p-1",
+		Name:           "Laptop",
+		Description:    "High-end laptop",
+		Category:       "electronics",
+		Status:         "active",
+		BasePrice:      getproduct.MoneyDTO{Amount: 1000, Currency: "USD"},
+		EffectivePrice: getproduct.MoneyDTO{Amount: 800, Currency: "USD"},
+		Discount: &getproduct.DiscountDTO{
+			Percentage: "20",
+			StartsAt:   mapStartsAt,
+			EndsAt:     mapEndsAt,
+			IsActive:   true,
+		},
+	}
+
+	p := Product(dto)
+
+	if p.Id != dto.ID || p.Name != dto.Name || p.Description != dto.Description ||
+		p.Category != dto.Category || p.Status != dto.Status {
+		t.Fatalf("scalar fields not mapped: %+v", p)
+	}
+	if p.BasePrice.Amount != 1000 || p.BasePrice.Currency != "USD" {
+		t.Fatalf("unexpected base price %+v", p.BasePrice)
+	}
+	if p.EffectivePrice.Amount != 800 || p.EffectivePrice.Currency != "USD" {
+		t.Fatalf("unexpected effective price %+v", p.EffectivePrice)
+	}
+	if p.Discount == nil {
+		t.Fatal("expected discount to be mapped")
+	}
+	if p.Discount.AmountPercentage != "20" || !p.Discount.IsActive {
+		t.Fatalf("unexpected discount %+v", p.Discount)
+	}
+	if !p.Discount.StartsAt.AsTime().Equal(mapStartsAt) || !p.Discount.EndsAt.AsTime().Equal(mapEndsAt) {
+		t.Fatalf("unexpected discount period %v - %v", p.Discount.StartsAt.AsTime(), p.Discount.EndsAt.AsTime())
+	}
+}
+
+func TestProduct_NilDiscount(t *testing.T) {
+	dto := &getproduct.ProductDTO{
+		ID:             "p-1",
+		Name:           "Laptop",
+		BasePrice:      getproduct.MoneyDTO{Amount: 1000, Currency: "USD"},
+		EffectivePrice: getproduct.MoneyDTO{Amount: 1000, Currency: "USD"},
+	}
+
+	if p := Product(dto); p.Discount != nil {
+		t.Fatalf("expected nil discount, got %+v", p.Discount)
+	}
+}
+
+func TestProductSummary_WithDiscount(t *testing.T) {
+	endsAt := mapEndsAt
+	dto := &listproducts.ProductSummaryDTO{
+		ID:             "p-2",
+		Name:           "Mouse",
+		Category:       "electronics",
+		Status:         "active",
+		BasePrice:      listproducts.MoneyDTO{Amount: 500, Currency: "USD"},
+		EffectivePrice: listproducts.MoneyDTO{Amount: 450, Currency: "USD"},
+		IsDiscounted:   true,
+		DiscountEndsAt: &endsAt,
+	}
+
+	p, err := ProductSummary(dto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Id != dto.ID || p.Name != dto.Name || p.Category != dto.Category || p.Status != dto.Status {
+		t.Fatalf("scalar fields not mapped: %+v", p)
+	}
+	if p.Description != "" {
+		t.Fatalf("summary must not carry a description, got %q", p.Description)
+	}
+	if p.BasePrice.Amount != 500 || p.EffectivePrice.Amount != 450 {
+		t.Fatalf("unexpected prices base=%+v effective=%+v", p.BasePrice, p.EffectivePrice)
+	}
+	if p.Discount == nil || !p.Discount.IsActive || !p.Discount.EndsAt.AsTime().Equal(mapEndsAt) {
+		t.Fatalf("unexpected discount %+v", p.Discount)
+	}
+}
+
+func TestProductSummary_NilDiscount(t *testing.T) {
+	dto := &listproducts.ProductSummaryDTO{
+		ID:             "p-2",
+		BasePrice:      listproducts.MoneyDTO{Amount: 500, Currency: "USD"},
+		EffectivePrice: listproducts.MoneyDTO{Amount: 500, Currency: "USD"},
+	}
+
+	if p, _ := ProductSummary(dto); p.Discount != nil {
+		t.Fatalf("expected nil discount, got %+v", p.Discount)
+	}
+}
+
+func TestProductSummary_RejectsMissingCurrency(t *testing.T) {
+	dto := &listproducts.ProductSummaryDTO{
+		ID:             "p-2",
+		BasePrice:      listproducts.MoneyDTO{Amount: 500, Currency: "USD"},
+		EffectivePrice: listproducts.MoneyDTO{},
+	}
+
+	if p, err := ProductSummary(dto); err == nil {
+		t.Fatalf("expected an error for an empty currency, got %+v", p)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	m := Money(1234, "VND")
+
+	if m.Amount != 1234 || m.Currency != "VND" {
+		t.Fatalf("unexpected money %+v", m)
+	}
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	effectivepricebackfill "github.com/product-catalog-service/internal/app/product/queries/effective_price_backfill"
+	forcecleardiscount "github.com/product-catalog-service/internal/app/product/usecases/force_clear_discount"
+	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+)
+
+// AdminConfig guards the /admin routes. With no Token configured every admin
+// request is refused, so the endpoints are never served open.
+type AdminConfig struct {
+	// Token is the shared secret callers send as "Authorization: Bearer <token>".
+	Token string
+}
+
+// requireAdmin rejects requests that do not carry the configured admin token.
+func (s *Server) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.p.Admin.Token == "" {
+			writeError(w, http.StatusForbidden, "admin endpoints are disabled")
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.p.Admin.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing or invalid admin token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ── Outbox status ─────────────────────────────────────────────────────────────
+
+type outboxStatusResponse struct {
+	Pending          int64  `json:"pending"`
+	Processed        int64  `json:"processed"`
+	Dead             int64  `json:"dead"`
+	OldestPendingAge string `json:"oldest_pending_age"`
+}
+
+func (s *Server) handleOutboxStatus(w http.ResponseWriter, r *http.Request) {
+	dto, err := s.p.OutboxStatusQuery.Execute(r.Context())
+	if err != nil {
+		s.p.Log.Sugar().Errorw("outboxStatus", "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, outboxStatusResponse{
+		Pending:          dto.Pending,
+		Processed:        dto.Processed,
+		Dead:             dto.Dead,
+		OldestPendingAge: dto.OldestPendingAge.String(),
+	})
+}
+
+// ── Effective price backfill ──────────────────────────────────────────────────
+
+type effectivePriceBackfillResponse struct {
+	Scanned    int    `json:"scanned"`
+	Differing  int    `json:"differing"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+// handleEffectivePriceBackfill processes one batch; callers repeat with
+// ?cursor=<next_cursor> until it comes back empty.
+func (s *Server) handleEffectivePriceBackfill(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	resp, err := s.p.EffectivePriceBackfillQuery.Execute(r.Context(), &effectivepricebackfill.BackfillRequest{
+		Cursor: q.Get("cursor"),
+		Limit:  parseIntParam(q.Get("limit"), 0),
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("effectivePriceBackfill", "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, effectivePriceBackfillResponse{
+		Scanned:    resp.Scanned,
+		Differing:  resp.Differing,
+		NextCursor: resp.NextCursor,
+		DryRun:     resp.DryRun,
+	})
+}
+
+// ── Force-clear discount ──────────────────────────────────────────────────────
+
+// handleForceClearDiscount nulls a product's discount columns even when the
+// stored discount is too malformed for DELETE /products/{id}/discount to load.
+func (s *Server) handleForceClearDiscount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := s.p.ForceClearDiscountInteractor.Execute(r.Context(), &forcecleardiscount.ForceClearDiscountRequest{ProductID: id})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("forceClearDiscount", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	s.p.Log.Sugar().Warnw("forceClearDiscount: discount cleared", "id", id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ── Correct price ─────────────────────────────────────────────────────────────
+
+type correctPriceBody struct {
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+}
+
+// handleCorrectPrice sets a product's base price even when that changes its
+// currency, which PUT /products/{id} refuses, to fix a product created in
+// the wrong currency.
+func (s *Server) handleCorrectPrice(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var body correctPriceBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.Price == "" {
+		writeError(w, http.StatusBadRequest, "price is required")
+		return
+	}
+
+	_, err := s.p.UpdateProductInteractor.Execute(r.Context(), &updateproduct.UpdateProductRequest{
+		ProductID:           id,
+		BasePrice:           &updateproduct.PriceUpdate{Price: body.Price, Currency: body.Currency},
+		AllowCurrencyChange: true,
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("correctPrice", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	s.p.Log.Sugar().Warnw("correctPrice: base price corrected", "id", id, "price", body.Price, "currency", body.Currency)
+	w.WriteHeader(http.StatusNoContent)
+}
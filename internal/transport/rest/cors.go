@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// CORSConfig is the cross-origin allow-list applied to every REST route.
+// With no AllowedOrigins, cross-origin requests get no CORS headers and
+// browsers fall back to same-origin only.
+type CORSConfig struct {
+	AllowedOrigins []string // exact origins, or "*" for any
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// DefaultCORSConfig denies all cross-origin requests.
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders: []string{"Content-Type", "Accept-Currency", "Prefer"},
+	}
+}
+
+func (c CORSConfig) allowsOrigin(origin string) bool {
+	return slices.Contains(c.AllowedOrigins, "*") || slices.Contains(c.AllowedOrigins, origin)
+}
+
+// WithCORS wraps next with cfg's CORS policy. Preflight requests are answered
+// directly: 204 for an allowed origin, 403 otherwise.
+func WithCORS(cfg CORSConfig, next http.Handler) http.Handler {
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		allowed := cfg.allowsOrigin(origin)
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			if !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,20 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/product-catalog-service/common"
+)
+
+// WithAcceptCurrency passes a well-formed Accept-Currency header (e.g. "VND")
+// on to the read queries through the request context. A missing or malformed
+// header leaves prices in their stored currency.
+func WithAcceptCurrency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Currency")
+		if currency, ok := common.ParseCurrency(r.Header.Get("Accept-Currency")); ok {
+			r = r.WithContext(common.WithCurrency(r.Context(), currency))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -2,7 +2,16 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/transport/errcatalog"
 )
 
 // writeJSON encodes v as JSON and writes it with the given HTTP status code.
@@ -12,11 +21,70 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// protoJSON renders proto messages with their proto field names and every
+// field present, so proto-shaped responses keep the same keys whatever is set.
+var protoJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// writeProtoJSON encodes m in the proto JSON mapping, as the gRPC gateway
+// convention expects: snake_case keys, int64 as strings, RFC 3339 timestamps.
+func writeProtoJSON(w http.ResponseWriter, status int, m proto.Message) {
+	b, err := protoJSON.Marshal(m)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
 // writeError writes a JSON error response.
 func writeError(w http.ResponseWriter, status int, msg string) {
 	writeJSON(w, status, map[string]string{"error": msg})
 }
 
+// fieldErrorBody is one entry of a validation error response.
+type fieldErrorBody struct {
+	Field  string `json:"field"`
+	Error  string `json:"error"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// writeDomainError writes err with its mapped status. Validation errors are
+// returned as 422 with every invalid field listed under "fields". Unmapped
+// errors may carry storage details, so clients only get a generic message and
+// a correlation ID that matches the server-side log entry. Domain errors also
+// carry their reason code, and messages follow r's Accept-Language.
+func (s *Server) writeDomainError(w http.ResponseWriter, r *http.Request, err error) {
+	status := domainErrToStatus(err)
+	if status == http.StatusInternalServerError {
+		id := uuid.NewString()
+		s.log.Error("internal error", zap.String("correlation_id", id), zap.Error(err))
+		writeJSON(w, status, map[string]string{"error": "internal error", "correlation_id": id})
+		return
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	locale := errcatalog.Negotiate(r.Header.Get("Accept-Language"))
+	body := map[string]any{"error": errcatalog.Message(err, locale)}
+	if reason := errcatalog.Reason(err); reason != "" {
+		body["reason"] = reason
+	}
+
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) {
+		writeJSON(w, status, body)
+		return
+	}
+
+	fields := make([]fieldErrorBody, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		fields = append(fields, fieldErrorBody{Field: f.Field, Error: errcatalog.Message(f.Err, locale), Reason: errcatalog.Reason(f.Err)})
+	}
+	body["fields"] = fields
+	writeJSON(w, http.StatusUnprocessableEntity, body)
+}
+
 // errorStatus maps common domain / sentinel errors to HTTP status codes.
 // Returns 500 for unknown errors.
 func errorStatus(err error) int {
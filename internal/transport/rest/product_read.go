@@ -1,11 +1,17 @@
 package rest
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
+	pricepreview "github.com/product-catalog-service/internal/app/product/queries/price_preview"
+	"github.com/product-catalog-service/internal/transport/protomap"
 )
 
 // ── Get by ID ─────────────────────────────────────────────────────────────────
@@ -14,11 +20,53 @@ func (s *Server) handleGetProduct(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
 	dto, err := s.p.GetProductQuery.Execute(r.Context(), &getproduct.GetProductRequest{
-		ProductID: id,
+		ProductID:             id,
+		IncludeDiscountStates: r.URL.Query()["include_discount_states"],
+		PreviewAsScheduled:    r.URL.Query().Get("preview_as_scheduled") == "true",
 	})
 	if err != nil {
 		s.p.Log.Sugar().Errorw("getProduct", "id", id, "error", err)
-		writeError(w, domainErrToStatus(err), err.Error())
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto)
+}
+
+// ── Get by ID, proto shape ────────────────────────────────────────────────────
+
+// handleGetProductV1 serves the product as the gRPC GetProduct does, through
+// the same mapping, for clients that move between the two transports.
+func (s *Server) handleGetProductV1(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	dto, err := s.p.GetProductQuery.Execute(r.Context(), &getproduct.GetProductRequest{
+		ProductID:             id,
+		IncludeDiscountStates: r.URL.Query()["include_discount_states"],
+		PreviewAsScheduled:    r.URL.Query().Get("preview_as_scheduled") == "true",
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("getProductV1", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeProtoJSON(w, http.StatusOK, protomap.Product(dto))
+}
+
+// ── Get by slug ───────────────────────────────────────────────────────────────
+
+func (s *Server) handleGetProductBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+
+	dto, err := s.p.GetProductQuery.ExecuteBySlug(r.Context(), &getproduct.GetProductBySlugRequest{
+		Slug:                  slug,
+		IncludeDiscountStates: r.URL.Query()["include_discount_states"],
+		PreviewAsScheduled:    r.URL.Query().Get("preview_as_scheduled") == "true",
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("getProductBySlug", "slug", slug, "error", err)
+		s.writeDomainError(w, r, err)
 		return
 	}
 
@@ -30,19 +78,197 @@ func (s *Server) handleGetProduct(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListProducts(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
+	limit, err := parsePageParam(q.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit: expected an integer")
+		return
+	}
+	offset, err := parsePageParam(q.Get("offset"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid offset: expected an integer")
+		return
+	}
+
 	req := &listproducts.ListProductsRequest{
-		Limit:  parseIntParam(q.Get("limit"), 20),
-		Offset: parseIntParam(q.Get("offset"), 0),
+		Statuses:             q["status"],
+		Limit:                limit,
+		Offset:               offset,
+		Cursor:               q.Get("cursor"),
+		IncludeTotal:         q.Get("include_total") == "true",
+		RefreshTotal:         q.Get("refresh_total") == "true",
+		IncludeSubcategories: q.Get("include_subcategories") == "true",
+		SortBy:               q.Get("sort_by"),
+		InStockOnly:          q.Get("in_stock_only") == "true",
+		AttributeKey:         q.Get("attribute_key"),
+		AttributeValue:       q.Get("attribute_value"),
+		OnSale:               q.Get("on_sale") == "true",
 	}
 
 	if cat := q.Get("category"); cat != "" {
 		req.Category = &cat
 	}
+	if v := q.Get("featured"); v != "" {
+		featured, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid featured: expected true or false")
+			return
+		}
+		req.Featured = &featured
+	}
+
+	createdAfter, err := parseTimeParam(q.Get("created_after"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid created_after: expected RFC3339 timestamp")
+		return
+	}
+	req.CreatedAfter = createdAfter
+
+	createdBefore, err := parseTimeParam(q.Get("created_before"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid created_before: expected RFC3339 timestamp")
+		return
+	}
+	req.CreatedBefore = createdBefore
 
 	resp, err := s.p.ListProductsQuery.Execute(r.Context(), req)
 	if err != nil {
 		s.p.Log.Sugar().Errorw("listProducts", "error", err)
-		writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ── Category descendants ──────────────────────────────────────────────────────
+
+func (s *Server) handleListSubcategories(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	resp, err := s.p.ListSubcategoriesQuery.Execute(r.Context(), &listsubcategories.ListSubcategoriesRequest{
+		CategoryID: id,
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("listSubcategories", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ── Batch get ─────────────────────────────────────────────────────────────────
+
+type batchGetProductsBody struct {
+	IDs                   []string `json:"ids"`
+	IncludeDiscountStates []string `json:"include_discount_states"`
+	// OrderPreserving answers with "results" aligned to ids, null where an id
+	// is missing or archived, instead of the "products" map.
+	OrderPreserving bool `json:"order_preserving"`
+}
+
+// batchGetProductsResponse lists the products found by id, and which of the
+// requested ids were missing or archived (not_found) or malformed (invalid).
+type batchGetProductsResponse struct {
+	Products map[string]*getproduct.ProductDTO `json:"products"`
+	NotFound []string                          `json:"not_found"`
+	Invalid  []string                          `json:"invalid"`
+}
+
+func (s *Server) handleBatchGetProducts(w http.ResponseWriter, r *http.Request) {
+	var body batchGetProductsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	req := &getproduct.BatchGetProductsRequest{
+		ProductIDs:            body.IDs,
+		IncludeDiscountStates: body.IncludeDiscountStates,
+	}
+	if body.OrderPreserving {
+		results, err := s.p.GetProductQuery.ExecuteBatchOrdered(r.Context(), req)
+		if err != nil {
+			s.p.Log.Sugar().Errorw("batchGetProducts", "count", len(body.IDs), "error", err)
+			s.writeDomainError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string][]*getproduct.ProductDTO{"results": results})
+		return
+	}
+
+	res, err := s.p.GetProductQuery.ExecuteBatchPartial(r.Context(), req)
+	if err != nil {
+		s.p.Log.Sugar().Errorw("batchGetProducts", "count", len(body.IDs), "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, batchGetProductsResponse{
+		Products: res.Found,
+		NotFound: res.NotFound,
+		Invalid:  res.Invalid,
+	})
+}
+
+// ── Product existence ─────────────────────────────────────────────────────────
+
+type checkExistenceBody struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+func (s *Server) handleCheckExistence(w http.ResponseWriter, r *http.Request) {
+	var body checkExistenceBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := s.p.CheckExistenceQuery.Execute(r.Context(), &checkexistence.CheckExistenceRequest{
+		ProductIDs: body.ProductIDs,
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("checkExistence", "count", len(body.ProductIDs), "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]map[string]bool{"exists": resp.Exists})
+}
+
+// ── Price preview ─────────────────────────────────────────────────────────────
+
+type pricePreviewBody struct {
+	ProductID string `json:"product_id"`
+	Quantity  int64  `json:"quantity"`
+}
+
+func (s *Server) handlePricePreview(w http.ResponseWriter, r *http.Request) {
+	var body pricePreviewBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	dto, err := s.p.PricePreviewQuery.Execute(r.Context(), &pricepreview.PricePreviewRequest{
+		ProductID: body.ProductID,
+		Quantity:  body.Quantity,
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("pricePreview", "id", body.ProductID, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto)
+}
+
+// ── Price statistics ──────────────────────────────────────────────────────────
+
+func (s *Server) handlePriceStats(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.p.PriceStatsQuery.Execute(r.Context())
+	if err != nil {
+		s.p.Log.Sugar().Errorw("priceStats", "error", err)
+		s.writeDomainError(w, r, err)
 		return
 	}
 
@@ -59,3 +285,25 @@ func parseIntParam(s string, defaultVal int) int {
 	}
 	return v
 }
+
+// parsePageParam parses an optional integer paging parameter. Negative values
+// are passed through so the query can reject them with ErrInvalidPagination.
+func parsePageParam(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// parseTimeParam parses an optional RFC3339 query parameter.
+// Returns nil when the parameter is absent.
+func parseTimeParam(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	productv1 "github.com/product-catalog-service/gen/product/v1"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
+)
+
+// singleProductQueryRepo serves one product; the list methods are unused here.
+type singleProductQueryRepo struct {
+	contract.QueryRepository
+	p *domain.Product
+}
+
+func (r singleProductQueryRepo) GetByID(_ context.Context, id string) (*domain.Product, error) {
+	if r.p.ID() != id {
+		return nil, domain.ErrProductNotFound
+	}
+	return r.p, nil
+}
+
+func protoFieldNames(d protoreflect.MessageDescriptor) []string {
+	var names []string
+	fields := d.Fields()
+	for i := range fields.Len() {
+		names = append(names, string(fields.Get(i).Name()))
+	}
+	slices.Sort(names)
+	return names
+}
+
+func sortedKeys(m map[string]any) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestHandleGetProductV1_MatchesProtoFieldNames(t *testing.T) {
+	d, err := domain.NewDiscount("10", testNow.Add(-time.Hour), testNow.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-1",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(1000, "USD"),
+		Discount:  d,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	srv := NewServer(Params{
+		Log: zap.NewNop(),
+		// The audit fills rounding_delta, so every proto field is present.
+		GetProductQuery: getproduct.NewGetProductQuery(singleProductQueryRepo{p: p},
+			services.NewPricingCalculator(), fixedTicker{}, getproduct.WithRoundingAudit()),
+	})
+
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/products/p-1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := protoFieldNames((&productv1.Product{}).ProtoReflect().Descriptor())
+	if got := sortedKeys(body); !slices.Equal(got, want) {
+		t.Fatalf("product keys drifted from proto:\n got  %v\n want %v", got, want)
+	}
+	discount, ok := body["discount"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a discount object, got %v", body["discount"])
+	}
+	want = protoFieldNames((&productv1.Discount{}).ProtoReflect().Descriptor())
+	if got := sortedKeys(discount); !slices.Equal(got, want) {
+		t.Fatalf("discount keys drifted from proto:\n got  %v\n want %v", got, want)
+	}
+	if discount["is_active"] != true {
+		t.Fatalf("expected is_active=true, got %v", discount["is_active"])
+	}
+}
+
+func TestHandleGetProductV1_NotFound(t *testing.T) {
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-1",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(1000, "USD"),
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	srv := NewServer(Params{
+		Log: zap.NewNop(),
+		GetProductQuery: getproduct.NewGetProductQuery(singleProductQueryRepo{p: p},
+			services.NewPricingCalculator(), fixedTicker{}),
+	})
+
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/products/missing", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -2,21 +2,72 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/product-catalog-service/internal/app/product/domain"
+	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
+	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
+	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
+	renamecategory "github.com/product-catalog-service/internal/app/product/usecases/rename_category"
 	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
 )
 
+// ── Write responses ───────────────────────────────────────────────────────────
+
+// prefersRepresentation reports whether the client sent
+// "Prefer: return=representation" (RFC 7240) to get the written product back.
+func prefersRepresentation(r *http.Request) bool {
+	for _, v := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(v, ",") {
+			pref, _, _ = strings.Cut(pref, ";")
+			if strings.EqualFold(strings.TrimSpace(pref), "return=representation") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeProductWritten answers a successful write to product id with 204, or,
+// when the client prefers a representation, 200 and the product as
+// GET /products/{id} serves it. The write is already committed, so a failed
+// read falls back to 204 rather than reporting an error.
+func (s *Server) writeProductWritten(w http.ResponseWriter, r *http.Request, id string) {
+	if !prefersRepresentation(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	dto, err := s.p.GetProductQuery.Execute(r.Context(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		s.p.Log.Sugar().Warnw("writeProductWritten: representation unavailable", "id", id, "error", err)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Preference-Applied", "return=representation")
+	writeJSON(w, http.StatusOK, dto)
+}
+
 // ── Create ────────────────────────────────────────────────────────────────────
 
 type createProductBody struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Category    string `json:"category"`
+	Price       string `json:"price"`    // optional decimal, e.g. "19.99"
+	Currency    string `json:"currency"` // defaults to the category's currency
+	// PublishAt stages the product inactive until this time, when it goes live.
+	PublishAt *time.Time `json:"publish_at"`
+	// UnpublishAt takes the product down at this time; it must be after publish_at.
+	UnpublishAt *time.Time `json:"unpublish_at"`
 }
 
 func (s *Server) handleCreateProduct(w http.ResponseWriter, r *http.Request) {
@@ -30,10 +81,14 @@ func (s *Server) handleCreateProduct(w http.ResponseWriter, r *http.Request) {
 		Name:        body.Name,
 		Description: body.Description,
 		Category:    body.Category,
+		Price:       body.Price,
+		Currency:    body.Currency,
+		PublishAt:   body.PublishAt,
+		UnpublishAt: body.UnpublishAt,
 	})
 	if err != nil {
 		s.p.Log.Sugar().Errorw("createProduct", "error", err)
-		writeError(w, domainErrToStatus(err), err.Error())
+		s.writeDomainError(w, r, err)
 		return
 	}
 
@@ -43,9 +98,42 @@ func (s *Server) handleCreateProduct(w http.ResponseWriter, r *http.Request) {
 // ── Update ────────────────────────────────────────────────────────────────────
 
 type updateProductBody struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Category    *string `json:"category"`
+	Name        *string             `json:"name"`
+	Description *string             `json:"description"`
+	Category    *string             `json:"category"`
+	Price       *string             `json:"price"`    // optional decimal, e.g. "19.99"
+	Currency    string              `json:"currency"` // defaults to the product's currency
+	Discount    *updateDiscountBody `json:"discount"`
+	Featured    *bool               `json:"featured"`
+	MediaURLs   *[]string           `json:"media_urls"` // replaces the list; [] removes all media
+	Stock       *int64              `json:"stock_quantity"`
+	// QuantityTiers replaces the volume discounts; [] removes them all.
+	QuantityTiers    *[]quantityTierBody `json:"quantity_tiers"`
+	AddCategories    []string            `json:"add_categories"`    // additional categories to list the product in
+	RemoveCategories []string            `json:"remove_categories"` // additional categories to unlist it from
+	SetAttributes    map[string]string   `json:"set_attributes"`    // attributes to set, by key
+	RemoveAttributes []string            `json:"remove_attributes"` // attribute keys to delete; applied first
+	PublishAt        *scheduleUpdateBody `json:"publish_at"`
+	UnpublishAt      *scheduleUpdateBody `json:"unpublish_at"`
+}
+
+type quantityTierBody struct {
+	MinQuantity int64  `json:"min_quantity"`
+	Percentage  string `json:"percentage"`
+}
+
+type updateDiscountBody struct {
+	Clear      bool      `json:"clear"`
+	Percentage string    `json:"percentage"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+// scheduleUpdateBody schedules the launch or takedown at At, or cancels it
+// when Clear is set.
+type scheduleUpdateBody struct {
+	Clear bool      `json:"clear"`
+	At    time.Time `json:"at"`
 }
 
 func (s *Server) handleUpdateProduct(w http.ResponseWriter, r *http.Request) {
@@ -57,19 +145,59 @@ func (s *Server) handleUpdateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.p.UpdateProductInteractor.Execute(r.Context(), &updateproduct.UpdateProductRequest{
-		ProductID:   id,
-		Name:        body.Name,
-		Description: body.Description,
-		Category:    body.Category,
-	})
+	req := &updateproduct.UpdateProductRequest{
+		ProductID:        id,
+		Name:             body.Name,
+		Description:      body.Description,
+		Category:         body.Category,
+		Featured:         body.Featured,
+		MediaURLs:        body.MediaURLs,
+		Stock:            body.Stock,
+		AddCategories:    body.AddCategories,
+		RemoveCategories: body.RemoveCategories,
+		SetAttributes:    body.SetAttributes,
+		RemoveAttributes: body.RemoveAttributes,
+	}
+	if body.Price != nil && *body.Price != "" {
+		req.BasePrice = &updateproduct.PriceUpdate{Price: *body.Price, Currency: body.Currency}
+	}
+	if d := body.Discount; d != nil {
+		req.Discount = &updateproduct.DiscountUpdate{
+			Clear:      d.Clear,
+			Percentage: d.Percentage,
+			StartsAt:   d.StartsAt,
+			EndsAt:     d.EndsAt,
+		}
+	}
+	if body.QuantityTiers != nil {
+		tiers := make([]updateproduct.QuantityTierUpdate, 0, len(*body.QuantityTiers))
+		for _, t := range *body.QuantityTiers {
+			tiers = append(tiers, updateproduct.QuantityTierUpdate{MinQuantity: t.MinQuantity, Percentage: t.Percentage})
+		}
+		req.QuantityTiers = &tiers
+	}
+	if pa := body.PublishAt; pa != nil {
+		req.PublishAt = &updateproduct.ScheduleUpdate{Clear: pa.Clear, At: pa.At}
+	}
+	if ua := body.UnpublishAt; ua != nil {
+		req.UnpublishAt = &updateproduct.ScheduleUpdate{Clear: ua.Clear, At: ua.At}
+	}
+
+	res, err := s.p.UpdateProductInteractor.Execute(r.Context(), req)
 	if err != nil {
 		s.p.Log.Sugar().Errorw("updateProduct", "id", id, "error", err)
-		writeError(w, domainErrToStatus(err), err.Error())
+		s.writeDomainError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	// With a representation preferred, the product is returned even for a
+	// no-op. Otherwise 204 signals a no-op update and a change reports which
+	// fields changed.
+	if prefersRepresentation(r) || !res.Changed {
+		s.writeProductWritten(w, r, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]domain.Field{"changed_fields": res.ChangedFields})
 }
 
 // ── Activate ─────────────────────────────────────────────────────────────────
@@ -78,23 +206,79 @@ func (s *Server) handleActivateProduct(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
 	err := s.p.ActivateProductInteractor.Execute(r.Context(), &activateproduct.ActivateProductRequest{
-		ProductID: id,
+		ProductID:       id,
+		RestoreDiscount: r.URL.Query().Get("restore_discount") == "true",
 	})
 	if err != nil {
 		s.p.Log.Sugar().Errorw("activateProduct", "id", id, "error", err)
-		writeError(w, domainErrToStatus(err), err.Error())
+		s.writeDomainError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	s.writeProductWritten(w, r, id)
+}
+
+// ── Deactivate ───────────────────────────────────────────────────────────────
+
+// handleDeactivateProduct takes the product off sale; ?force=true drops a
+// scheduled discount that has not started instead of failing.
+func (s *Server) handleDeactivateProduct(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := s.p.DeactivateProductInteractor.Execute(r.Context(), &deactivateproduct.DeactivateProductRequest{
+		ProductID: id,
+		Force:     r.URL.Query().Get("force") == "true",
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("deactivateProduct", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	s.writeProductWritten(w, r, id)
 }
 
 // ── Apply Discount ────────────────────────────────────────────────────────────
 
+// applyDiscountBody takes exactly one of ends_at, duration (e.g. "72h") or
+// duration_seconds. With a duration, starts_at defaults to now.
 type applyDiscountBody struct {
-	Percentage string    `json:"percentage"`
-	StartsAt   time.Time `json:"starts_at"`
-	EndsAt     time.Time `json:"ends_at"`
+	Percentage      string    `json:"percentage"`
+	StartsAt        time.Time `json:"starts_at"`
+	EndsAt          time.Time `json:"ends_at"`
+	Duration        string    `json:"duration"`
+	DurationSeconds *int64    `json:"duration_seconds"`
+}
+
+// toRequest builds the apply-discount request for product id; on a malformed
+// body it returns the message to answer 400 with instead.
+func (body *applyDiscountBody) toRequest(id string) (*applydiscount.ApplyDiscountRequest, string) {
+	hasEnd := !body.EndsAt.IsZero()
+	if body.Duration != "" && body.DurationSeconds != nil {
+		return nil, "duration and duration_seconds are mutually exclusive"
+	}
+	if hasDuration := body.Duration != "" || body.DurationSeconds != nil; hasEnd == hasDuration {
+		return nil, "exactly one of ends_at or duration is required"
+	}
+
+	req := &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: body.Percentage,
+		StartsAt:   body.StartsAt,
+		EndsAt:     body.EndsAt,
+	}
+	switch {
+	case body.Duration != "":
+		d, err := time.ParseDuration(body.Duration)
+		if err != nil {
+			return nil, "invalid duration: expected a Go duration such as 72h"
+		}
+		req.Duration = &d
+	case body.DurationSeconds != nil:
+		d := time.Duration(*body.DurationSeconds) * time.Second
+		req.Duration = &d
+	}
+	return req, ""
 }
 
 func (s *Server) handleApplyDiscount(w http.ResponseWriter, r *http.Request) {
@@ -106,17 +290,232 @@ func (s *Server) handleApplyDiscount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := s.p.ApplyDiscountInteractor.Execute(r.Context(), &applydiscount.ApplyDiscountRequest{
+	req, msg := body.toRequest(id)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	err := s.p.ApplyDiscountInteractor.Execute(r.Context(), req)
+	if err != nil {
+		s.p.Log.Sugar().Errorw("applyDiscount", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	s.writeProductWritten(w, r, id)
+}
+
+// ── Validate Discount ─────────────────────────────────────────────────────────
+
+// handleValidateDiscount answers 200 whenever validation ran, listing every
+// rule the discount would break; nothing is persisted.
+func (s *Server) handleValidateDiscount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var body applyDiscountBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req, msg := body.toRequest(id)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	violations := []fieldErrorBody{}
+	err := s.p.ApplyDiscountInteractor.Validate(r.Context(), req)
+	var verr *domain.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		for _, f := range verr.Fields {
+			violations = append(violations, fieldErrorBody{Field: f.Field, Error: f.Err.Error()})
+		}
+	case err != nil:
+		s.p.Log.Sugar().Errorw("validateDiscount", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"valid":      len(violations) == 0,
+		"violations": violations,
+	})
+}
+
+// ── Remove Discount ───────────────────────────────────────────────────────────
+
+func (s *Server) handleRemoveDiscount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	err := s.p.RemoveDiscountInteractor.Execute(r.Context(), &removediscount.RemoveDiscountRequest{
 		ProductID:  id,
-		Percentage: body.Percentage,
-		StartsAt:   body.StartsAt,
-		EndsAt:     body.EndsAt,
+		Idempotent: r.URL.Query().Get("idempotent") == "true",
 	})
 	if err != nil {
-		s.p.Log.Sugar().Errorw("applyDiscount", "id", id, "error", err)
-		writeError(w, domainErrToStatus(err), err.Error())
+		s.p.Log.Sugar().Errorw("removeDiscount", "id", id, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	s.writeProductWritten(w, r, id)
+}
+
+// ── Bulk Remove Discount ──────────────────────────────────────────────────────
+
+type bulkRemoveDiscountBody struct {
+	Category string `json:"category"`
+}
+
+type bulkRemoveDiscountResponse struct {
+	RemovedCount int                         `json:"removed_count"`
+	SkippedCount int                         `json:"skipped_count"`
+	Products     []bulkRemoveDiscountOutcome `json:"products"` // every active product in the category, by id
+}
+
+type bulkRemoveDiscountOutcome struct {
+	ProductID string `json:"product_id"`
+	Outcome   string `json:"outcome"` // removed | skipped
+}
+
+func (s *Server) handleBulkRemoveDiscount(w http.ResponseWriter, r *http.Request) {
+	var body bulkRemoveDiscountBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	res, err := s.p.BulkRemoveDiscountInteractor.Execute(r.Context(), &bulkremovediscount.BulkRemoveDiscountRequest{
+		Category: body.Category,
+	})
+	if err != nil {
+		s.p.Log.Sugar().Errorw("bulkRemoveDiscount", "category", body.Category, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	resp := bulkRemoveDiscountResponse{RemovedCount: res.Removed, SkippedCount: res.Skipped, Products: []bulkRemoveDiscountOutcome{}}
+	for _, o := range res.Products {
+		resp.Products = append(resp.Products, bulkRemoveDiscountOutcome{ProductID: o.ProductID, Outcome: string(o.Outcome)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ── Bulk Activate ─────────────────────────────────────────────────────────────
+
+type bulkActivateProductsBody struct {
+	Category        string `json:"category"`
+	RestoreDiscount bool   `json:"restore_discount"`
+}
+
+func (s *Server) handleBulkActivateProducts(w http.ResponseWriter, r *http.Request) {
+	var body bulkActivateProductsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	activated, err := s.p.BulkActivateProductsInteractor.Execute(r.Context(), &bulkactivateproducts.BulkActivateProductsRequest{
+		Category:        body.Category,
+		RestoreDiscount: body.RestoreDiscount,
+	})
+	if err != nil {
+		// Earlier chunks stay committed; a retry activates the remaining products.
+		s.p.Log.Sugar().Errorw("bulkActivateProducts", "category", body.Category, "activated", activated, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"activated_count": activated})
+}
+
+// ── Rename Category ───────────────────────────────────────────────────────────
+
+type renameCategoryBody struct {
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+func (s *Server) handleRenameCategory(w http.ResponseWriter, r *http.Request) {
+	var body renameCategoryBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	renamed, err := s.p.RenameCategoryInteractor.Execute(r.Context(), &renamecategory.RenameCategoryRequest{
+		OldName: body.OldName,
+		NewName: body.NewName,
+	})
+	if err != nil {
+		// Earlier chunks stay committed; a retry moves the remaining products.
+		s.p.Log.Sugar().Errorw("renameCategory", "old", body.OldName, "new", body.NewName, "renamed", renamed, "error", err)
+		s.writeDomainError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"renamed_count": renamed})
+}
+
+// ── Apply Discount Schedule ───────────────────────────────────────────────────
+
+type scheduleEntryBody struct {
+	ProductID  string    `json:"product_id"`
+	Percentage string    `json:"percentage"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+type applyDiscountScheduleBody struct {
+	Entries []scheduleEntryBody `json:"entries"`
+}
+
+type scheduleEntryResult struct {
+	ProductID string `json:"product_id"`
+	Applied   bool   `json:"applied"`
+	Error     string `json:"error,omitempty"`
+}
+
+// handleApplyDiscountSchedule always answers 200 once the request is valid;
+// per-entry failures are reported in the results list.
+func (s *Server) handleApplyDiscountSchedule(w http.ResponseWriter, r *http.Request) {
+	var body applyDiscountScheduleBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(body.Entries) == 0 {
+		writeError(w, http.StatusBadRequest, "entries must not be empty")
+		return
+	}
+
+	req := &applydiscountschedule.ApplyDiscountScheduleRequest{}
+	for _, e := range body.Entries {
+		req.Entries = append(req.Entries, applydiscountschedule.ScheduleEntry{
+			ProductID:  e.ProductID,
+			Percentage: e.Percentage,
+			StartsAt:   e.StartsAt,
+			EndsAt:     e.EndsAt,
+		})
+	}
+
+	results, err := s.p.ApplyDiscountScheduleInteractor.Execute(r.Context(), req)
+	if err != nil {
+		s.p.Log.Sugar().Errorw("applyDiscountSchedule", "entries", len(req.Entries), "error", err)
+		s.writeDomainError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	out := make([]scheduleEntryResult, len(results))
+	applied := 0
+	for i, res := range results {
+		out[i] = scheduleEntryResult{ProductID: res.ProductID, Applied: res.Err == nil}
+		if res.Err != nil {
+			out[i].Error = res.Err.Error()
+		} else {
+			applied++
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": out, "applied_count": applied})
 }
@@ -0,0 +1,312 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.uber.org/zap"
+
+	"github.com/product-catalog-service/common/commitplanner"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/domain/services"
+	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
+	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
+	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
+	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
+	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
+	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fixedTicker struct{}
+
+func (fixedTicker) Now() time.Time { return testNow }
+
+type nopApplier struct{}
+
+func (nopApplier) Apply(context.Context, *commitplanner.Plan) error { return nil }
+
+// singleProductRepo serves one product and drops mutations.
+type singleProductRepo struct{ p *domain.Product }
+
+func (r *singleProductRepo) GetByID(_ context.Context, id string) (*domain.Product, error) {
+	if r.p == nil || r.p.ID() != id {
+		return nil, domain.ErrProductNotFound
+	}
+	return r.p, nil
+}
+func (r *singleProductRepo) InsertMut(p *domain.Product) *spanner.Mutation {
+	r.p = p
+	return nil
+}
+func (r *singleProductRepo) UpdateMut(*domain.Product) *spanner.Mutation    { return nil }
+func (r *singleProductRepo) ForceClearDiscountMut(string) *spanner.Mutation { return nil }
+func (r *singleProductRepo) ListDiscountedByCategory(context.Context, string) ([]*domain.Product, error) {
+	return nil, nil
+}
+func (r *singleProductRepo) ListByCategory(context.Context, string) ([]*domain.Product, error) {
+	return nil, nil
+}
+func (r *singleProductRepo) SlugsWithPrefix(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+func (r *singleProductRepo) ListDuePublish(context.Context, time.Time, int) ([]*domain.Product, error) {
+	return nil, nil
+}
+func (r *singleProductRepo) ListDueUnpublish(context.Context, time.Time, int) ([]*domain.Product, error) {
+	return nil, nil
+}
+
+type nopEventRepo struct{}
+
+func (nopEventRepo) InsertMut(domain.DomainEvent) (*spanner.Mutation, error) {
+	return &spanner.Mutation{}, nil
+}
+
+func newApplyDiscountServer(t *testing.T) (*Server, *singleProductRepo) {
+	t.Helper()
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-1",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(1000, "USD"),
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo := &singleProductRepo{p: p}
+	srv := NewServer(Params{
+		Log:                     zap.NewNop(),
+		ApplyDiscountInteractor: applydiscount.NewApplyDiscountInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+	})
+	return srv, repo
+}
+
+func postDiscount(srv *Server, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products/p-1/discount", strings.NewReader(body)))
+	return rec
+}
+
+func TestHandleApplyDiscount_Duration(t *testing.T) {
+	srv, repo := newApplyDiscountServer(t)
+
+	rec := postDiscount(srv, `{"percentage":"10","duration":"72h"}`)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	d := repo.p.Discount()
+	if !d.StartsAt().Equal(testNow) || !d.EndsAt().Equal(testNow.Add(72*time.Hour)) {
+		t.Fatalf("expected discount %v..%v, got %v..%v", testNow, testNow.Add(72*time.Hour), d.StartsAt(), d.EndsAt())
+	}
+}
+
+func TestHandleApplyDiscount_ExplicitEnd(t *testing.T) {
+	srv, repo := newApplyDiscountServer(t)
+
+	rec := postDiscount(srv, `{"percentage":"10","starts_at":"2026-03-01T00:00:00Z","ends_at":"2026-03-05T00:00:00Z"}`)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	if want := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC); !repo.p.Discount().EndsAt().Equal(want) {
+		t.Fatalf("expected end %v, got %v", want, repo.p.Discount().EndsAt())
+	}
+}
+
+func TestHandleApplyDiscount_RequiresExactlyOneOfEndOrDuration(t *testing.T) {
+	for name, body := range map[string]string{
+		"neither": `{"percentage":"10"}`,
+		"both":    `{"percentage":"10","ends_at":"2026-03-05T00:00:00Z","duration":"24h"}`,
+		"invalid": `{"percentage":"10","duration":"soon"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv, repo := newApplyDiscountServer(t)
+
+			rec := postDiscount(srv, body)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", rec.Code)
+			}
+			if repo.p.Discount() != nil {
+				t.Fatal("discount must not be applied")
+			}
+		})
+	}
+}
+
+func validateDiscount(srv *Server, body string) (*httptest.ResponseRecorder, validateDiscountReply) {
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products/p-1/discount:validate", strings.NewReader(body)))
+	var reply validateDiscountReply
+	_ = json.Unmarshal(rec.Body.Bytes(), &reply)
+	return rec, reply
+}
+
+type validateDiscountReply struct {
+	Valid      bool             `json:"valid"`
+	Violations []fieldErrorBody `json:"violations"`
+}
+
+func TestHandleValidateDiscount_Valid(t *testing.T) {
+	srv, repo := newApplyDiscountServer(t)
+
+	rec, reply := validateDiscount(srv, `{"percentage":"10","duration":"72h"}`)
+
+	if rec.Code != http.StatusOK || !reply.Valid || len(reply.Violations) != 0 {
+		t.Fatalf("expected a valid reply, got %d: %s", rec.Code, rec.Body)
+	}
+	if repo.p.Discount() != nil {
+		t.Fatal("validation must not apply the discount")
+	}
+}
+
+func TestHandleValidateDiscount_ReportsEveryViolation(t *testing.T) {
+	srv, repo := newApplyDiscountServer(t)
+	if err := repo.p.Deactivate(testNow, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+
+	// Inactive product, out-of-range percentage and a window that has not started.
+	rec, reply := validateDiscount(srv, `{"percentage":"150","starts_at":"2026-04-01T00:00:00Z","ends_at":"2026-04-05T00:00:00Z"}`)
+
+	if rec.Code != http.StatusOK || reply.Valid {
+		t.Fatalf("expected an invalid reply, got %d: %s", rec.Code, rec.Body)
+	}
+	var fields []string
+	for _, v := range reply.Violations {
+		fields = append(fields, v.Field)
+	}
+	if want := []string{"product", "percentage", "starts_at"}; !slices.Equal(fields, want) {
+		t.Fatalf("expected violations on %v, got %v", want, reply.Violations)
+	}
+}
+
+func TestHandleCreateProduct_DecimalPrice(t *testing.T) {
+	repo := &singleProductRepo{}
+	srv := NewServer(Params{
+		Log:                     zap.NewNop(),
+		CreateProductInteractor: createproduct.NewCreateProductInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}, createproduct.DefaultConfig()),
+	})
+
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products",
+		strings.NewReader(`{"name":"Laptop","category":"electronics","price":"19.99"}`)))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := repo.p.BasePrice(); got.Amount() != 1999 || got.Currency() != "USD" {
+		t.Fatalf("expected 1999 USD, got %s", got)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products",
+		strings.NewReader(`{"name":"Laptop","category":"electronics","price":"19.999"}`)))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for an over-precise price, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products",
+		strings.NewReader(`{"name":"Laptop","category":"electronics"}`)))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a missing price, got %d", rec.Code)
+	}
+}
+
+// newWriteServer serves every single-product write endpoint and GetProduct
+// over one inactive product, p-1.
+func newWriteServer(t *testing.T) *Server {
+	t.Helper()
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-1",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(1000, "USD"),
+		Status:    domain.ProductStatusInactive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo := &singleProductRepo{p: p}
+	return NewServer(Params{
+		Log:                         zap.NewNop(),
+		UpdateProductInteractor:     updateproduct.NewUpdateProductInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+		ActivateProductInteractor:   activateproduct.NewActivateProductInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+		DeactivateProductInteractor: deactivateproduct.NewDeactivateProductInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+		ApplyDiscountInteractor:     applydiscount.NewApplyDiscountInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+		RemoveDiscountInteractor:    removediscount.NewRemoveDiscountInteractor(nopApplier{}, repo, nopEventRepo{}, fixedTicker{}),
+		GetProductQuery:             getproduct.NewGetProductQuery(singleProductQueryRepo{p: p}, services.NewPricingCalculator(), fixedTicker{}),
+	})
+}
+
+// productWrites walks p-1 through every write endpoint; each step's check
+// inspects the returned representation.
+var productWrites = []struct {
+	name, method, path, body string
+	check                    func(dto getproduct.ProductDTO) bool
+}{
+	{"activate", http.MethodPost, "/products/p-1/activate", "",
+		func(dto getproduct.ProductDTO) bool { return dto.Status == "active" }},
+	{"apply discount", http.MethodPost, "/products/p-1/discount", `{"percentage":"10","duration":"72h"}`,
+		func(dto getproduct.ProductDTO) bool { return dto.Discount != nil && dto.EffectivePrice.Amount == 900 }},
+	{"update", http.MethodPut, "/products/p-1", `{"name":"Notebook"}`,
+		func(dto getproduct.ProductDTO) bool { return dto.Name == "Notebook" }},
+	{"remove discount", http.MethodDelete, "/products/p-1/discount", "",
+		func(dto getproduct.ProductDTO) bool { return dto.Discount == nil && dto.EffectivePrice.Amount == 1000 }},
+	{"deactivate", http.MethodPost, "/products/p-1/deactivate", "",
+		func(dto getproduct.ProductDTO) bool { return dto.Status == "inactive" }},
+}
+
+func TestHandleProductWrites_NoContentByDefault(t *testing.T) {
+	srv := newWriteServer(t)
+
+	for _, step := range productWrites {
+		if step.name == "update" {
+			continue // a changing update reports its changed fields
+		}
+		rec := httptest.NewRecorder()
+		srv.Mux.ServeHTTP(rec, httptest.NewRequest(step.method, step.path, strings.NewReader(step.body)))
+
+		if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+			t.Fatalf("%s: expected an empty 204, got %d: %s", step.name, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestHandleProductWrites_ReturnRepresentation(t *testing.T) {
+	srv := newWriteServer(t)
+
+	for _, step := range productWrites {
+		req := httptest.NewRequest(step.method, step.path, strings.NewReader(step.body))
+		req.Header.Set("Prefer", "return=representation")
+		rec := httptest.NewRecorder()
+		srv.Mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", step.name, rec.Code, rec.Body)
+		}
+		if got := rec.Header().Get("Preference-Applied"); got != "return=representation" {
+			t.Fatalf("%s: expected Preference-Applied, got %q", step.name, got)
+		}
+		var dto getproduct.ProductDTO
+		if err := json.Unmarshal(rec.Body.Bytes(), &dto); err != nil {
+			t.Fatalf("%s: decode: %v", step.name, err)
+		}
+		if dto.ID != "p-1" || !step.check(dto) {
+			t.Fatalf("%s: unexpected product %+v", step.name, dto)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReadinessConfig selects the optional checks behind /readyz. The zero value
+// disables them all, so services without an outbox relay always report ready.
+type ReadinessConfig struct {
+	// OutboxMaxPendingAge marks the service degraded once the oldest pending
+	// outbox event is older than this. Zero disables the check.
+	OutboxMaxPendingAge time.Duration
+}
+
+type readinessCheck struct {
+	Status        string `json:"status"` // ok | degraded | error
+	Detail        string `json:"detail,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"` // set for errors; matches the server log
+}
+
+type readinessResponse struct {
+	Status string                    `json:"status"` // ok | degraded
+	Checks map[string]readinessCheck `json:"checks,omitempty"`
+}
+
+// handleReadyz answers 200 when every enabled check passes and 503 otherwise,
+// with per-check detail in the body.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	resp := readinessResponse{Status: "ok", Checks: map[string]readinessCheck{}}
+
+	if maxAge := s.p.Readiness.OutboxMaxPendingAge; maxAge > 0 && s.p.OutboxStatusQuery != nil {
+		check := readinessCheck{Status: "ok"}
+		dto, err := s.p.OutboxStatusQuery.Execute(r.Context())
+		switch {
+		case err != nil:
+			// As in writeDomainError: the raw error stays in the log, keyed by
+			// an ID the caller can quote.
+			id := uuid.NewString()
+			s.log.Error("readyz outbox", zap.String("correlation_id", id), zap.Error(err))
+			check = readinessCheck{Status: "error", Detail: "internal error", CorrelationID: id}
+		case dto.Backlogged(maxAge):
+			check = readinessCheck{
+				Status: "degraded",
+				Detail: "oldest pending event is " + dto.OldestPendingAge.String() + " old, limit " + maxAge.String(),
+			}
+		}
+		resp.Checks["outbox"] = check
+		if check.Status != "ok" {
+			resp.Status = "degraded"
+		}
+	}
+
+	code := http.StatusOK
+	if resp.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, resp)
+}
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/product-catalog-service/internal/models/m_outbox"
+	"github.com/product-catalog-service/internal/outbox"
+)
+
+// stubOutboxRepo reports a fixed backlog, or fails with err.
+type stubOutboxRepo struct {
+	pending int64
+	oldest  *time.Time
+	err     error
+}
+
+func (r stubOutboxRepo) CountByStatus(context.Context) (map[string]int64, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return map[string]int64{m_outbox.StatusPending: r.pending}, nil
+}
+
+func (r stubOutboxRepo) OldestPendingAt(context.Context) (*time.Time, error) {
+	return r.oldest, nil
+}
+
+func readyz(t *testing.T, p Params) (int, readinessResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	NewServer(p).Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var body readinessResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestHandleReadyz_StaleOutboxIsDegraded(t *testing.T) {
+	stale := testNow.Add(-10 * time.Minute)
+	code, body := readyz(t, Params{
+		Log:               zap.NewNop(),
+		OutboxStatusQuery: outbox.NewStatusQuery(stubOutboxRepo{pending: 3, oldest: &stale}, fixedTicker{}),
+		Readiness:         ReadinessConfig{OutboxMaxPendingAge: 5 * time.Minute},
+	})
+
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", code)
+	}
+	if body.Status != "degraded" || body.Checks["outbox"].Status != "degraded" {
+		t.Fatalf("expected degraded outbox check, got %+v", body)
+	}
+}
+
+func TestHandleReadyz_FreshOutboxIsReady(t *testing.T) {
+	fresh := testNow.Add(-time.Minute)
+	code, body := readyz(t, Params{
+		Log:               zap.NewNop(),
+		OutboxStatusQuery: outbox.NewStatusQuery(stubOutboxRepo{pending: 1, oldest: &fresh}, fixedTicker{}),
+		Readiness:         ReadinessConfig{OutboxMaxPendingAge: 5 * time.Minute},
+	})
+
+	if code != http.StatusOK || body.Checks["outbox"].Status != "ok" {
+		t.Fatalf("expected ready, got %d %+v", code, body)
+	}
+}
+
+func TestHandleReadyz_OutboxCheckOptIn(t *testing.T) {
+	stale := testNow.Add(-time.Hour)
+	code, body := readyz(t, Params{
+		Log:               zap.NewNop(),
+		OutboxStatusQuery: outbox.NewStatusQuery(stubOutboxRepo{pending: 3, oldest: &stale}, fixedTicker{}),
+	})
+
+	if code != http.StatusOK || len(body.Checks) != 0 {
+		t.Fatalf("expected ready without checks when disabled, got %d %+v", code, body)
+	}
+}
+
+func TestHandleReadyz_OutboxErrorHidesDetailBehindCorrelationID(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	code, body := readyz(t, Params{
+		Log:               zap.New(core),
+		OutboxStatusQuery: outbox.NewStatusQuery(stubOutboxRepo{err: errors.New("spanner: session pool exhausted")}, fixedTicker{}),
+		Readiness:         ReadinessConfig{OutboxMaxPendingAge: 5 * time.Minute},
+	})
+
+	check := body.Checks["outbox"]
+	if code != http.StatusServiceUnavailable || check.Status != "error" {
+		t.Fatalf("expected an outbox error, got %d %+v", code, body)
+	}
+	if strings.Contains(check.Detail, "spanner") || check.CorrelationID == "" {
+		t.Fatalf("expected a generic detail with a correlation ID, got %+v", check)
+	}
+	entries := logs.FilterField(zap.String("correlation_id", check.CorrelationID)).All()
+	if len(entries) != 1 {
+		t.Fatalf("expected the raw error logged under %s, got %v", check.CorrelationID, logs.All())
+	}
+}
@@ -8,26 +8,57 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/product-catalog-service/common/buildinfo"
 	"github.com/product-catalog-service/internal/app/product/domain"
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
+	effectivepricebackfill "github.com/product-catalog-service/internal/app/product/queries/effective_price_backfill"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
+	pricepreview "github.com/product-catalog-service/internal/app/product/queries/price_preview"
+	pricestats "github.com/product-catalog-service/internal/app/product/queries/price_stats"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
+	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
+	forcecleardiscount "github.com/product-catalog-service/internal/app/product/usecases/force_clear_discount"
+	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
+	renamecategory "github.com/product-catalog-service/internal/app/product/usecases/rename_category"
 	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+	"github.com/product-catalog-service/internal/outbox"
+	"github.com/product-catalog-service/internal/transport/errcatalog"
 )
 
 // Params bundles all handler dependencies injected by FX.
 type Params struct {
 	fx.In
 
-	Log                       *zap.Logger
-	CreateProductInteractor   *createproduct.CreateProductInteractor
-	UpdateProductInteractor   *updateproduct.UpdateProductInteractor
-	ApplyDiscountInteractor   *applydiscount.ApplyDiscountInteractor
-	ActivateProductInteractor *activateproduct.ActivateProductInteractor
-	GetProductQuery           *getproduct.GetProductQuery
-	ListProductsQuery         *listproducts.ListProductsQuery
+	Log                             *zap.Logger
+	BuildInfo                       buildinfo.Info
+	CreateProductInteractor         *createproduct.CreateProductInteractor
+	UpdateProductInteractor         *updateproduct.UpdateProductInteractor
+	ApplyDiscountInteractor         *applydiscount.ApplyDiscountInteractor
+	ActivateProductInteractor       *activateproduct.ActivateProductInteractor
+	DeactivateProductInteractor     *deactivateproduct.DeactivateProductInteractor
+	RemoveDiscountInteractor        *removediscount.RemoveDiscountInteractor
+	ForceClearDiscountInteractor    *forcecleardiscount.ForceClearDiscountInteractor
+	BulkRemoveDiscountInteractor    *bulkremovediscount.BulkRemoveDiscountInteractor
+	BulkActivateProductsInteractor  *bulkactivateproducts.BulkActivateProductsInteractor
+	ApplyDiscountScheduleInteractor *applydiscountschedule.ApplyDiscountScheduleInteractor
+	RenameCategoryInteractor        *renamecategory.RenameCategoryInteractor
+	GetProductQuery                 *getproduct.GetProductQuery
+	ListProductsQuery               *listproducts.ListProductsQuery
+	ListSubcategoriesQuery          *listsubcategories.ListSubcategoriesQuery
+	CheckExistenceQuery             *checkexistence.CheckExistenceQuery
+	PriceStatsQuery                 *pricestats.PriceStatsQuery
+	PricePreviewQuery               *pricepreview.PricePreviewQuery
+	OutboxStatusQuery               *outbox.StatusQuery
+	EffectivePriceBackfillQuery     *effectivepricebackfill.BackfillQuery
+	Readiness                       ReadinessConfig
+	Admin                           AdminConfig
 }
 
 // Server holds the HTTP mux and handler dependencies.
@@ -47,27 +78,80 @@ func NewServer(p Params) *Server {
 func (s *Server) registerRoutes() {
 	// Health
 	s.Mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.Mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.Mux.HandleFunc("GET /version", s.handleVersion)
+	s.Mux.HandleFunc("GET /meta", s.handleMeta)
 
 	// Write endpoints
 	s.Mux.HandleFunc("POST /products", s.handleCreateProduct)
 	s.Mux.HandleFunc("PUT /products/{id}", s.handleUpdateProduct)
 	s.Mux.HandleFunc("POST /products/{id}/activate", s.handleActivateProduct)
+	s.Mux.HandleFunc("POST /products/{id}/deactivate", s.handleDeactivateProduct)
 	s.Mux.HandleFunc("POST /products/{id}/discount", s.handleApplyDiscount)
+	s.Mux.HandleFunc("POST /products/{id}/discount:validate", s.handleValidateDiscount)
+	s.Mux.HandleFunc("DELETE /products/{id}/discount", s.handleRemoveDiscount)
+	s.Mux.HandleFunc("POST /products:bulkRemoveDiscount", s.handleBulkRemoveDiscount)
+	s.Mux.HandleFunc("POST /products:bulkActivate", s.handleBulkActivateProducts)
+	s.Mux.HandleFunc("POST /discounts:applySchedule", s.handleApplyDiscountSchedule)
+	s.Mux.HandleFunc("POST /categories:rename", s.handleRenameCategory)
 
 	// Read endpoints
 	s.Mux.HandleFunc("GET /products/{id}", s.handleGetProduct)
+	s.Mux.HandleFunc("GET /products/slug/{slug}", s.handleGetProductBySlug)
+	s.Mux.HandleFunc("GET /v1/products/{id}", s.handleGetProductV1)
 	s.Mux.HandleFunc("GET /products", s.handleListProducts)
+	s.Mux.HandleFunc("GET /categories/{id}/descendants", s.handleListSubcategories)
+	s.Mux.HandleFunc("POST /products:batchGet", s.handleBatchGetProducts)
+	s.Mux.HandleFunc("POST /products:exists", s.handleCheckExistence)
+	s.Mux.HandleFunc("POST /products:previewPrice", s.handlePricePreview)
+
+	// Analytics endpoints
+	s.Mux.HandleFunc("GET /analytics/price-stats", s.handlePriceStats)
+
+	// Admin endpoints
+	s.Mux.HandleFunc("GET /admin/outbox/status", s.requireAdmin(s.handleOutboxStatus))
+	s.Mux.HandleFunc("POST /admin/effective-prices:backfill", s.requireAdmin(s.handleEffectivePriceBackfill))
+	s.Mux.HandleFunc("POST /admin/products/{id}/discount:forceClear", s.requireAdmin(s.handleForceClearDiscount))
+	s.Mux.HandleFunc("POST /admin/products/{id}/price:correct", s.requireAdmin(s.handleCorrectPrice))
 }
 
 func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"version":    s.p.BuildInfo.Version,
+		"commit":     s.p.BuildInfo.Commit,
+		"build_time": s.p.BuildInfo.BuildTime,
+	})
+}
+
+// handleMeta lists the product statuses, discount states and error reasons
+// responses may carry, taken from the domain's own definitions.
+func (s *Server) handleMeta(w http.ResponseWriter, r *http.Request) {
+	statuses := make([]string, 0, len(domain.ProductStatuses))
+	for _, st := range domain.ProductStatuses {
+		statuses = append(statuses, string(st))
+	}
+	states := make([]string, 0, len(domain.DiscountStates))
+	for _, st := range domain.DiscountStates {
+		states = append(states, string(st))
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{
+		"product_statuses": statuses,
+		"discount_states":  states,
+		"error_reasons":    errcatalog.Reasons(),
+	})
+}
+
 // NewHTTPServer creates an *http.Server with proper timeouts and FX lifecycle hooks.
-func NewHTTPServer(lc fx.Lifecycle, srv *Server, log *zap.Logger, addr string) *http.Server {
+// The mux is wrapped with Accept-Currency handling and the CORS policy before
+// being handed to the server.
+func NewHTTPServer(lc fx.Lifecycle, srv *Server, log *zap.Logger, cors CORSConfig, addr string) *http.Server {
 	httpSrv := &http.Server{
 		Addr:    addr,
-		Handler: srv.Mux,
+		Handler: WithCORS(cors, WithAcceptCurrency(srv.Mux)),
 	}
 
 	lc.Append(fx.Hook{
@@ -90,17 +174,69 @@ func NewHTTPServer(lc fx.Lifecycle, srv *Server, log *zap.Logger, addr string) *
 }
 
 // domainErrToStatus maps domain sentinel errors to HTTP status codes.
+// statusClientClosedRequest is the non-standard 499 status used when the
+// client went away before the response was written.
+const statusClientClosedRequest = 499
+
 func domainErrToStatus(err error) int {
 	switch {
-	case errors.Is(err, domain.ErrProductNotFound):
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, domain.ErrProductNotFound),
+		errors.Is(err, domain.ErrCategoryNotFound):
 		return http.StatusNotFound
+	case errors.Is(err, domain.ErrProductArchived):
+		return http.StatusGone
+	case errors.Is(err, domain.ErrScheduledDiscountPending),
+		errors.Is(err, domain.ErrDiscountOverlap),
+		errors.Is(err, domain.ErrSlugConflict),
+		errors.Is(err, domain.ErrProductAlreadyActive):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidPagination),
+		errors.Is(err, domain.ErrInvalidSortOrder),
+		errors.Is(err, domain.ErrIncompleteAttribute),
+		errors.Is(err, domain.ErrNoFieldsToUpdate),
+		errors.Is(err, domain.ErrDuplicateIDInBatch):
+		return http.StatusBadRequest
 	case errors.Is(err, domain.ErrProductNotActive),
 		errors.Is(err, domain.ErrProductNameRequired),
+		errors.Is(err, domain.ErrProductCategoryRequired),
+		errors.Is(err, domain.ErrProductFieldTooLong),
+		errors.Is(err, domain.ErrInvalidMediaURL),
+		errors.Is(err, domain.ErrTooManyMediaURLs),
+		errors.Is(err, domain.ErrNegativeStock),
+		errors.Is(err, domain.ErrStockOverflow),
+		errors.Is(err, domain.ErrInvalidAttributeKey),
+		errors.Is(err, domain.ErrInvalidAttributeValue),
+		errors.Is(err, domain.ErrTooManyAttributes),
+		errors.Is(err, domain.ErrPublishAtNotInFuture),
+		errors.Is(err, domain.ErrUnpublishBeforePublish),
+		errors.Is(err, domain.ErrCurrencyChangeNotAllowed),
+		errors.Is(err, domain.ErrDuplicateCategory),
+		errors.Is(err, domain.ErrTooManyCategories),
+		errors.Is(err, domain.ErrPrimaryCategoryRemoval),
+		errors.Is(err, domain.ErrInvalidQuantityTier),
+		errors.Is(err, domain.ErrDuplicateQuantityTier),
+		errors.Is(err, domain.ErrTooManyQuantityTiers),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidDecimalAmount),
+		errors.Is(err, domain.ErrAmountTooPrecise),
+		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrNegativeAmount),
+		errors.Is(err, domain.ErrCategoryIDRequired),
 		errors.Is(err, domain.ErrProductBasePriceRequired),
 		errors.Is(err, domain.ErrDiscountInvalidPercentage),
 		errors.Is(err, domain.ErrDiscountInvalidPeriod),
+		errors.Is(err, domain.ErrDiscountInvalidDuration),
 		errors.Is(err, domain.ErrNoActiveDiscount),
-		errors.Is(err, domain.ErrInvalidDiscountPeriod):
+		errors.Is(err, domain.ErrInvalidDiscountPeriod),
+		errors.Is(err, domain.ErrDiscountBackdatedTooFar),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrInvalidDiscountState):
 		return http.StatusUnprocessableEntity
 	default:
 		return http.StatusInternalServerError
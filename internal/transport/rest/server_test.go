@@ -0,0 +1,252 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/common/buildinfo"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+)
+
+func TestHandleVersion_ReturnsInjectedBuildInfo(t *testing.T) {
+	buildinfo.Version = "v1.2.3"
+	buildinfo.Commit = "abc1234"
+	buildinfo.BuildTime = "2026-02-20T12:00:00Z"
+	t.Cleanup(func() {
+		buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = "dev", "unknown", "unknown"
+	})
+
+	srv := NewServer(Params{Log: zap.NewNop(), BuildInfo: buildinfo.Get()})
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["version"] != "v1.2.3" || body["commit"] != "abc1234" || body["build_time"] != "2026-02-20T12:00:00Z" {
+		t.Fatalf("unexpected body %v", body)
+	}
+}
+
+func TestHandleMeta_ListsDomainEnumerations(t *testing.T) {
+	srv := NewServer(Params{Log: zap.NewNop()})
+	rec := httptest.NewRecorder()
+	srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/meta", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var body map[string][]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, st := range []domain.ProductStatus{domain.ProductStatusActive, domain.ProductStatusInactive} {
+		if !slices.Contains(body["product_statuses"], string(st)) {
+			t.Fatalf("expected product status %q in %v", st, body["product_statuses"])
+		}
+	}
+	for _, st := range []domain.DiscountState{domain.DiscountStateActive, domain.DiscountStateUpcoming, domain.DiscountStateExpired} {
+		if !slices.Contains(body["discount_states"], string(st)) {
+			t.Fatalf("expected discount state %q in %v", st, body["discount_states"])
+		}
+	}
+	for _, reason := range []string{"PRODUCT_NOT_FOUND", "VALIDATION_FAILED"} {
+		if !slices.Contains(body["error_reasons"], reason) {
+			t.Fatalf("expected error reason %q in %v", reason, body["error_reasons"])
+		}
+	}
+}
+
+func TestDomainErrToStatus_ArchivedVersusMissing(t *testing.T) {
+	if got := domainErrToStatus(domain.ErrProductArchived); got != http.StatusGone {
+		t.Fatalf("expected 410 for archived, got %d", got)
+	}
+	if got := domainErrToStatus(domain.ErrProductNotFound); got != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing, got %d", got)
+	}
+}
+
+func TestDomainErrToStatus_ContextErrors(t *testing.T) {
+	if got := domainErrToStatus(fmt.Errorf("get product: %w", context.Canceled)); got != 499 {
+		t.Fatalf("expected 499 for a canceled request, got %d", got)
+	}
+	if got := domainErrToStatus(fmt.Errorf("commit: %w", context.DeadlineExceeded)); got != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504 for an exceeded deadline, got %d", got)
+	}
+}
+
+func TestWriteDomainError_HidesInternalDetails(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	srv := NewServer(Params{Log: zap.New(core)})
+	raw := errors.New(`spanner: code = "InvalidArgument", desc = "Syntax error at [1:8]: SELECT product_id FROM products"`)
+
+	rec := httptest.NewRecorder()
+	srv.writeDomainError(rec, httptest.NewRequest(http.MethodGet, "/products/p1", nil), fmt.Errorf("GetByID: %w", raw))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["error"] != "internal error" || body["correlation_id"] == "" {
+		t.Fatalf("expected a generic error with a correlation id, got %v", body)
+	}
+
+	entries := logs.FilterField(zap.String("correlation_id", body["correlation_id"])).All()
+	if len(entries) != 1 || !strings.Contains(entries[0].ContextMap()["error"].(string), "Syntax error") {
+		t.Fatalf("expected the raw error logged under the correlation id, got %v", logs.All())
+	}
+}
+
+func TestWriteDomainError_LocalizesMessage(t *testing.T) {
+	srv := NewServer(Params{Log: zap.NewNop()})
+	for lang, want := range map[string]string{
+		"":                  domain.ErrProductNotFound.Error(),
+		"vi":                "không tìm thấy sản phẩm",
+		"vi-VN,en;q=0.5":    "không tìm thấy sản phẩm",
+		"de-DE,de;q=0.9":    domain.ErrProductNotFound.Error(),
+		"en-US,vi;q=0.5":    domain.ErrProductNotFound.Error(),
+		"not a language!!!": domain.ErrProductNotFound.Error(),
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/products/p1", nil)
+		req.Header.Set("Accept-Language", lang)
+		rec := httptest.NewRecorder()
+		srv.writeDomainError(rec, req, domain.ErrProductNotFound)
+
+		var body map[string]string
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%q: decode: %v", lang, err)
+		}
+		if rec.Code != http.StatusNotFound || body["error"] != want || body["reason"] != "PRODUCT_NOT_FOUND" {
+			t.Fatalf("%q: expected 404 %q with reason PRODUCT_NOT_FOUND, got %d %v", lang, want, rec.Code, body)
+		}
+	}
+}
+
+func TestHandleListProducts_RejectsNegativePagination(t *testing.T) {
+	// The query must reject the request before touching its (nil) repositories.
+	srv := NewServer(Params{
+		Log:               zap.NewNop(),
+		ListProductsQuery: listproducts.NewListProductsQuery(nil, nil, nil, fixedTicker{}, listproducts.DefaultConfig()),
+	})
+
+	for _, query := range []string{"offset=-5", "limit=-1", "limit=ten"} {
+		rec := httptest.NewRecorder()
+		srv.Mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestAdminRoutes_RequireToken(t *testing.T) {
+	cases := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{"no token configured", "", "Bearer secret", http.StatusForbidden},
+		{"missing header", "secret", "", http.StatusUnauthorized},
+		{"wrong token", "secret", "Bearer nope", http.StatusUnauthorized},
+		// A valid token reaches the handler, which rejects the empty body.
+		{"valid token", "secret", "Bearer secret", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := NewServer(Params{Log: zap.NewNop(), Admin: AdminConfig{Token: tc.token}})
+			req := httptest.NewRequest(http.MethodPost, "/admin/products/p1/price:correct", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			srv.Mux.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestWithCORS_PreflightFromAllowedOrigin(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = []string{"https://admin.example.com"}
+	h := WithCORS(cfg, NewServer(Params{Log: zap.NewNop()}).Mux)
+
+	req := httptest.NewRequest(http.MethodOptions, "/products", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://admin.example.com" {
+		t.Fatalf("unexpected Access-Control-Allow-Origin %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST, PUT, DELETE" {
+		t.Fatalf("unexpected Access-Control-Allow-Methods %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, Accept-Currency, Prefer" {
+		t.Fatalf("unexpected Access-Control-Allow-Headers %q", got)
+	}
+}
+
+func TestWithCORS_DisallowedOrigin(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = []string{"https://admin.example.com"}
+	h := WithCORS(cfg, NewServer(Params{Log: zap.NewNop()}).Mux)
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/products", nil)
+	preflight.Header.Set("Origin", "https://evil.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for disallowed preflight, got %d", rec.Code)
+	}
+
+	get := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	get.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, get)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("expected no Access-Control-Allow-Origin, got %q", got)
+	}
+}
+
+func TestWithAcceptCurrency_PassesValidCodesOnly(t *testing.T) {
+	for header, want := range map[string]string{"vnd": "VND", "EUR": "EUR", "": "", "US": "", "U$D": ""} {
+		var got string
+		h := WithAcceptCurrency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = common.CurrencyFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Accept-Currency", header)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		if got != want {
+			t.Fatalf("Accept-Currency %q: expected %q in context, got %q", header, want, got)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+-- migrations/002_outbox_attempts.sql
+-- Track publish attempts so repeatedly-failing events can be dead-lettered
+-- (status = 'dead') instead of blocking the relay.
+
+ALTER TABLE outbox_events ADD COLUMN attempts INT64 NOT NULL DEFAULT (0);
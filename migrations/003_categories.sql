@@ -0,0 +1,12 @@
+-- migrations/003_categories.sql
+-- Category taxonomy: each category optionally points at its parent.
+-- products.category holds a category_id; existing flat categories are roots
+-- until they are inserted here with a parent.
+
+CREATE TABLE categories (
+    category_id  STRING(100)  NOT NULL,
+    name         STRING(255)  NOT NULL,
+    parent_id    STRING(100),
+) PRIMARY KEY (category_id);
+
+CREATE INDEX idx_categories_parent ON categories(parent_id);
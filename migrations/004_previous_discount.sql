@@ -0,0 +1,7 @@
+-- migrations/004_previous_discount.sql
+-- Keep the discount dropped by the last deactivation so reactivation can
+-- optionally restore it.
+
+ALTER TABLE products ADD COLUMN previous_discount_percent NUMERIC;
+ALTER TABLE products ADD COLUMN previous_discount_start_date TIMESTAMP;
+ALTER TABLE products ADD COLUMN previous_discount_end_date TIMESTAMP;
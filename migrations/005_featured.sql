@@ -0,0 +1,4 @@
+-- migrations/005_featured.sql
+-- Featured products are listed first by default.
+
+ALTER TABLE products ADD COLUMN featured BOOL NOT NULL DEFAULT (false);
@@ -0,0 +1,5 @@
+-- migrations/006_outbox_sequence.sql
+-- Number events within the operation that raised them: events committed
+-- together share created_at, so consumers order them by sequence.
+
+ALTER TABLE outbox_events ADD COLUMN sequence INT64;
@@ -0,0 +1,23 @@
+-- Denormalised listing rows maintained by the read-model projector from
+-- outbox events. Prices are precomputed at projection time; the projector's
+-- sweep re-projects rows once next_price_change_at (a discount starting or
+-- ending) has passed. Column names match products so list filters apply as-is.
+
+CREATE TABLE product_read_model (
+    product_id              STRING(36)   NOT NULL,
+    name                    STRING(255)  NOT NULL,
+    category                STRING(100)  NOT NULL,
+    status                  STRING(20)   NOT NULL,
+    featured                BOOL         NOT NULL,
+    created_at              TIMESTAMP,
+    base_price_amount       INT64        NOT NULL,
+    currency                STRING(3)    NOT NULL,
+    effective_price_amount  INT64        NOT NULL,
+    is_discounted           BOOL         NOT NULL,
+    discount_ends_at        TIMESTAMP,
+    next_price_change_at    TIMESTAMP,
+    projected_at            TIMESTAMP    NOT NULL OPTIONS (allow_commit_timestamp=true),
+) PRIMARY KEY (product_id);
+
+CREATE INDEX idx_read_model_listing ON product_read_model(status, featured DESC, product_id);
+CREATE INDEX idx_read_model_next_price_change ON product_read_model(next_price_change_at);
@@ -0,0 +1,4 @@
+-- migrations/008_product_media.sql
+-- Ordered image and other media URLs per product; NULL when a product has none.
+
+ALTER TABLE products ADD COLUMN media_urls ARRAY<STRING(2048)>;
@@ -0,0 +1,8 @@
+-- migrations/009_product_slug.sql
+-- Human-friendly unique product URLs such as /p/wireless-keyboard. Products
+-- created before this migration keep a NULL slug, which the index skips, and
+-- are only reachable by ID until a slug is set.
+
+ALTER TABLE products ADD COLUMN slug STRING(100);
+
+CREATE UNIQUE NULL_FILTERED INDEX products_by_slug ON products(slug);
@@ -0,0 +1,7 @@
+-- migrations/010_product_stock.sql
+-- Units on hand per product. Existing products start out of stock; the
+-- read model carries the column too so the in-stock filter applies to it.
+
+ALTER TABLE products ADD COLUMN stock_quantity INT64 NOT NULL DEFAULT (0);
+
+ALTER TABLE product_read_model ADD COLUMN stock_quantity INT64 NOT NULL DEFAULT (0);
@@ -0,0 +1,7 @@
+-- migrations/011_quantity_tiers.sql
+-- Volume discounts ("5% off 10+"), stored as parallel arrays ordered by
+-- minimum quantity: element i of each array describes tier i. NULL when a
+-- product has no tiers.
+
+ALTER TABLE products ADD COLUMN quantity_tier_min_quantities ARRAY<INT64>;
+ALTER TABLE products ADD COLUMN quantity_tier_percents ARRAY<NUMERIC>;
@@ -0,0 +1,8 @@
+-- migrations/012_additional_categories.sql
+-- Categories a product is listed in besides its primary category; NULL when
+-- there are none. Category filters match either column. The read model keeps
+-- a copy so the same filters apply to it.
+
+ALTER TABLE products ADD COLUMN additional_categories ARRAY<STRING(100)>;
+
+ALTER TABLE product_read_model ADD COLUMN additional_categories ARRAY<STRING(100)>;
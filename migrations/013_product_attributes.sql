@@ -0,0 +1,8 @@
+-- migrations/013_product_attributes.sql
+-- Free-form product attributes such as color or size, as a JSON object of
+-- string values keyed by snake_case name; NULL when a product has none. The
+-- read model keeps a copy so attribute filters apply to it.
+
+ALTER TABLE products ADD COLUMN attributes JSON;
+
+ALTER TABLE product_read_model ADD COLUMN attributes JSON;
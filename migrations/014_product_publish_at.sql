@@ -0,0 +1,7 @@
+-- migrations/014_product_publish_at.sql
+-- When a staged (inactive) product is due to go live; NULL when no launch is
+-- scheduled. The publish job scans the index for due products.
+
+ALTER TABLE products ADD COLUMN publish_at TIMESTAMP;
+
+CREATE INDEX idx_products_publish_at ON products(publish_at);
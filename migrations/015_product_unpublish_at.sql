@@ -0,0 +1,7 @@
+-- migrations/015_product_unpublish_at.sql
+-- When a live product is due to be taken down; NULL when no takedown is
+-- scheduled. The unpublish job scans the index for due products.
+
+ALTER TABLE products ADD COLUMN unpublish_at TIMESTAMP;
+
+CREATE INDEX idx_products_unpublish_at ON products(unpublish_at);
@@ -0,0 +1,5 @@
+-- migrations/016_base_price_currency.sql
+-- Currency of the base price. It was implicit before this migration and every
+-- product was read back as VND, so existing rows are backfilled with VND.
+
+ALTER TABLE products ADD COLUMN base_price_currency STRING(3) NOT NULL DEFAULT ('VND');
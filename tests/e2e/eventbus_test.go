@@ -0,0 +1,88 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/product-catalog-service/internal/app/product/domain"
+	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
+	"github.com/product-catalog-service/internal/eventbus"
+)
+
+// ────────────────────────────────────────────────────────────────────────────
+// In-process event bus
+// ────────────────────────────────────────────────────────────────────────────
+
+func createThroughBus(t *testing.T, bus *eventbus.Bus, committer *mockCommitter) (string, error) {
+	t.Helper()
+	repo, eventRepo, _, ticker := buildDeps(t)
+	it := createproduct.NewCreateProductInteractor(eventbus.NewApplier(committer, bus), repo, eventRepo, ticker, createproduct.DefaultConfig())
+	return it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name:        "Laptop",
+		Description: "a product",
+		Category:    "electronics",
+		Amount:      amount(100),
+	})
+}
+
+func TestEventBus_DeliversCreatedAfterCommit(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	committer := &mockCommitter{}
+	var got []string
+	bus.Subscribe("product.created", func(_ context.Context, event domain.DomainEvent) {
+		if !committer.applied {
+			t.Error("event delivered before the commit")
+		}
+		got = append(got, event.(*domain.ProductCreatedEvent).ProductID())
+	})
+	bus.Subscribe("product.updated", func(context.Context, domain.DomainEvent) {
+		t.Error("unexpected product.updated delivery")
+	})
+
+	id, err := createThroughBus(t, bus, committer)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got) != 1 || got[0] != id {
+		t.Fatalf("expected one product.created for %s, got %v", id, got)
+	}
+}
+
+func TestEventBus_FailedCommitPublishesNothing(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	delivered := false
+	bus.Subscribe("product.created", func(context.Context, domain.DomainEvent) { delivered = true })
+
+	_, err := createThroughBus(t, bus, &mockCommitter{err: errors.New("commit failed")})
+
+	if err == nil {
+		t.Fatal("expected the commit error")
+	}
+	if delivered {
+		t.Fatal("expected no delivery for a failed commit")
+	}
+}
+
+func TestEventBus_HandlerPanicDoesNotFailRequest(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	var order []string
+	bus.Subscribe("product.created", func(context.Context, domain.DomainEvent) {
+		order = append(order, "first")
+		panic("boom")
+	})
+	bus.Subscribe("product.created", func(context.Context, domain.DomainEvent) {
+		order = append(order, "second")
+	})
+
+	if _, err := createThroughBus(t, bus, &mockCommitter{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("expected both handlers in subscription order, got %v", order)
+	}
+}
@@ -0,0 +1,259 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/product-catalog-service/internal/models/m_outbox"
+	"github.com/product-catalog-service/internal/outbox"
+)
+
+// ────────────────────────────────────────────────────────────────────────────
+// In-memory outbox
+// ────────────────────────────────────────────────────────────────────────────
+
+// inMemoryOutbox is a slice-backed implementation of outbox.Repository.
+type inMemoryOutbox struct {
+	rows []m_outbox.OutboxEventRow
+}
+
+func (o *inMemoryOutbox) CountByStatus(_ context.Context) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, row := range o.rows {
+		counts[row.Status]++
+	}
+	return counts, nil
+}
+
+func (o *inMemoryOutbox) OldestPendingAt(_ context.Context) (*time.Time, error) {
+	var oldest *time.Time
+	for i := range o.rows {
+		row := &o.rows[i]
+		if row.Status != m_outbox.StatusPending {
+			continue
+		}
+		if oldest == nil || row.CreatedAt.Before(*oldest) {
+			oldest = &row.CreatedAt
+		}
+	}
+	return oldest, nil
+}
+
+func (o *inMemoryOutbox) FetchPending(_ context.Context, limit int) ([]m_outbox.OutboxEventRow, error) {
+	var pending []m_outbox.OutboxEventRow
+	for _, row := range o.rows {
+		if row.Status == m_outbox.StatusPending {
+			pending = append(pending, row)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (o *inMemoryOutbox) MarkProcessed(_ context.Context, eventID string) error {
+	row := o.find(eventID)
+	row.Status = m_outbox.StatusProcessed
+	return nil
+}
+
+func (o *inMemoryOutbox) RecordFailure(_ context.Context, eventID string, attempts int64, status string) error {
+	row := o.find(eventID)
+	row.Attempts = attempts
+	row.Status = status
+	return nil
+}
+
+func (o *inMemoryOutbox) ScanEvents(_ context.Context, filter outbox.ReplayFilter, fn func(m_outbox.OutboxEventRow) error) error {
+	rows := slices.Clone(o.rows)
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].CreatedAt.Equal(rows[j].CreatedAt) {
+			return rows[i].CreatedAt.Before(rows[j].CreatedAt)
+		}
+		return rows[i].EventID < rows[j].EventID
+	})
+	for _, row := range rows {
+		if len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, row.EventType) {
+			continue
+		}
+		if filter.Since != nil && row.CreatedAt.Before(*filter.Since) {
+			continue
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *inMemoryOutbox) find(eventID string) *m_outbox.OutboxEventRow {
+	for i := range o.rows {
+		if o.rows[i].EventID == eventID {
+			return &o.rows[i]
+		}
+	}
+	return nil
+}
+
+// failingPublisher rejects every event whose ID is in poison and records the rest.
+type failingPublisher struct {
+	poison    map[string]bool
+	published []string
+}
+
+func (p *failingPublisher) Publish(_ context.Context, event m_outbox.OutboxEventRow) error {
+	if p.poison[event.EventID] {
+		return errors.New("broker rejected event")
+	}
+	p.published = append(p.published, event.EventID)
+	return nil
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Outbox status query
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestOutboxStatus_MixedStatuses(t *testing.T) {
+	store := &inMemoryOutbox{rows: []m_outbox.OutboxEventRow{
+		{EventID: "e1", Status: m_outbox.StatusProcessed, CreatedAt: baseTime.Add(-3 * time.Hour)},
+		{EventID: "e2", Status: m_outbox.StatusPending, CreatedAt: baseTime.Add(-90 * time.Minute)},
+		{EventID: "e3", Status: m_outbox.StatusPending, CreatedAt: baseTime.Add(-10 * time.Minute)},
+		{EventID: "e4", Status: m_outbox.StatusProcessed, CreatedAt: baseTime.Add(-time.Minute)},
+		{EventID: "e5", Status: m_outbox.StatusProcessed, CreatedAt: baseTime},
+	}}
+
+	q := outbox.NewStatusQuery(store, newTicker(baseTime))
+	dto, err := q.Execute(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.Pending != 2 {
+		t.Fatalf("expected 2 pending, got %d", dto.Pending)
+	}
+	if dto.Processed != 3 {
+		t.Fatalf("expected 3 processed, got %d", dto.Processed)
+	}
+	if dto.OldestPendingAge != 90*time.Minute {
+		t.Fatalf("expected oldest pending age 1h30m, got %s", dto.OldestPendingAge)
+	}
+}
+
+func TestOutboxStatus_NothingPending(t *testing.T) {
+	store := &inMemoryOutbox{rows: []m_outbox.OutboxEventRow{
+		{EventID: "e1", Status: m_outbox.StatusProcessed, CreatedAt: baseTime.Add(-time.Hour)},
+	}}
+
+	q := outbox.NewStatusQuery(store, newTicker(baseTime))
+	dto, err := q.Execute(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.Pending != 0 || dto.OldestPendingAge != 0 {
+		t.Fatalf("expected no pending backlog, got pending=%d age=%s", dto.Pending, dto.OldestPendingAge)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Outbox relay
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestRelay_DeadLettersPoisonEvent(t *testing.T) {
+	store := &inMemoryOutbox{rows: []m_outbox.OutboxEventRow{
+		{EventID: "poison", Status: m_outbox.StatusPending, CreatedAt: baseTime.Add(-time.Hour)},
+		{EventID: "ok-1", Status: m_outbox.StatusPending, CreatedAt: baseTime.Add(-30 * time.Minute)},
+	}}
+	publisher := &failingPublisher{poison: map[string]bool{"poison": true}}
+	relay := outbox.NewRelay(store, publisher,
+		outbox.RelayConfig{BatchSize: 10, MaxAttempts: 3}, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		if _, err := relay.RunOnce(context.Background()); err != nil {
+			t.Fatalf("run %d: %v", i, err)
+		}
+		// A new event arrives after the poison one; it must not be blocked.
+		if i == 1 {
+			store.rows = append(store.rows, m_outbox.OutboxEventRow{
+				EventID: "ok-2", Status: m_outbox.StatusPending, CreatedAt: baseTime,
+			})
+		}
+	}
+
+	if got := store.find("poison"); got.Status != m_outbox.StatusDead || got.Attempts != 3 {
+		t.Fatalf("expected poison event dead after 3 attempts, got status=%s attempts=%d", got.Status, got.Attempts)
+	}
+	for _, id := range []string{"ok-1", "ok-2"} {
+		if got := store.find(id); got.Status != m_outbox.StatusProcessed {
+			t.Fatalf("expected %s processed, got %s", id, got.Status)
+		}
+	}
+
+	// Dead events are no longer fetched.
+	n, err := relay.RunOnce(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("expected empty run after dead-lettering, got n=%d err=%v", n, err)
+	}
+
+	dto, err := outbox.NewStatusQuery(store, newTicker(baseTime)).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if dto.Dead != 1 || dto.Pending != 0 {
+		t.Fatalf("expected dead=1 pending=0, got dead=%d pending=%d", dto.Dead, dto.Pending)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Outbox replay
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestReplay_EmitsInOrderWithoutMutatingRows(t *testing.T) {
+	store := &inMemoryOutbox{rows: []m_outbox.OutboxEventRow{
+		{EventID: "e3", EventType: "product.updated", Status: m_outbox.StatusPending, CreatedAt: baseTime},
+		{EventID: "e1", EventType: "product.created", Status: m_outbox.StatusProcessed, CreatedAt: baseTime.Add(-2 * time.Hour)},
+		{EventID: "e2", EventType: "product.created", Status: m_outbox.StatusDead, Attempts: 5, CreatedAt: baseTime.Add(-time.Hour)},
+	}}
+	before := slices.Clone(store.rows)
+	publisher := &failingPublisher{}
+
+	n, err := outbox.NewReplayer(store).Replay(context.Background(), outbox.ReplayFilter{}, publisher)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 3 || !slices.Equal(publisher.published, []string{"e1", "e2", "e3"}) {
+		t.Fatalf("expected e1,e2,e3 in order, got %v", publisher.published)
+	}
+	if !slices.Equal(store.rows, before) {
+		t.Fatal("replay must not change outbox rows")
+	}
+}
+
+func TestReplay_FiltersByTypeAndSince(t *testing.T) {
+	store := &inMemoryOutbox{rows: []m_outbox.OutboxEventRow{
+		{EventID: "e1", EventType: "product.created", CreatedAt: baseTime.Add(-2 * time.Hour)},
+		{EventID: "e2", EventType: "product.created", CreatedAt: baseTime.Add(-time.Hour)},
+		{EventID: "e3", EventType: "product.updated", CreatedAt: baseTime},
+	}}
+	publisher := &failingPublisher{}
+	since := baseTime.Add(-90 * time.Minute)
+
+	_, err := outbox.NewReplayer(store).Replay(context.Background(),
+		outbox.ReplayFilter{EventTypes: []string{"product.created"}, Since: &since}, publisher)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(publisher.published, []string{"e2"}) {
+		t.Fatalf("expected only e2, got %v", publisher.published)
+	}
+}
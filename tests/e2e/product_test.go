@@ -3,25 +3,49 @@
 package integration_test
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/product-catalog-service/common"
 	"github.com/product-catalog-service/common/commitplanner"
 	"github.com/product-catalog-service/internal/app/product/contract"
 	"github.com/product-catalog-service/internal/app/product/domain"
 	"github.com/product-catalog-service/internal/app/product/domain/services"
+	"github.com/product-catalog-service/internal/app/product/queries/analytics"
+	checkexistence "github.com/product-catalog-service/internal/app/product/queries/check_existence"
+	discountpreview "github.com/product-catalog-service/internal/app/product/queries/discount_preview"
+	effectivepricebackfill "github.com/product-catalog-service/internal/app/product/queries/effective_price_backfill"
 	getproduct "github.com/product-catalog-service/internal/app/product/queries/get_product"
 	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	listsubcategories "github.com/product-catalog-service/internal/app/product/queries/list_subcategories"
+	pricepreview "github.com/product-catalog-service/internal/app/product/queries/price_preview"
+	pricestats "github.com/product-catalog-service/internal/app/product/queries/price_stats"
 	activateproduct "github.com/product-catalog-service/internal/app/product/usecases/activate_product"
 	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	applydiscountschedule "github.com/product-catalog-service/internal/app/product/usecases/apply_discount_schedule"
+	bulkactivateproducts "github.com/product-catalog-service/internal/app/product/usecases/bulk_activate_products"
+	bulkremovediscount "github.com/product-catalog-service/internal/app/product/usecases/bulk_remove_discount"
 	createproduct "github.com/product-catalog-service/internal/app/product/usecases/create_product"
 	deactivateproduct "github.com/product-catalog-service/internal/app/product/usecases/deactivate_product"
+	forcecleardiscount "github.com/product-catalog-service/internal/app/product/usecases/force_clear_discount"
+	publishscheduled "github.com/product-catalog-service/internal/app/product/usecases/publish_scheduled"
 	removediscount "github.com/product-catalog-service/internal/app/product/usecases/remove_discount"
+	renamecategory "github.com/product-catalog-service/internal/app/product/usecases/rename_category"
+	unpublishscheduled "github.com/product-catalog-service/internal/app/product/usecases/unpublish_scheduled"
 	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
 )
 
@@ -36,13 +60,15 @@ func (f fixedTicker) Now() time.Time { return f.t }
 
 func newTicker(t time.Time) common.Ticker { return fixedTicker{t} }
 
-// mockCommitter records whether Apply was called and can be made to return an error.
+// mockCommitter records whether (and how often) Apply was called and can be made to return an error.
 type mockCommitter struct {
 	applied bool
+	calls   int
 	err     error
 }
 
 func (m *mockCommitter) Apply(_ context.Context, _ *commitplanner.Plan) error {
+	m.calls++
 	if m.err != nil {
 		return m.err
 	}
@@ -52,12 +78,23 @@ func (m *mockCommitter) Apply(_ context.Context, _ *commitplanner.Plan) error {
 
 // inMemoryProductRepo is a simple map-backed implementation of both
 // contract.ProductRepository and contract.QueryRepository.
+// createdAt stands in for the commit-timestamp column; InsertMut stamps baseTime
+// and tests may override individual entries.
 type inMemoryProductRepo struct {
-	store map[string]*domain.Product
+	store     map[string]*domain.Product
+	createdAt map[string]time.Time
+	counts    int              // number of CountActive calls
+	existsIDs []string         // ids passed to the last ExistsBatch call
+	batchIDs  []string         // ids passed to the last GetByIDs call
+	written   [][]domain.Field // dirty fields seen by each UpdateMut call
+	corrupt   map[string]bool  // ids whose stored discount fails to decode
 }
 
 func newInMemoryProductRepo() *inMemoryProductRepo {
-	return &inMemoryProductRepo{store: make(map[string]*domain.Product)}
+	return &inMemoryProductRepo{
+		store:     make(map[string]*domain.Product),
+		createdAt: make(map[string]time.Time),
+	}
 }
 
 func (r *inMemoryProductRepo) GetByID(_ context.Context, id string) (*domain.Product, error) {
@@ -65,32 +102,153 @@ func (r *inMemoryProductRepo) GetByID(_ context.Context, id string) (*domain.Pro
 	if !ok {
 		return nil, domain.ErrProductNotFound
 	}
+	if r.corrupt[id] {
+		return nil, fmt.Errorf("GetByID decode: %w", domain.ErrDiscountInvalidPercentage)
+	}
 	return p, nil
 }
 
+func (r *inMemoryProductRepo) GetBySlug(_ context.Context, slug string) (*domain.Product, error) {
+	for _, p := range r.store {
+		if p.Slug() == slug {
+			return p, nil
+		}
+	}
+	return nil, domain.ErrProductNotFound
+}
+
+func (r *inMemoryProductRepo) SlugsWithPrefix(_ context.Context, base string) ([]string, error) {
+	var slugs []string
+	for _, p := range r.store {
+		if p.Slug() == base || strings.HasPrefix(p.Slug(), base+"-") {
+			slugs = append(slugs, p.Slug())
+		}
+	}
+	return slugs, nil
+}
+
+func (r *inMemoryProductRepo) ListDuePublish(_ context.Context, now time.Time, limit int) ([]*domain.Product, error) {
+	var due []*domain.Product
+	for _, p := range r.store {
+		if p.PublishDue(now) {
+			due = append(due, p)
+		}
+	}
+	slices.SortFunc(due, func(a, b *domain.Product) int {
+		if c := a.PublishAt().Compare(*b.PublishAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID(), b.ID())
+	})
+	return due[:min(limit, len(due))], nil
+}
+
+func (r *inMemoryProductRepo) ListDueUnpublish(_ context.Context, now time.Time, limit int) ([]*domain.Product, error) {
+	var due []*domain.Product
+	for _, p := range r.store {
+		if p.UnpublishDue(now) {
+			due = append(due, p)
+		}
+	}
+	slices.SortFunc(due, func(a, b *domain.Product) int {
+		if c := a.UnpublishAt().Compare(*b.UnpublishAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID(), b.ID())
+	})
+	return due[:min(limit, len(due))], nil
+}
+
 func (r *inMemoryProductRepo) InsertMut(p *domain.Product) *spanner.Mutation {
 	// In the e2e flow the committer calls Apply, but our mockCommitter doesn't
 	// touch Spanner. We persist directly here so the query side can find the product.
 	r.store[p.ID()] = p
+	r.createdAt[p.ID()] = baseTime
 	return nil // nil mutations are skipped by plan.Add callers
 }
 
 func (r *inMemoryProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
 	r.store[p.ID()] = p
+	r.written = append(r.written, p.Changes().Fields())
+	return nil
+}
+
+func (r *inMemoryProductRepo) ForceClearDiscountMut(id string) *spanner.Mutation {
+	delete(r.corrupt, id)
 	return nil
 }
 
+func (r *inMemoryProductRepo) ListByCategory(_ context.Context, category string) ([]*domain.Product, error) {
+	var result []*domain.Product
+	for _, p := range r.store {
+		if p.InCategory(category) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryProductRepo) ListDiscountedByCategory(_ context.Context, category string) ([]*domain.Product, error) {
+	var result []*domain.Product
+	for _, p := range r.store {
+		if p.Status() == domain.ProductStatusActive && p.InCategory(category) && p.Discount() != nil {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 func (r *inMemoryProductRepo) ListActive(_ context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []domain.ProductStatus{domain.ProductStatusActive}
+	}
+
 	var result []*domain.Product
 	for _, p := range r.store {
-		if p.Status() != domain.ProductStatusActive {
+		if !slices.Contains(statuses, p.Status()) {
+			continue
+		}
+		if len(filter.Categories) > 0 {
+			if !slices.ContainsFunc(filter.Categories, p.InCategory) {
+				continue
+			}
+		} else if filter.Category != nil && !p.InCategory(*filter.Category) {
+			continue
+		}
+		createdAt := r.createdAt[p.ID()]
+		if filter.CreatedAfter != nil && createdAt.Before(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !createdAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		if filter.Featured != nil && p.IsFeatured() != *filter.Featured {
+			continue
+		}
+		if filter.InStockOnly && !p.InStock() {
 			continue
 		}
-		if filter.Category != nil && p.Category() != *filter.Category {
+		if a := filter.Attribute; a != nil {
+			if v, ok := p.Attribute(a.Key); !ok || v != a.Value {
+				continue
+			}
+		}
+		if at := filter.OnSaleAt; at != nil && (p.Discount() == nil || !p.Discount().IsValidAt(*at)) {
 			continue
 		}
 		result = append(result, p)
 	}
+	// Mirror the Spanner default ORDER BY featured DESC, product_id.
+	slices.SortFunc(result, func(a, b *domain.Product) int {
+		if a.IsFeatured() != b.IsFeatured() {
+			if a.IsFeatured() {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ID(), b.ID())
+	})
 	// Apply offset + limit
 	if page.Offset >= len(result) {
 		return []*domain.Product{}, nil
@@ -102,14 +260,82 @@ func (r *inMemoryProductRepo) ListActive(_ context.Context, filter contract.List
 	return result, nil
 }
 
-// inMemoryEventRepo just discards mutations (no Spanner in e2e).
+func (r *inMemoryProductRepo) CountActive(ctx context.Context, filter contract.ListProductsFilter) (int, error) {
+	r.counts++
+	all, err := r.ListActive(ctx, filter, contract.Page{})
+	return len(all), err
+}
+
+func (r *inMemoryProductRepo) ExistsBatch(_ context.Context, ids []string) (map[string]bool, error) {
+	r.existsIDs = ids
+	exists := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		_, exists[id] = r.store[id]
+	}
+	return exists, nil
+}
+
+func (r *inMemoryProductRepo) ListActiveSummaries(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+	return r.ListActive(ctx, filter, page)
+}
+
+// ListActiveSummariesByDiscount mirrors the Spanner ORDER BY on the discount
+// valid at at, falling back to the default ordering on ties.
+func (r *inMemoryProductRepo) ListActiveSummariesByDiscount(ctx context.Context, filter contract.ListProductsFilter, at time.Time, page contract.Page) ([]*domain.Product, error) {
+	all, err := r.ListActive(ctx, filter, contract.Page{})
+	if err != nil {
+		return nil, err
+	}
+	depth := func(p *domain.Product) float64 {
+		if d := p.Discount(); d != nil && d.IsValidAt(at) {
+			return d.PercentageFloat64()
+		}
+		return 0
+	}
+	slices.SortStableFunc(all, func(a, b *domain.Product) int {
+		return cmp.Compare(depth(b), depth(a))
+	})
+	if page.Offset >= len(all) {
+		return []*domain.Product{}, nil
+	}
+	return all[page.Offset:min(page.Offset+page.Limit, len(all))], nil
+}
+
+// GetByIDs returns the stored products among ids in reverse request order, so
+// callers that rely on it matching the request fail.
+func (r *inMemoryProductRepo) GetByIDs(_ context.Context, ids []string) ([]*domain.Product, error) {
+	r.batchIDs = ids
+	var products []*domain.Product
+	for _, id := range slices.Backward(ids) {
+		if p, ok := r.store[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+// inMemoryCategoryRepo serves a fixed taxonomy.
+type inMemoryCategoryRepo struct {
+	categories []*domain.Category
+}
+
+func (r *inMemoryCategoryRepo) ListAll(_ context.Context) ([]*domain.Category, error) {
+	return r.categories, nil
+}
+
+// inMemoryEventRepo records events and returns empty mutations (no Spanner in e2e).
+// When err is set every InsertMut fails with it, as for an unserialisable event.
 type inMemoryEventRepo struct {
 	events []domain.DomainEvent
+	err    error
 }
 
-func (r *inMemoryEventRepo) InsertMut(event domain.DomainEvent) *spanner.Mutation {
+func (r *inMemoryEventRepo) InsertMut(event domain.DomainEvent) (*spanner.Mutation, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
 	r.events = append(r.events, event)
-	return nil
+	return &spanner.Mutation{}, nil
 }
 
 // ────────────────────────────────────────────────────────────────────────────
@@ -131,14 +357,18 @@ func buildDeps(t *testing.T) (
 	return newInMemoryProductRepo(), &inMemoryEventRepo{}, &mockCommitter{}, newTicker(baseTime)
 }
 
+// amount returns a pointer to a base price in the smallest currency unit.
+func amount(v int64) *int64 { return &v }
+
 // createOne is a test utility that runs CreateProduct and returns the new product ID.
 func createOne(t *testing.T, repo *inMemoryProductRepo, eventRepo *inMemoryEventRepo, committer *mockCommitter, ticker common.Ticker, name, category string) string {
 	t.Helper()
-	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
 	id, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
 		Name:        name,
 		Description: "a product",
 		Category:    category,
+		Amount:      amount(100),
 	})
 	if err != nil {
 		t.Fatalf("createOne: %v", err)
@@ -152,12 +382,13 @@ func createOne(t *testing.T, repo *inMemoryProductRepo, eventRepo *inMemoryEvent
 
 func TestCreateProduct_Success(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
 
 	id, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
 		Name:        "Laptop",
 		Description: "High-end laptop",
 		Category:    "electronics",
+		Amount:      amount(100),
 	})
 
 	if err != nil {
@@ -176,11 +407,12 @@ func TestCreateProduct_Success(t *testing.T) {
 
 func TestCreateProduct_EmptyName(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
 
 	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
 		Name:     "",
 		Category: "electronics",
+		Amount:   amount(100),
 	})
 
 	if !errors.Is(err, domain.ErrProductNameRequired) {
@@ -188,14 +420,66 @@ func TestCreateProduct_EmptyName(t *testing.T) {
 	}
 }
 
+func TestCreateProduct_EmptyCategory(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+
+	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name:     "Laptop",
+		Category: "",
+		Amount:   amount(100),
+	})
+
+	if !errors.Is(err, domain.ErrProductCategoryRequired) {
+		t.Fatalf("expected ErrProductCategoryRequired, got %v", err)
+	}
+	if committer.applied {
+		t.Fatal("committer must not be called for an invalid product")
+	}
+}
+
+func TestCreateProduct_ReportsAllInvalidFields(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+
+	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name:        "",
+		Description: strings.Repeat("x", domain.MaxDescriptionLength+1),
+		Category:    "",
+	})
+
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	got := map[string]error{}
+	for _, f := range verr.Fields {
+		got[f.Field] = f.Err
+	}
+	if len(got) != 4 ||
+		!errors.Is(got["name"], domain.ErrProductNameRequired) ||
+		!errors.Is(got["category"], domain.ErrProductCategoryRequired) ||
+		!errors.Is(got["description"], domain.ErrProductFieldTooLong) ||
+		!errors.Is(got["price"], domain.ErrProductBasePriceRequired) {
+		t.Fatalf("unexpected field errors %v", verr.Fields)
+	}
+	if !errors.Is(err, domain.ErrProductNameRequired) {
+		t.Fatal("ValidationError should still match the underlying sentinels")
+	}
+	if committer.calls != 0 {
+		t.Fatal("committer must not be called for an invalid request")
+	}
+}
+
 func TestCreateProduct_CommitterError(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	committer.err = errors.New("spanner unavailable")
-	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
 
 	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
 		Name:     "Laptop",
 		Category: "electronics",
+		Amount:   amount(100),
 	})
 
 	if err == nil {
@@ -203,6 +487,89 @@ func TestCreateProduct_CommitterError(t *testing.T) {
 	}
 }
 
+func TestCreateProduct_CurrencyResolvedPerCategory(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	cfg := createproduct.Config{
+		DefaultCurrency:    "USD",
+		CategoryCurrencies: map[string]string{"groceries": "VND"},
+	}
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, cfg)
+
+	for _, tc := range []struct {
+		category, price, currency string
+		wantCurrency              string
+		wantAmount                int64
+	}{
+		{"electronics", "19.99", "", "USD", 1999},
+		{"groceries", "25000", "", "VND", 25000},
+		{"groceries", "3.50", "EUR", "EUR", 350}, // explicit currency wins
+	} {
+		id, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+			Name:     "Item",
+			Category: tc.category,
+			Price:    tc.price,
+			Currency: tc.currency,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tc.category, err)
+		}
+		got := repo.store[id].BasePrice()
+		if got.Currency() != tc.wantCurrency || got.Amount() != tc.wantAmount {
+			t.Fatalf("%s: expected %d %s, got %s", tc.category, tc.wantAmount, tc.wantCurrency, got)
+		}
+	}
+}
+
+func TestCreateProduct_AmountInMinorUnits(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+
+	id, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name:     "Phone",
+		Category: "electronics",
+		Amount:   amount(2500000),
+		Currency: "VND",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := repo.store[id].BasePrice(); got.Amount() != 2500000 || got.Currency() != "VND" {
+		t.Fatalf("expected 2500000 VND, got %s", got)
+	}
+
+	for _, tc := range []struct {
+		amount   int64
+		currency string
+		want     error
+	}{
+		{-1, "USD", domain.ErrNegativeAmount},
+		{100, "dollars", domain.ErrInvalidCurrency},
+	} {
+		_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+			Name:     "Phone",
+			Category: "electronics",
+			Amount:   amount(tc.amount),
+			Currency: tc.currency,
+		})
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%d %s: expected %v, got %v", tc.amount, tc.currency, tc.want, err)
+		}
+	}
+}
+
+func TestCreateProductConfig_ValidateRejectsBadCurrency(t *testing.T) {
+	cfg := createproduct.Config{
+		DefaultCurrency:    "USD",
+		CategoryCurrencies: map[string]string{"groceries": "dong"},
+	}
+	if err := cfg.Validate(); !errors.Is(err, domain.ErrInvalidCurrency) {
+		t.Fatalf("expected ErrInvalidCurrency, got %v", err)
+	}
+	if err := createproduct.DefaultConfig().Validate(); err != nil {
+		t.Fatalf("expected the default config to be valid, got %v", err)
+	}
+}
+
 // ────────────────────────────────────────────────────────────────────────────
 // UpdateProduct
 // ────────────────────────────────────────────────────────────────────────────
@@ -214,7 +581,7 @@ func TestUpdateProduct_Success(t *testing.T) {
 
 	newName := "New Name"
 	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
 		ProductID: id,
 		Name:      &newName,
 	})
@@ -225,424 +592,4464 @@ func TestUpdateProduct_Success(t *testing.T) {
 	if repo.store[id].Name() != newName {
 		t.Fatalf("expected name %q, got %q", newName, repo.store[id].Name())
 	}
+	if !res.Changed || !slices.Equal(res.ChangedFields, []domain.Field{domain.FieldName}) {
+		t.Fatalf("expected only name to change, got %+v", res)
+	}
 }
 
-func TestUpdateProduct_ProductNotFound(t *testing.T) {
+func TestUpdateProduct_SecondCommitWritesOnlyNewFields(t *testing.T) {
+	// The in-memory repository reuses the aggregate, so dirty flags left over
+	// from the first commit would leak into the second mutation.
 	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Old Name", "electronics")
 	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
 
-	err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
-		ProductID: "non-existent-id",
-	})
+	newName := "New Name"
+	if _, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Name: &newName}); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	newDesc := "Now with more RAM"
+	if _, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Description: &newDesc}); err != nil {
+		t.Fatalf("describe: %v", err)
+	}
 
-	if !errors.Is(err, domain.ErrProductNotFound) {
-		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	if len(repo.written) != 2 {
+		t.Fatalf("expected 2 update mutations, got %d", len(repo.written))
+	}
+	if !slices.Equal(repo.written[1], []domain.Field{domain.FieldDescription}) {
+		t.Fatalf("expected only description on the second commit, got %v", repo.written[1])
+	}
+	if fields := repo.store[id].Changes().Fields(); len(fields) != 0 {
+		t.Fatalf("expected no dirty fields after commit, got %v", fields)
 	}
 }
 
-// ────────────────────────────────────────────────────────────────────────────
-// ApplyDiscount
-// ────────────────────────────────────────────────────────────────────────────
+func TestUpdateProduct_EmptyRequestRejected(t *testing.T) {
+	_, eventRepo, committer, ticker := buildDeps(t)
 
-func TestApplyDiscount_Success(t *testing.T) {
+	// A nil repository panics if the interactor tries to load the product.
+	it := updateproduct.NewUpdateProductInteractor(committer, nil, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: "p-1"})
+
+	if !errors.Is(err, domain.ErrNoFieldsToUpdate) {
+		t.Fatalf("expected ErrNoFieldsToUpdate, got %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit for an empty update")
+	}
+}
+
+func TestUpdateProduct_NoChange(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
 
-	startsAt := baseTime.Add(-time.Hour)
-	endsAt := baseTime.Add(24 * time.Hour)
-
-	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
-		ProductID:  id,
-		Percentage: "10",
-		StartsAt:   startsAt,
-		EndsAt:     endsAt,
+	sameName, sameCategory := "Laptop", "electronics"
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Name:      &sameName,
+		Category:  &sameCategory,
+		Discount:  &updateproduct.DiscountUpdate{Clear: true},
 	})
 
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if repo.store[id].Discount() == nil {
-		t.Fatal("expected discount to be set")
+	if res.Changed || len(res.ChangedFields) != 0 {
+		t.Fatalf("expected no changes, got %+v", res)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit for a no-op update")
 	}
 }
 
-func TestApplyDiscount_InvalidPeriod(t *testing.T) {
+func TestUpdateProduct_NameAndDiscountInOneCommit(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	id := createOne(t, repo, eventRepo, committer, ticker, "Old Name", "electronics")
+	committer.calls = 0
+	repo.store[id].ClearEvents()
+	eventRepo.events = nil
 
-	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
-		ProductID:  id,
-		Percentage: "10",
-		StartsAt:   baseTime.Add(time.Hour), // starts AFTER endsAt
-		EndsAt:     baseTime.Add(-time.Hour),
+	newName := "New Name"
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Name:      &newName,
+		Discount: &updateproduct.DiscountUpdate{
+			Percentage: "25",
+			StartsAt:   baseTime.Add(-time.Hour),
+			EndsAt:     baseTime.Add(24 * time.Hour),
+		},
 	})
 
-	if !errors.Is(err, domain.ErrDiscountInvalidPeriod) {
-		t.Fatalf("expected ErrDiscountInvalidPeriod, got %v", err)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if committer.calls != 1 {
+		t.Fatalf("expected a single commit, got %d", committer.calls)
+	}
+	p := repo.store[id]
+	if p.Name() != newName || p.Discount() == nil || p.Discount().Percentage() != "25.0" {
+		t.Fatalf("expected name and discount to be updated, got name=%q discount=%v", p.Name(), p.Discount())
+	}
+	if len(eventRepo.events) != 2 {
+		t.Fatalf("expected updated + discount_applied events, got %d", len(eventRepo.events))
+	}
+	if _, ok := eventRepo.events[0].(*domain.ProductUpdatedEvent); !ok {
+		t.Fatalf("expected ProductUpdatedEvent first, got %T", eventRepo.events[0])
+	}
+	if _, ok := eventRepo.events[1].(*domain.DiscountAppliedEvent); !ok {
+		t.Fatalf("expected DiscountAppliedEvent second, got %T", eventRepo.events[1])
 	}
 }
 
-func TestApplyDiscount_NotActive(t *testing.T) {
+func TestUpdateProduct_InvalidDiscountRejected(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
 
-	// Deactivate the product first
-	deactivateTicker := newTicker(baseTime)
-	_ = deactivateTicker
-	p := repo.store[id]
-	_ = p.Deactivate(baseTime)
-
-	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
-		ProductID:  id,
-		Percentage: "10",
-		StartsAt:   baseTime.Add(-time.Hour),
-		EndsAt:     baseTime.Add(24 * time.Hour),
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Discount: &updateproduct.DiscountUpdate{
+			Percentage: "150",
+			StartsAt:   baseTime.Add(-time.Hour),
+			EndsAt:     baseTime.Add(24 * time.Hour),
+		},
 	})
 
-	if !errors.Is(err, domain.ErrProductNotActive) {
-		t.Fatalf("expected ErrProductNotActive, got %v", err)
+	if !errors.Is(err, domain.ErrDiscountInvalidPercentage) {
+		t.Fatalf("expected ErrDiscountInvalidPercentage, got %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit on invalid discount")
 	}
 }
 
-// ────────────────────────────────────────────────────────────────────────────
-// ActivateProduct
-// ────────────────────────────────────────────────────────────────────────────
-
-func TestActivateProduct_AlreadyActive(t *testing.T) {
+func TestUpdateProduct_BackdatedDiscountRejected(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
 
-	// Activate an already-active product → should be a no-op (idempotent)
-	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: id})
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, updateproduct.WithMaxBackdating(24*time.Hour))
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Discount: &updateproduct.DiscountUpdate{
+			Percentage: "10",
+			StartsAt:   baseTime.Add(-3 * 365 * 24 * time.Hour),
+			EndsAt:     baseTime.Add(24 * time.Hour),
+		},
+	})
 
-	if err != nil {
-		t.Fatalf("expected no error for idempotent activate, got %v", err)
+	if !errors.Is(err, domain.ErrDiscountBackdatedTooFar) {
+		t.Fatalf("expected ErrDiscountBackdatedTooFar, got %v", err)
+	}
+	if committer.calls != 0 || repo.store[id].Discount() != nil {
+		t.Fatal("expected the backdated discount neither applied nor committed")
 	}
 }
 
-func TestActivateProduct_FromInactive(t *testing.T) {
+func TestUpdateProduct_DiscountWithinClockSkewAccepted(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	id := createOne(t, repo, eventRepo, committer, ticker, "Lamp", "home")
-
-	// Deactivate first
-	_ = repo.store[id].Deactivate(baseTime)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	req := &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Discount: &updateproduct.DiscountUpdate{
+			Percentage: "10",
+			StartsAt:   baseTime.Add(2 * time.Second), // the client's clock runs ahead
+			EndsAt:     baseTime.Add(24 * time.Hour),
+		},
+	}
 
-	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: id})
+	strict := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	if _, err := strict.Execute(context.Background(), req); !errors.Is(err, domain.ErrInvalidDiscountPeriod) {
+		t.Fatalf("expected ErrInvalidDiscountPeriod without a tolerance, got %v", err)
+	}
 
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	tolerant := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, updateproduct.WithClockSkewTolerance(5*time.Second))
+	if _, err := tolerant.Execute(context.Background(), req); err != nil {
+		t.Fatalf("expected the discount accepted within the tolerance, got %v", err)
 	}
-	if repo.store[id].Status() != domain.ProductStatusActive {
-		t.Fatal("expected product to be active")
+	if repo.store[id].Discount() == nil {
+		t.Fatal("expected the discount stored")
 	}
 }
 
-// ────────────────────────────────────────────────────────────────────────────
-// GetProduct query
-// ────────────────────────────────────────────────────────────────────────────
-
-func TestGetProduct_WithNoDiscount(t *testing.T) {
+func TestUpdateProduct_EmptyCategoryRejected(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	id := createOne(t, repo, eventRepo, committer, ticker, "Headphones", "electronics")
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
 
-	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
-	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	empty := ""
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		Category:  &empty,
+	})
 
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	if !errors.Is(err, domain.ErrProductCategoryRequired) {
+		t.Fatalf("expected ErrProductCategoryRequired, got %v", err)
 	}
-	if dto.ID != id {
-		t.Fatalf("expected ID %q, got %q", id, dto.ID)
+	if committer.calls != 0 {
+		t.Fatal("expected no commit on empty category")
 	}
-	if dto.Discount != nil {
-		t.Fatal("expected no discount")
+}
+
+func TestUpdateProduct_CurrencyChangeRejected(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
+
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		BasePrice: &updateproduct.PriceUpdate{Amount: 25000000, Currency: "VND"},
+	})
+
+	if !errors.Is(err, domain.ErrCurrencyChangeNotAllowed) {
+		t.Fatalf("expected ErrCurrencyChangeNotAllowed, got %v", err)
 	}
-	if dto.EffectivePrice.Amount != dto.BasePrice.Amount {
-		t.Fatal("effective price should equal base price when no discount")
+	if committer.calls != 0 {
+		t.Fatal("expected no commit on a currency change")
+	}
+	if got := repo.store[id].BasePrice().Currency(); got != "USD" {
+		t.Fatalf("expected the price to stay in USD, got %s", got)
 	}
 }
 
-func TestGetProduct_WithActiveDiscount(t *testing.T) {
+func TestUpdateProduct_CurrencyCorrectionAllowed(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	// Apply 20% discount
-	startsAt := baseTime.Add(-time.Hour)
-	endsAt := baseTime.Add(24 * time.Hour)
-	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	_ = it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
-		ProductID:  id,
-		Percentage: "20",
-		StartsAt:   startsAt,
-		EndsAt:     endsAt,
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:           id,
+		BasePrice:           &updateproduct.PriceUpdate{Amount: 25000000, Currency: "VND"},
+		AllowCurrencyChange: true,
 	})
 
-	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
-	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
-
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if dto.Discount == nil || !dto.Discount.IsActive {
-		t.Fatal("expected active discount on DTO")
-	}
-	if dto.EffectivePrice.Amount >= dto.BasePrice.Amount {
-		t.Fatal("expected effective price to be lower than base price")
+	if got := repo.store[id].BasePrice(); got.Amount() != 25000000 || got.Currency() != "VND" {
+		t.Fatalf("expected 25000000 VND, got %d %s", got.Amount(), got.Currency())
 	}
 }
 
-func TestGetProduct_NotFound(t *testing.T) {
-	repo, _, _, ticker := buildDeps(t)
-	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+func TestUpdateProduct_FieldChangeEvents(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Old Name", "electronics")
+	repo.store[id].ClearEvents()
+	eventRepo.events = nil
+	oldDesc := repo.store[id].Description()
+	oldPrice := repo.store[id].BasePrice()
 
-	_, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: "ghost"})
+	newName, newDesc := "New Name", "New description"
+	newPrice := domain.MustNewMoney(oldPrice.Amount()+500, oldPrice.Currency())
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, updateproduct.WithFieldChangeEvents())
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:   id,
+		Name:        &newName,
+		Description: &newDesc,
+		BasePrice:   &updateproduct.PriceUpdate{Amount: newPrice.Amount()},
+	})
 
-	if !errors.Is(err, domain.ErrProductNotFound) {
-		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(eventRepo.events) != 3 {
+		t.Fatalf("expected 3 field events, got %d", len(eventRepo.events))
+	}
+	name, ok := eventRepo.events[0].(*domain.ProductNameChangedEvent)
+	if !ok || name.From() != "Old Name" || name.To() != newName {
+		t.Fatalf("expected name change Old Name -> %s, got %#v", newName, eventRepo.events[0])
+	}
+	desc, ok := eventRepo.events[1].(*domain.ProductDescriptionChangedEvent)
+	if !ok || desc.From() != oldDesc || desc.To() != newDesc {
+		t.Fatalf("expected description change %q -> %q, got %#v", oldDesc, newDesc, eventRepo.events[1])
+	}
+	price, ok := eventRepo.events[2].(*domain.ProductPriceChangedEvent)
+	if !ok || price.From().Amount() != oldPrice.Amount() || price.To().Amount() != newPrice.Amount() {
+		t.Fatalf("expected price change %d -> %d, got %#v", oldPrice.Amount(), newPrice.Amount(), eventRepo.events[2])
+	}
+	for i, e := range eventRepo.events {
+		if e.Sequence() != int64(i+1) {
+			t.Fatalf("event %d: expected sequence %d, got %d", i, i+1, e.Sequence())
+		}
 	}
 }
 
-// ────────────────────────────────────────────────────────────────────────────
-// ListProducts query
-// ────────────────────────────────────────────────────────────────────────────
-
-func TestListProducts_All(t *testing.T) {
+func TestUpdateProduct_ZeroPriceIsNotOmittedPrice(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
-	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
-	createOne(t, repo, eventRepo, committer, ticker, "Desk", "furniture")
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	before := repo.store[id].BasePrice()
 
-	q := listproducts.NewListProductsQuery(repo, pricing, ticker)
-	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+	name := "Laptop Pro"
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	if _, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Name: &name}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := repo.store[id].BasePrice(); !got.Equals(before) {
+		t.Fatalf("expected an omitted price to leave %s untouched, got %s", before, got)
+	}
 
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		BasePrice: &updateproduct.PriceUpdate{Amount: 0},
+	})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(resp.Items) != 3 {
-		t.Fatalf("expected 3 items, got %d", len(resp.Items))
+	if got := repo.store[id].BasePrice(); got.Amount() != 0 || !slices.Contains(res.ChangedFields, domain.FieldBasePrice) {
+		t.Fatalf("expected a zero price to be set, got %s (changed %v)", got, res.ChangedFields)
 	}
 }
 
-func TestListProducts_FilterByCategory(t *testing.T) {
+func TestUpdateProduct_ProductNotFound(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
-	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
-	createOne(t, repo, eventRepo, committer, ticker, "Desk", "furniture")
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
 
-	cat := "electronics"
-	q := listproducts.NewListProductsQuery(repo, pricing, ticker)
-	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{
-		Category: &cat,
-		Limit:    10,
+	name := "New Name"
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: "non-existent-id",
+		Name:      &name,
 	})
 
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestUpdateProduct_MarkFeatured(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	featured := true
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Featured: &featured})
+
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(resp.Items) != 2 {
-		t.Fatalf("expected 2 electronics, got %d", len(resp.Items))
+	if !repo.store[id].IsFeatured() {
+		t.Fatal("expected product to be featured")
 	}
-	for _, item := range resp.Items {
-		if item.Category != "electronics" {
-			t.Fatalf("unexpected category %q", item.Category)
-		}
+	if !res.Changed || !slices.Contains(res.ChangedFields, domain.FieldFeatured) {
+		t.Fatalf("expected featured in changed fields, got %+v", res)
+	}
+	events := eventRepo.events
+	e, ok := events[len(events)-1].(*domain.ProductFeaturedChangedEvent)
+	if !ok || !e.Featured() {
+		t.Fatalf("expected ProductFeaturedChangedEvent, got %T", events[len(events)-1])
+	}
+
+	// Marking it again is a no-op once the committed state is reloaded.
+	repo.store[id] = repo.store[id].Snapshot()
+	res, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Featured: &featured})
+	if err != nil || res.Changed {
+		t.Fatalf("expected no-op, got %+v, %v", res, err)
 	}
 }
 
-func TestListProducts_Pagination(t *testing.T) {
+func TestUpdateProduct_SetMedia(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	for i := 0; i < 5; i++ {
-		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
-	}
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	q := listproducts.NewListProductsQuery(repo, pricing, ticker)
+	media := []string{"https://cdn.example.com/laptop-front.jpg", "https://cdn.example.com/laptop-side.jpg"}
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, MediaURLs: &media})
 
-	page1, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Offset: 0})
 	if err != nil {
-		t.Fatalf("page1 error: %v", err)
+		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(page1.Items) != 2 {
-		t.Fatalf("expected 2 items on page1, got %d", len(page1.Items))
+	if !res.Changed || !slices.Contains(res.ChangedFields, domain.FieldMedia) {
+		t.Fatalf("expected media_urls in changed fields, got %+v", res)
+	}
+	events := eventRepo.events
+	e, ok := events[len(events)-1].(*domain.ProductMediaChangedEvent)
+	if !ok || !slices.Equal(e.MediaURLs(), media) {
+		t.Fatalf("expected ProductMediaChangedEvent with %v, got %T", media, events[len(events)-1])
 	}
 
-	page2, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Offset: 2})
+	dto, err := getproduct.NewGetProductQuery(repo, pricing, ticker).Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
 	if err != nil {
-		t.Fatalf("page2 error: %v", err)
+		t.Fatalf("get: %v", err)
 	}
-	if len(page2.Items) != 2 {
-		t.Fatalf("expected 2 items on page2, got %d", len(page2.Items))
+	if !slices.Equal(dto.MediaURLs, media) {
+		t.Fatalf("expected media %v in order, got %v", media, dto.MediaURLs)
 	}
 }
 
-func TestListProducts_ExcludesInactive(t *testing.T) {
+func TestUpdateProduct_InvalidMediaRejected(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
-	_ = repo.store[id].Deactivate(baseTime) // make it inactive
-	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	calls := committer.calls
 
-	q := listproducts.NewListProductsQuery(repo, pricing, ticker)
-	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+	media := []string{"not a url"}
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, MediaURLs: &media})
 
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, domain.ErrInvalidMediaURL) {
+		t.Fatalf("expected a validation error wrapping ErrInvalidMediaURL, got %v", err)
 	}
-	if len(resp.Items) != 1 {
-		t.Fatalf("expected 1 active item, got %d", len(resp.Items))
+	if committer.calls != calls {
+		t.Fatal("expected no commit for invalid media")
 	}
 }
 
 // ────────────────────────────────────────────────────────────────────────────
-// DeactivateProduct
+// ApplyDiscount
 // ────────────────────────────────────────────────────────────────────────────
 
-func TestDeactivateProduct_Success(t *testing.T) {
+func TestApplyDiscount_Success(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+	startsAt := baseTime.Add(-time.Hour)
+	endsAt := baseTime.Add(24 * time.Hour)
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	})
 
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if repo.store[id].Status() != domain.ProductStatusInactive {
-		t.Fatal("expected product to be inactive after deactivation")
+	if repo.store[id].Discount() == nil {
+		t.Fatal("expected discount to be set")
 	}
 }
 
-func TestDeactivateProduct_Idempotent(t *testing.T) {
+func TestApplyDiscount_InvalidPeriod(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
-	_ = repo.store[id].Deactivate(baseTime) // already inactive
 
-	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(time.Hour), // starts AFTER endsAt
+		EndsAt:     baseTime.Add(-time.Hour),
+	})
 
-	if err != nil {
-		t.Fatalf("expected no error for idempotent deactivate, got %v", err)
-	}
-	if repo.store[id].Status() != domain.ProductStatusInactive {
-		t.Fatal("expected product to remain inactive")
+	if !errors.Is(err, domain.ErrDiscountInvalidPeriod) {
+		t.Fatalf("expected ErrDiscountInvalidPeriod, got %v", err)
 	}
 }
 
-func TestDeactivateProduct_RemovesDiscount(t *testing.T) {
+func TestApplyDiscount_WithDuration(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	// Apply a discount first
-	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+	d := 48 * time.Hour
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
 		ProductID:  id,
-		Percentage: "15",
-		StartsAt:   baseTime.Add(-time.Hour),
-		EndsAt:     baseTime.Add(24 * time.Hour),
+		Percentage: "10",
+		StartsAt:   baseTime,
+		Duration:   &d,
 	})
 
-	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
-
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if repo.store[id].Discount() != nil {
-		t.Fatal("expected discount to be cleared on deactivation")
+	if got := repo.store[id].Discount().EndsAt(); !got.Equal(baseTime.Add(d)) {
+		t.Fatalf("expected discount to end at %v, got %v", baseTime.Add(d), got)
 	}
 }
 
-func TestDeactivateProduct_NotFound(t *testing.T) {
+func TestApplyDiscount_NormalizesToUTC(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: "ghost"})
+	ict := time.FixedZone("ICT", 7*60*60)
+	startsAt := baseTime.In(ict)
+	endsAt := startsAt.Add(24 * time.Hour)
 
-	if !errors.Is(err, domain.ErrProductNotFound) {
-		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	d := repo.store[id].Discount()
+	if d.StartsAt() != startsAt.UTC() || d.EndsAt() != endsAt.UTC() {
+		t.Fatalf("expected UTC dates %v..%v, got %v..%v", startsAt.UTC(), endsAt.UTC(), d.StartsAt(), d.EndsAt())
 	}
 }
 
-// ────────────────────────────────────────────────────────────────────────────
-// RemoveDiscount
-// ────────────────────────────────────────────────────────────────────────────
+func TestNewDiscount_RejectsNonFinitePercentage(t *testing.T) {
+	for _, pct := range []string{"NaN", "Inf", "-Inf"} {
+		if _, err := domain.NewDiscount(pct, baseTime, baseTime.Add(time.Hour)); !errors.Is(err, domain.ErrDiscountInvalidPercentage) {
+			t.Fatalf("%s: expected ErrDiscountInvalidPercentage, got %v", pct, err)
+		}
+	}
+}
 
-func TestRemoveDiscount_Success(t *testing.T) {
+func TestNewDiscountForDuration_RejectsNonPositive(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Hour} {
+		if _, err := domain.NewDiscountForDuration("10", baseTime, d); !errors.Is(err, domain.ErrDiscountInvalidDuration) {
+			t.Fatalf("duration %s: expected ErrDiscountInvalidDuration, got %v", d, err)
+		}
+	}
+}
+
+func TestApplyDiscount_MaxBackdating(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	limited := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker, applydiscount.WithMaxBackdating(24*time.Hour))
 
-	// Apply discount first
-	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+	backdated := &applydiscount.ApplyDiscountRequest{
 		ProductID:  id,
 		Percentage: "10",
-		StartsAt:   baseTime.Add(-time.Hour),
+		StartsAt:   baseTime.Add(-3 * 365 * 24 * time.Hour),
 		EndsAt:     baseTime.Add(24 * time.Hour),
-	})
-
-	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
-
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := limited.Validate(context.Background(), backdated); !errors.Is(err, domain.ErrDiscountBackdatedTooFar) {
+		t.Fatalf("expected Validate to report ErrDiscountBackdatedTooFar, got %v", err)
+	}
+	if err := limited.Execute(context.Background(), backdated); !errors.Is(err, domain.ErrDiscountBackdatedTooFar) {
+		t.Fatalf("expected ErrDiscountBackdatedTooFar, got %v", err)
 	}
 	if repo.store[id].Discount() != nil {
-		t.Fatal("expected discount to be nil after removal")
+		t.Fatalf("expected no discount stored, got %v", repo.store[id].Discount())
 	}
-}
-
-func TestRemoveDiscount_NoActiveDiscount(t *testing.T) {
-	repo, eventRepo, committer, ticker := buildDeps(t)
-	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
-
-	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
-	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
 
-	if !errors.Is(err, domain.ErrNoActiveDiscount) {
-		t.Fatalf("expected ErrNoActiveDiscount, got %v", err)
+	slightlyPast := &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}
+	if err := limited.Execute(context.Background(), slightlyPast); err != nil {
+		t.Fatalf("expected a start an hour ago to be accepted, got %v", err)
+	}
+	if d := repo.store[id].Discount(); d == nil || !d.StartsAt().Equal(slightlyPast.StartsAt) {
+		t.Fatalf("expected the discount stored with the requested start, got %v", d)
 	}
 }
 
-func TestRemoveDiscount_NotFound(t *testing.T) {
+func TestApplyDiscount_NoMaxBackdatingAcceptsAnyStart(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
-	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
-
-	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: "ghost"})
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	if !errors.Is(err, domain.ErrProductNotFound) {
-		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	// Without a window, e.g. during a data migration, old starts are accepted.
+	uc := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := uc.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-3 * 365 * 24 * time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("expected no error without a backdating window, got %v", err)
 	}
 }
 
-func TestRemoveDiscount_ThenGetProduct_PriceEqualsBase(t *testing.T) {
+func TestApplyDiscount_NotActive(t *testing.T) {
 	repo, eventRepo, committer, ticker := buildDeps(t)
 	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
 
-	// Apply then remove discount
-	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
-	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+	// Deactivate the product first
+	deactivateTicker := newTicker(baseTime)
+	_ = deactivateTicker
+	p := repo.store[id]
+	_ = p.Deactivate(baseTime, false)
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
 		ProductID:  id,
-		Percentage: "30",
+		Percentage: "10",
 		StartsAt:   baseTime.Add(-time.Hour),
 		EndsAt:     baseTime.Add(24 * time.Hour),
 	})
-	removeIt := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
-	_ = removeIt.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
 
-	// After discount removal, effective price == base price
+	if !errors.Is(err, domain.ErrProductNotActive) {
+		t.Fatalf("expected ErrProductNotActive, got %v", err)
+	}
+}
+
+func TestDiscount_Progress(t *testing.T) {
+	d, err := domain.NewDiscount("10", baseTime, baseTime.Add(10*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{"before", baseTime.Add(-time.Hour), 0},
+		{"start", baseTime, 0},
+		{"midpoint", baseTime.Add(5 * time.Hour), 0.5},
+		{"end", baseTime.Add(10 * time.Hour), 1},
+		{"after", baseTime.Add(48 * time.Hour), 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := d.Progress(tc.at); got != tc.want {
+				t.Fatalf("Progress = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiscount_IsValidAtSkewTolerance(t *testing.T) {
+	startsAt := baseTime.Add(2 * time.Second)
+	d, err := domain.NewDiscount("10", startsAt, startsAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	tolerant := d.WithSkewTolerance(2 * time.Second)
+
+	for _, tc := range []struct {
+		name            string
+		now             time.Time
+		want, wantSkews bool
+	}{
+		{"beyond tolerance", startsAt.Add(-2*time.Second - time.Nanosecond), false, false},
+		{"at tolerance", startsAt.Add(-2 * time.Second), false, true},
+		{"at start", startsAt, true, true},
+		{"last instant", startsAt.Add(time.Hour - time.Nanosecond), true, true},
+		{"at end", startsAt.Add(time.Hour), false, false}, // the end is never extended
+	} {
+		if got := d.IsValidAt(tc.now); got != tc.want {
+			t.Fatalf("%s: IsValidAt = %v, want %v", tc.name, got, tc.want)
+		}
+		if got := tolerant.IsValidAt(tc.now); got != tc.wantSkews {
+			t.Fatalf("%s: IsValidAt with tolerance = %v, want %v", tc.name, got, tc.wantSkews)
+		}
+	}
+}
+
+func TestApplyDiscount_ClockSkewTolerance(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	// A client clock one second ahead of the server asks for a discount starting now.
+	req := &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(time.Second),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}
+
+	strict := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := strict.Execute(context.Background(), req); !errors.Is(err, domain.ErrInvalidDiscountPeriod) {
+		t.Fatalf("expected ErrInvalidDiscountPeriod without tolerance, got %v", err)
+	}
+
+	tolerant := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker, applydiscount.WithClockSkewTolerance(time.Second))
+	if err := tolerant.Validate(context.Background(), req); err != nil {
+		t.Fatalf("expected Validate to accept the start within tolerance, got %v", err)
+	}
+	if err := tolerant.Execute(context.Background(), req); err != nil {
+		t.Fatalf("expected no error within tolerance, got %v", err)
+	}
+	if d := repo.store[id].Discount(); d == nil || !d.StartsAt().Equal(req.StartsAt) {
+		t.Fatalf("expected the discount stored with the requested start, got %v", d)
+	}
+}
+
+func TestDiscount_Overlaps(t *testing.T) {
+	h := func(n int) time.Time { return baseTime.Add(time.Duration(n) * time.Hour) }
+	mk := func(from, to int) *domain.Discount {
+		d, err := domain.NewDiscount("10", h(from), h(to))
+		if err != nil {
+			t.Fatalf("new discount: %v", err)
+		}
+		return d
+	}
+
+	for _, tc := range []struct {
+		name string
+		a, b *domain.Discount
+		want bool
+	}{
+		{"adjacent", mk(0, 2), mk(2, 4), false},
+		{"disjoint", mk(0, 1), mk(3, 4), false},
+		{"contained", mk(0, 10), mk(2, 4), true},
+		{"partial", mk(0, 3), mk(2, 5), true},
+		{"identical", mk(0, 2), mk(0, 2), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.Overlaps(tc.b); got != tc.want {
+				t.Fatalf("a.Overlaps(b) = %v, want %v", got, tc.want)
+			}
+			if got := tc.b.Overlaps(tc.a); got != tc.want {
+				t.Fatalf("b.Overlaps(a) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyDiscount_RejectsOverlap(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("first discount: %v", err)
+	}
+
+	err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "30",
+		StartsAt:   baseTime,
+		EndsAt:     baseTime.Add(48 * time.Hour),
+	})
+
+	if !errors.Is(err, domain.ErrDiscountOverlap) {
+		t.Fatalf("expected ErrDiscountOverlap, got %v", err)
+	}
+	if got := repo.store[id].Discount().Percentage(); got != "10.0" {
+		t.Fatalf("expected original discount to be kept, got %s", got)
+	}
+}
+
+func TestApplyDiscount_ReplacesExpired(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	expired, err := domain.NewDiscount("10", baseTime.Add(-48*time.Hour), baseTime.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-expired",
+		Name:      "Mouse",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(100, "USD"),
+		Discount:  expired,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  p.ID(),
+		Percentage: "20",
+		StartsAt:   baseTime.Add(-2 * time.Hour),
+		EndsAt:     baseTime.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("expected expired discount to be replaced, got %v", err)
+	}
+}
+
+func TestValidateDiscount_ValidRequestPersistsNothing(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	calls := committer.calls
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	d := 24 * time.Hour
+	err := it.Validate(context.Background(), &applydiscount.ApplyDiscountRequest{ProductID: id, Percentage: "15", Duration: &d})
+
+	if err != nil {
+		t.Fatalf("expected no violations, got %v", err)
+	}
+	if committer.calls != calls || repo.store[id].Discount() != nil || len(repo.store[id].Events()) != 0 {
+		t.Fatal("validation must not change or persist the product")
+	}
+}
+
+func TestValidateDiscount_ReportsViolationsTogether(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("first discount: %v", err)
+	}
+
+	req := &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "-5",
+		StartsAt:   baseTime,
+		EndsAt:     baseTime.Add(48 * time.Hour),
+	}
+	err := it.Validate(context.Background(), req)
+
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 {
+		t.Fatalf("expected two violations, got %v", err)
+	}
+	if !errors.Is(err, domain.ErrDiscountInvalidPercentage) || !errors.Is(err, domain.ErrDiscountOverlap) {
+		t.Fatalf("expected percentage and overlap violations, got %v", err)
+	}
+	// The real apply path rejects the same request.
+	if err := it.Execute(context.Background(), req); !errors.Is(err, domain.ErrDiscountInvalidPercentage) {
+		t.Fatalf("expected Execute to reject the request too, got %v", err)
+	}
+
+	err = it.Validate(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime,
+		EndsAt:     baseTime.Add(-time.Hour),
+	})
+	if !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Field != "ends_at" {
+		t.Fatalf("expected a single ends_at violation, got %v", err)
+	}
+
+	if err := it.Validate(context.Background(), &applydiscount.ApplyDiscountRequest{ProductID: "missing"}); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// ActivateProduct
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestActivateProduct_AlreadyActive(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	// Activate an already-active product → should be a no-op (idempotent)
+	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error for idempotent activate, got %v", err)
+	}
+}
+
+func TestActivateProduct_FromInactive(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Lamp", "home")
+
+	// Deactivate first
+	_ = repo.store[id].Deactivate(baseTime, false)
+
+	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.store[id].Status() != domain.ProductStatusActive {
+		t.Fatal("expected product to be active")
+	}
+}
+
+func TestActivateProduct_RestoresPreviousDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Lamp", "home")
+
+	d, err := domain.NewDiscount("15", baseTime.Add(-time.Hour), baseTime.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p := repo.store[id]
+	if err := p.ApplyDiscount(d, baseTime); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	if err := p.Deactivate(baseTime, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if p.Discount() != nil || p.PreviousDiscount() == nil {
+		t.Fatal("expected deactivation to move the discount to previous discount")
+	}
+
+	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
+	err = it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: id, RestoreDiscount: true})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.Discount() == nil || p.Discount().Percentage() != "15.0" {
+		t.Fatalf("expected discount to be restored, got %+v", p.Discount())
+	}
+	if p.PreviousDiscount() != nil {
+		t.Fatal("expected previous discount to be cleared")
+	}
+	events := eventRepo.events
+	if _, ok := events[len(events)-1].(*domain.DiscountAppliedEvent); !ok {
+		t.Fatalf("expected DiscountAppliedEvent last, got %T", events[len(events)-1])
+	}
+}
+
+func TestActivateProduct_SkipsExpiredPreviousDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	expired, err := domain.NewDiscount("15", baseTime.Add(-48*time.Hour), baseTime.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:               "p-1",
+		Name:             "Lamp",
+		Category:         "home",
+		BasePrice:        domain.MustNewMoney(100, "USD"),
+		PreviousDiscount: expired,
+		Status:           domain.ProductStatusInactive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
+	err = it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: p.ID(), RestoreDiscount: true})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !p.IsActive() || p.Discount() != nil {
+		t.Fatalf("expected active product without discount, got status=%s discount=%+v", p.Status(), p.Discount())
+	}
+	for _, e := range eventRepo.events {
+		if _, ok := e.(*domain.DiscountAppliedEvent); ok {
+			t.Fatal("expected no DiscountAppliedEvent for an expired discount")
+		}
+	}
+}
+
+func TestActivateProduct_WithoutRestoreKeepsDiscountOff(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	d, err := domain.NewDiscount("15", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:               "p-1",
+		Name:             "Lamp",
+		Category:         "home",
+		BasePrice:        domain.MustNewMoney(100, "USD"),
+		PreviousDiscount: d,
+		Status:           domain.ProductStatusInactive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	it := activateproduct.NewActivateProductInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &activateproduct.ActivateProductRequest{ProductID: p.ID()}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.Discount() != nil {
+		t.Fatal("expected discount to stay off without restore")
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// GetProduct query
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestGetProduct_WithNoDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Headphones", "electronics")
+
 	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
 	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+
 	if err != nil {
-		t.Fatalf("expected no error on get, got %v", err)
+		t.Fatalf("expected no error, got %v", err)
 	}
-	if dto.EffectivePrice.Amount != dto.BasePrice.Amount {
-		t.Fatalf("expected effective price %d == base price %d after discount removal",
-			dto.EffectivePrice.Amount, dto.BasePrice.Amount)
+	if dto.ID != id {
+		t.Fatalf("expected ID %q, got %q", id, dto.ID)
 	}
 	if dto.Discount != nil {
-		t.Fatal("expected no discount in DTO after removal")
+		t.Fatal("expected no discount")
+	}
+	if dto.EffectivePrice.Amount != dto.BasePrice.Amount {
+		t.Fatal("effective price should equal base price when no discount")
+	}
+}
+
+func TestGetProduct_WithActiveDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+
+	// Apply 20% discount
+	startsAt := baseTime.Add(-time.Hour)
+	endsAt := baseTime.Add(24 * time.Hour)
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	_ = it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "20",
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	})
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.Discount == nil || !dto.Discount.IsActive {
+		t.Fatal("expected active discount on DTO")
+	}
+	if dto.EffectivePrice.Amount >= dto.BasePrice.Amount {
+		t.Fatal("expected effective price to be lower than base price")
+	}
+}
+
+func TestGetProduct_UsesTimeFromContext(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "20",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	now, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if now.EffectivePrice.Amount >= now.BasePrice.Amount {
+		t.Fatalf("expected the discount to apply at the ticker time, got %+v", now.EffectivePrice)
+	}
+
+	// Previewing after the window closes prices the product at its base price.
+	ctx := common.WithNow(context.Background(), baseTime.Add(48*time.Hour))
+	later, err := q.Execute(ctx, &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if later.EffectivePrice.Amount != later.BasePrice.Amount {
+		t.Fatalf("expected base price at the overridden time, got %+v", later.EffectivePrice)
+	}
+}
+
+func TestGetProduct_PreviewAsScheduled_UpcomingDiscount(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	startsAt := baseTime.Add(24 * time.Hour)
+	d, err := domain.NewDiscount("25", startsAt, startsAt.Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-sale",
+		Name:      "Mouse",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(100, "USD"),
+		Discount:  d,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	current, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID()})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if current.EffectivePrice.Amount != 100 || current.PreviewAt != nil {
+		t.Fatalf("expected the base price without a preview, got %+v (preview %v)", current.EffectivePrice, current.PreviewAt)
+	}
+
+	preview, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID(), PreviewAsScheduled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if preview.EffectivePrice.Amount != 75 {
+		t.Fatalf("expected the discounted price 75, got %+v", preview.EffectivePrice)
+	}
+	if preview.PreviewAt == nil || !preview.PreviewAt.Equal(startsAt) {
+		t.Fatalf("expected the preview flagged at the discount start %v, got %v", startsAt, preview.PreviewAt)
+	}
+	if preview.Discount == nil || !preview.Discount.IsActive || preview.Discount.State != string(domain.DiscountStateActive) {
+		t.Fatalf("expected the discount shown as active in the preview, got %+v", preview.Discount)
+	}
+}
+
+func TestGetProduct_PreviewAsScheduled_NoDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Headphones", "electronics")
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id, PreviewAsScheduled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.PreviewAt != nil || dto.Discount != nil || dto.EffectivePrice.Amount != dto.BasePrice.Amount {
+		t.Fatalf("expected the current base price without a preview, got %+v", dto)
+	}
+}
+
+func TestGetProduct_IncludeDiscountStates(t *testing.T) {
+	windows := map[domain.DiscountState][2]time.Time{
+		domain.DiscountStateActive:   {baseTime.Add(-time.Hour), baseTime.Add(time.Hour)},
+		domain.DiscountStateUpcoming: {baseTime.Add(time.Hour), baseTime.Add(2 * time.Hour)},
+		domain.DiscountStateExpired:  {baseTime.Add(-2 * time.Hour), baseTime.Add(-time.Hour)},
+	}
+
+	for state, w := range windows {
+		t.Run(string(state), func(t *testing.T) {
+			repo, _, _, ticker := buildDeps(t)
+			d, err := domain.NewDiscount("10", w[0], w[1])
+			if err != nil {
+				t.Fatalf("new discount: %v", err)
+			}
+			p, err := domain.Reconstitute(domain.ProductState{
+				ID:        "p-" + string(state),
+				Name:      "Mouse",
+				Category:  "electronics",
+				BasePrice: domain.MustNewMoney(100, "USD"),
+				Discount:  d,
+				Status:    domain.ProductStatusActive,
+			})
+			if err != nil {
+				t.Fatalf("reconstitute: %v", err)
+			}
+			repo.store[p.ID()] = p
+			q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+			// Default: the stored discount is always returned with its state label.
+			dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID()})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if dto.Discount == nil || dto.Discount.State != string(state) {
+				t.Fatalf("expected discount in state %q, got %+v", state, dto.Discount)
+			}
+			if dto.Discount.IsActive != (state == domain.DiscountStateActive) {
+				t.Fatalf("IsActive = %v for state %q", dto.Discount.IsActive, state)
+			}
+
+			// Requesting only the matching state keeps it.
+			dto, _ = q.Execute(context.Background(), &getproduct.GetProductRequest{
+				ProductID:             p.ID(),
+				IncludeDiscountStates: []string{string(state)},
+			})
+			if dto.Discount == nil {
+				t.Fatal("expected discount when its state is included")
+			}
+
+			// Requesting other states only drops it.
+			var others []string
+			for s := range windows {
+				if s != state {
+					others = append(others, string(s))
+				}
+			}
+			dto, _ = q.Execute(context.Background(), &getproduct.GetProductRequest{
+				ProductID:             p.ID(),
+				IncludeDiscountStates: others,
+			})
+			if dto.Discount != nil {
+				t.Fatalf("expected no discount when %q is excluded, got %+v", state, dto.Discount)
+			}
+		})
+	}
+}
+
+func TestGetProduct_InvalidDiscountState(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	_, err := q.Execute(context.Background(), &getproduct.GetProductRequest{
+		ProductID:             id,
+		IncludeDiscountStates: []string{"pending"},
+	})
+
+	if !errors.Is(err, domain.ErrInvalidDiscountState) {
+		t.Fatalf("expected ErrInvalidDiscountState, got %v", err)
+	}
+}
+
+func TestGetProduct_NotFound(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	_, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: "ghost"})
+
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestGetProduct_Archived(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	archivedAt := baseTime.Add(-time.Hour)
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:         "archived-1",
+		Name:       "Old Lamp",
+		Category:   "home",
+		BasePrice:  domain.MustNewMoney(100, "USD"),
+		Status:     domain.ProductStatusInactive,
+		ArchivedAt: &archivedAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	_, err = q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID()})
+
+	if !errors.Is(err, domain.ErrProductArchived) {
+		t.Fatalf("expected ErrProductArchived, got %v", err)
+	}
+	if errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatal("archived product must not be reported as not found")
+	}
+}
+
+func TestBatchGetProducts_PartialReportsNotFoundAndInvalid(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	a := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	b := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	archivedAt := baseTime
+	archived, err := domain.Reconstitute(domain.ProductState{
+		ID:         "6f1c2a4e-0000-4000-8000-000000000001",
+		Name:       "Old",
+		Category:   "electronics",
+		BasePrice:  domain.MustNewMoney(1000, "USD"),
+		Status:     domain.ProductStatusInactive,
+		ArchivedAt: &archivedAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[archived.ID()] = archived
+	missing := "6f1c2a4e-0000-4000-8000-000000000002"
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	got, err := q.ExecuteBatchPartial(context.Background(), &getproduct.BatchGetProductsRequest{
+		ProductIDs: []string{missing, a, "not-an-id", archived.ID(), b, "", "not-an-id", a},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got.Found) != 2 || got.Found[a] == nil || got.Found[a].Name != "Keyboard" || got.Found[b] == nil {
+		t.Fatalf("expected %q and %q found, got %+v", a, b, got.Found)
+	}
+	if !slices.Equal(got.NotFound, []string{missing, archived.ID()}) {
+		t.Fatalf("expected the missing and archived ids not found, got %v", got.NotFound)
+	}
+	if !slices.Equal(got.Invalid, []string{"not-an-id"}) {
+		t.Fatalf("expected the malformed id once as invalid, got %v", got.Invalid)
+	}
+}
+
+func TestBatchGetProducts_PartialAllInvalid(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	got, err := q.ExecuteBatchPartial(context.Background(), &getproduct.BatchGetProductsRequest{
+		ProductIDs: []string{"p-1", "42"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got.Found) != 0 || len(got.NotFound) != 0 || !slices.Equal(got.Invalid, []string{"p-1", "42"}) {
+		t.Fatalf("expected only invalid ids, got %+v", got)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// ListProducts query
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestListProducts_All(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Desk", "furniture")
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(resp.Items))
+	}
+}
+
+func TestListProducts_FilterByCategory(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Desk", "furniture")
+
+	cat := "electronics"
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{
+		Category: &cat,
+		Limit:    10,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 2 {
+		t.Fatalf("expected 2 electronics, got %d", len(resp.Items))
+	}
+	for _, item := range resp.Items {
+		if item.Category != "electronics" {
+			t.Fatalf("unexpected category %q", item.Category)
+		}
+	}
+}
+
+func TestListProducts_Pagination(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	for i := 0; i < 5; i++ {
+		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	page1, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Offset: 0})
+	if err != nil {
+		t.Fatalf("page1 error: %v", err)
+	}
+	if len(page1.Items) != 2 {
+		t.Fatalf("expected 2 items on page1, got %d", len(page1.Items))
+	}
+
+	page2, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("page2 error: %v", err)
+	}
+	if len(page2.Items) != 2 {
+		t.Fatalf("expected 2 items on page2, got %d", len(page2.Items))
+	}
+}
+
+func TestListProducts_SortByDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	plain := createOne(t, repo, eventRepo, committer, ticker, "Plain", "misc")
+	ten := createOne(t, repo, eventRepo, committer, ticker, "Ten", "misc")
+	thirty := createOne(t, repo, eventRepo, committer, ticker, "Thirty", "misc")
+
+	it := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	for id, pct := range map[string]string{ten: "10", thirty: "30"} {
+		if err := it.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+			ProductID:  id,
+			Percentage: pct,
+			StartsAt:   baseTime.Add(-time.Hour),
+			EndsAt:     baseTime.Add(time.Hour),
+		}); err != nil {
+			t.Fatalf("apply %s%%: %v", pct, err)
+		}
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	page1, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, SortBy: listproducts.SortByDiscount})
+	if err != nil {
+		t.Fatalf("page1 error: %v", err)
+	}
+	page2, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, SortBy: listproducts.SortByDiscount, Cursor: page1.NextCursor})
+	if err != nil {
+		t.Fatalf("page2 error: %v", err)
+	}
+
+	var got []string
+	for _, item := range append(page1.Items, page2.Items...) {
+		got = append(got, item.ID)
+	}
+	if want := []string{thirty, ten, plain}; !slices.Equal(got, want) {
+		t.Fatalf("expected deepest discount first %v, got %v", want, got)
+	}
+
+	// A cursor is tied to the ordering it was issued for.
+	_, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Cursor: page1.NextCursor})
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for a discount cursor on the default order, got %v", err)
+	}
+}
+
+func TestListProducts_RejectsUnknownSortOrder(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	_, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{SortBy: "price"})
+	if !errors.Is(err, domain.ErrInvalidSortOrder) {
+		t.Fatalf("expected ErrInvalidSortOrder, got %v", err)
+	}
+}
+
+func TestListProducts_RejectsNegativePagination(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	for _, req := range []*listproducts.ListProductsRequest{{Offset: -5}, {Limit: -1}} {
+		if _, err := q.Execute(context.Background(), req); !errors.Is(err, domain.ErrInvalidPagination) {
+			t.Fatalf("limit %d offset %d: expected ErrInvalidPagination, got %v", req.Limit, req.Offset, err)
+		}
+	}
+}
+
+func TestListProducts_CursorContinuesPagination(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	for i := 0; i < 3; i++ {
+		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	page1, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("page1 error: %v", err)
+	}
+	if page1.NextCursor == "" || !page1.HasNext {
+		t.Fatal("expected a next cursor after a full page")
+	}
+
+	page2, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 2, Cursor: page1.NextCursor})
+	if err != nil {
+		t.Fatalf("page2 error: %v", err)
+	}
+	if len(page2.Items) != 1 || page2.NextCursor != "" || page2.HasNext {
+		t.Fatalf("expected 1 trailing item and no cursor, got %d items cursor=%q", len(page2.Items), page2.NextCursor)
+	}
+}
+
+func TestListProducts_StableOrderAcrossCalls(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	for i := 0; i < 10; i++ {
+		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	}
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	ids := func() []string {
+		resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var out []string
+		for _, item := range resp.Items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	first, second := ids(), ids()
+	if !slices.Equal(first, second) {
+		t.Fatalf("expected identical order, got %v and %v", first, second)
+	}
+	if !slices.IsSorted(first) {
+		t.Fatalf("expected products ordered by ID, got %v", first)
+	}
+}
+
+func TestListProducts_FeaturedFirst(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	var ids []string
+	for _, name := range []string{"A", "B", "C", "D"} {
+		ids = append(ids, createOne(t, repo, eventRepo, committer, ticker, name, "misc"))
+	}
+	slices.Sort(ids)
+	featured := ids[2]
+	repo.store[featured].SetFeatured(true, baseTime)
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(resp.Items) != 4 || resp.Items[0].ID != featured || !resp.Items[0].Featured {
+		t.Fatalf("expected featured product %s first, got %+v", featured, resp.Items[0])
+	}
+	rest := []string{resp.Items[1].ID, resp.Items[2].ID, resp.Items[3].ID}
+	if !slices.IsSorted(rest) {
+		t.Fatalf("expected remaining products ordered by ID, got %v", rest)
+	}
+
+	only := true
+	resp, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, Featured: &only})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != featured {
+		t.Fatalf("expected only the featured product, got %d items", len(resp.Items))
+	}
+}
+
+func TestListProducts_InvalidCursor(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	valid := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"featured,product_id","o":2}`))
+	cases := map[string]string{
+		"truncated":     valid[:len(valid)-4],
+		"non-base64":    "not a cursor!",
+		"sort-mismatch": base64.RawURLEncoding.EncodeToString([]byte(`{"s":"discount","o":2}`)),
+	}
+
+	for name, token := range cases {
+		_, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Cursor: token})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Fatalf("%s: expected ErrInvalidCursor, got %v", name, err)
+		}
+	}
+
+	if _, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Cursor: valid}); err != nil {
+		t.Fatalf("expected well-formed cursor to be accepted, got %v", err)
+	}
+}
+
+func TestListProducts_ExcludesInactive(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	_ = repo.store[id].Deactivate(baseTime, false) // make it inactive
+	createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 {
+		t.Fatalf("expected 1 active item, got %d", len(resp.Items))
+	}
+}
+
+func TestListProducts_ConfiguredDefaultLimit(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	for i := 0; i < 3; i++ {
+		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.Config{DefaultLimit: 2, MaxLimit: 10})
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 2 {
+		t.Fatalf("expected configured default of 2 items, got %d", len(resp.Items))
+	}
+}
+
+func TestListProducts_TotalCountCachedWithinTTL(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	for i := 0; i < 3; i++ {
+		createOne(t, repo, eventRepo, committer, ticker, "Product", "misc")
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.Config{
+		DefaultLimit: 2, MaxLimit: 10, TotalCountTTL: time.Minute,
+	})
+	for i := 0; i < 2; i++ {
+		resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{IncludeTotal: true})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.TotalCount != 3 {
+			t.Fatalf("expected total 3, got %d", resp.TotalCount)
+		}
+	}
+	if repo.counts != 1 {
+		t.Fatalf("expected one count query within the TTL, got %d", repo.counts)
+	}
+
+	if _, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{IncludeTotal: true, RefreshTotal: true}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.counts != 2 {
+		t.Fatalf("expected refresh to bypass the cache, got %d count queries", repo.counts)
+	}
+}
+
+func TestListProducts_TotalCountCachedPerFeaturedFilter(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	featured := createOne(t, repo, eventRepo, committer, ticker, "Featured", "misc")
+	createOne(t, repo, eventRepo, committer, ticker, "Plain", "misc")
+	repo.store[featured].SetFeatured(true, baseTime)
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.Config{
+		DefaultLimit: 10, MaxLimit: 10, TotalCountTTL: time.Minute,
+	})
+	all, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{IncludeTotal: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	only := true
+	featuredOnly, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{IncludeTotal: true, Featured: &only})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if all.TotalCount != 2 || featuredOnly.TotalCount != 1 {
+		t.Fatalf("expected totals 2 unfiltered and 1 featured, got %d and %d", all.TotalCount, featuredOnly.TotalCount)
+	}
+}
+
+func TestListProducts_OnSaleTotalCachedAcrossRequests(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.Config{
+		DefaultLimit: 10, MaxLimit: 10, TotalCountTTL: time.Minute,
+	})
+
+	// Each request filters on its own now; the total is still served from cache.
+	for i := range 2 {
+		ctx := common.WithNow(context.Background(), baseTime.Add(time.Duration(i)*time.Second))
+		if _, err := q.Execute(ctx, &listproducts.ListProductsRequest{IncludeTotal: true, OnSale: true}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if repo.counts != 1 {
+		t.Fatalf("expected one count query within the TTL, got %d", repo.counts)
+	}
+}
+
+func TestListProducts_FilterByCreatedRange(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	oldID := createOne(t, repo, eventRepo, committer, ticker, "Old", "misc")
+	newID := createOne(t, repo, eventRepo, committer, ticker, "New", "misc")
+	futureID := createOne(t, repo, eventRepo, committer, ticker, "Future", "misc")
+	repo.createdAt[oldID] = baseTime.Add(-48 * time.Hour)
+	repo.createdAt[futureID] = baseTime.Add(48 * time.Hour)
+
+	after := baseTime.Add(-24 * time.Hour)
+	before := baseTime.Add(24 * time.Hour)
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+		Limit:         10,
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != newID {
+		t.Fatalf("expected only %q within range, got %d items", newID, len(resp.Items))
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// CheckExistence query
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestCheckExistence_MixOfExistingAndMissing(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	a := createOne(t, repo, eventRepo, committer, ticker, "A", "books")
+	b := createOne(t, repo, eventRepo, committer, ticker, "B", "books")
+
+	q := checkexistence.NewCheckExistenceQuery(repo)
+	resp, err := q.Execute(context.Background(), &checkexistence.CheckExistenceRequest{
+		ProductIDs: []string{a, "missing-1", b, a, "", "missing-1"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]bool{a: true, b: true, "missing-1": false}
+	if len(resp.Exists) != len(want) {
+		t.Fatalf("expected %d entries, got %v", len(want), resp.Exists)
+	}
+	for id, exists := range want {
+		if got, ok := resp.Exists[id]; !ok || got != exists {
+			t.Fatalf("id %q: expected exists=%v, got %v (present=%v)", id, exists, got, ok)
+		}
+	}
+	if len(repo.existsIDs) != 3 {
+		t.Fatalf("expected the repository to see 3 distinct ids, got %v", repo.existsIDs)
+	}
+}
+
+func TestCheckExistence_EmptyInputSkipsRepository(t *testing.T) {
+	repo, _, _, _ := buildDeps(t)
+
+	resp, err := checkexistence.NewCheckExistenceQuery(repo).Execute(context.Background(), &checkexistence.CheckExistenceRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Exists) != 0 || repo.existsIDs != nil {
+		t.Fatalf("expected an empty result without a repository call, got %v", resp.Exists)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// PriceStats query
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestPriceStats_UsesEffectivePricesPerCategory(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	active, err := domain.NewDiscount("50", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	upcoming, err := domain.NewDiscount("50", baseTime.Add(time.Hour), baseTime.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+
+	for _, seed := range []struct {
+		id, category string
+		amount       int64
+		currency     string
+		discount     *domain.Discount
+		status       domain.ProductStatus
+	}{
+		{"b-1", "books", 1000, "USD", nil, domain.ProductStatusActive},
+		{"b-2", "books", 3000, "USD", active, domain.ProductStatusActive},   // effective 1500
+		{"b-3", "books", 2001, "USD", upcoming, domain.ProductStatusActive}, // not yet discounted
+		{"b-4", "books", 9999, "USD", nil, domain.ProductStatusInactive},    // ignored
+		{"b-5", "books", 700, "EUR", nil, domain.ProductStatusActive},
+		{"t-1", "toys", 500, "USD", active, domain.ProductStatusActive}, // effective 250
+	} {
+		p, err := domain.Reconstitute(domain.ProductState{
+			ID:        seed.id,
+			Name:      seed.id,
+			Category:  seed.category,
+			BasePrice: domain.MustNewMoney(seed.amount, seed.currency),
+			Discount:  seed.discount,
+			Status:    seed.status,
+		})
+		if err != nil {
+			t.Fatalf("reconstitute %s: %v", seed.id, err)
+		}
+		repo.store[p.ID()] = p
+	}
+
+	resp, err := pricestats.NewPriceStatsQuery(repo, pricing, ticker).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	eur := func(amount int64) analytics.MoneyDTO { return analytics.MoneyDTO{Amount: amount, Currency: "EUR"} }
+	usd := func(amount int64) analytics.MoneyDTO { return analytics.MoneyDTO{Amount: amount, Currency: "USD"} }
+	want := []pricestats.CategoryPriceStatsDTO{
+		{Category: "books", Currency: "EUR", Count: 1, Min: eur(700), Max: eur(700), Avg: eur(700)},
+		{Category: "books", Currency: "USD", Count: 3, Min: usd(1000), Max: usd(2001), Avg: usd(1500)},
+		{Category: "toys", Currency: "USD", Count: 1, Min: usd(250), Max: usd(250), Avg: usd(250)},
+	}
+	if len(resp.Items) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(resp.Items))
+	}
+	for i, w := range want {
+		if *resp.Items[i] != w {
+			t.Fatalf("entry %d: expected %+v, got %+v", i, w, *resp.Items[i])
+		}
+	}
+}
+
+func TestPriceStats_SerializesMoneyWithCurrencyAndDisplay(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	for i, amount := range []int64{1999, 500, 2500} {
+		p, err := domain.Reconstitute(domain.ProductState{
+			ID:        fmt.Sprintf("p-%d", i),
+			Name:      "Item",
+			Category:  "books",
+			BasePrice: domain.MustNewMoney(amount, "USD"),
+			Status:    domain.ProductStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("reconstitute: %v", err)
+		}
+		repo.store[p.ID()] = p
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-vnd",
+		Name:      "Item",
+		Category:  "toys",
+		BasePrice: domain.MustNewMoney(150000, "VND"),
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	resp, err := pricestats.NewPriceStatsQuery(repo, pricing, ticker).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	type money struct {
+		AmountMinor int64  `json:"amount_minor"`
+		Currency    string `json:"currency"`
+		Display     string `json:"display"`
+	}
+	var got struct {
+		Items []struct {
+			Category      string `json:"category"`
+			Min, Avg, Max money
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	if len(got.Items) != 2 {
+		t.Fatalf("expected 2 entries, got %s", raw)
+	}
+
+	books, toys := got.Items[0], got.Items[1]
+	for field, want := range map[string][2]money{
+		"min": {books.Min, {500, "USD", "5.00 USD"}},
+		"avg": {books.Avg, {1666, "USD", "16.66 USD"}},
+		"max": {books.Max, {2500, "USD", "25.00 USD"}},
+	} {
+		if want[0] != want[1] {
+			t.Fatalf("books %s: expected %+v, got %+v", field, want[1], want[0])
+		}
+	}
+	// Zero-decimal currencies display without a fraction.
+	if want := (money{150000, "VND", "150000 VND"}); toys.Avg != want {
+		t.Fatalf("toys avg: expected %+v, got %+v", want, toys.Avg)
+	}
+}
+
+// slowSummariesRepo delays every summary page, as a loaded Spanner would.
+type slowSummariesRepo struct {
+	*inMemoryProductRepo
+	delay time.Duration
+	pages int
+}
+
+func (r *slowSummariesRepo) ListActiveSummaries(ctx context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*domain.Product, error) {
+	r.pages++
+	time.Sleep(r.delay)
+	return r.inMemoryProductRepo.ListActiveSummaries(ctx, filter, page)
+}
+
+func TestPriceStats_StopsPagingNearDeadline(t *testing.T) {
+	mem, _, _, ticker := buildDeps(t)
+	for i := range 1200 { // three pages
+		p, err := domain.Reconstitute(domain.ProductState{
+			ID:        fmt.Sprintf("p-%04d", i),
+			Name:      "Item",
+			Category:  "books",
+			BasePrice: domain.MustNewMoney(1000, "USD"),
+			Status:    domain.ProductStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("reconstitute: %v", err)
+		}
+		mem.store[p.ID()] = p
+	}
+	repo := &slowSummariesRepo{inMemoryProductRepo: mem, delay: 100 * time.Millisecond}
+	q := pricestats.NewPriceStatsQuery(repo, pricing, ticker)
+
+	// After one 100ms page, 50ms are left: too little for another.
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	resp, err := q.Execute(ctx)
+
+	if err != nil {
+		t.Fatalf("expected a partial result rather than an error, got %v", err)
+	}
+	if !resp.Truncated || repo.pages != 1 {
+		t.Fatalf("expected the scan truncated after 1 page, got truncated=%v after %d", resp.Truncated, repo.pages)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Count != 500 {
+		t.Fatalf("expected stats over the first page only, got %+v", resp.Items)
+	}
+
+	full, err := q.Execute(context.Background())
+	if err != nil || full.Truncated || full.Items[0].Count != 1200 {
+		t.Fatalf("expected a complete scan without a deadline, got %+v (err=%v)", full, err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Effective price backfill
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestEffectivePriceBackfill_ResumesAcrossBatches(t *testing.T) {
+	repo, eventRepo, _, ticker := buildDeps(t)
+	active, err := domain.NewDiscount("10", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	expired, err := domain.NewDiscount("10", baseTime.Add(-2*time.Hour), baseTime.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	for i, d := range []*domain.Discount{active, nil, expired, active, nil} {
+		p, err := domain.Reconstitute(domain.ProductState{
+			ID:        fmt.Sprintf("p-%d", i),
+			Name:      "Item",
+			Category:  "books",
+			BasePrice: domain.MustNewMoney(100, "USD"),
+			Discount:  d,
+			Status:    domain.ProductStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("reconstitute: %v", err)
+		}
+		repo.store[p.ID()] = p
+	}
+
+	q := effectivepricebackfill.NewBackfillQuery(repo, pricing, ticker)
+	var scanned, differing, batches int
+	cursor := ""
+	for {
+		resp, err := q.Execute(context.Background(), &effectivepricebackfill.BackfillRequest{Cursor: cursor, Limit: 2})
+		if err != nil {
+			t.Fatalf("batch %d: %v", batches, err)
+		}
+		if !resp.DryRun {
+			t.Fatal("expected a dry run while there is no snapshot column")
+		}
+		scanned += resp.Scanned
+		differing += resp.Differing
+		batches++
+		if cursor = resp.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	if scanned != 5 || differing != 2 || batches != 3 {
+		t.Fatalf("expected 5 scanned, 2 differing in 3 batches, got %d, %d in %d", scanned, differing, batches)
+	}
+	if len(eventRepo.events) != 0 {
+		t.Fatalf("expected no events, got %d", len(eventRepo.events))
+	}
+	for _, p := range repo.store {
+		if len(p.Events()) != 0 {
+			t.Fatalf("product %s must not raise events", p.ID())
+		}
+	}
+}
+
+func TestEffectivePriceBackfill_InvalidCursor(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+
+	_, err := effectivepricebackfill.NewBackfillQuery(repo, pricing, ticker).Execute(context.Background(),
+		&effectivepricebackfill.BackfillRequest{Cursor: "not-a-cursor"})
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Category taxonomy
+// ────────────────────────────────────────────────────────────────────────────
+
+// newTestTaxonomy builds electronics > computers > laptops, electronics > phones, and home.
+func newTestTaxonomy(t *testing.T) *inMemoryCategoryRepo {
+	t.Helper()
+	repo := &inMemoryCategoryRepo{}
+	for _, c := range [][3]string{
+		{"electronics", "Electronics", ""},
+		{"computers", "Computers", "electronics"},
+		{"laptops", "Laptops", "computers"},
+		{"phones", "Phones", "electronics"},
+		{"home", "Home", ""},
+	} {
+		cat, err := domain.NewCategory(c[0], c[1], c[2])
+		if err != nil {
+			t.Fatalf("new category %s: %v", c[0], err)
+		}
+		repo.categories = append(repo.categories, cat)
+	}
+	return repo
+}
+
+func TestListSubcategories_ResolvesAllDescendants(t *testing.T) {
+	q := listsubcategories.NewListSubcategoriesQuery(newTestTaxonomy(t))
+
+	resp, err := q.Execute(context.Background(), &listsubcategories.ListSubcategoriesRequest{CategoryID: "electronics"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var ids []string
+	for _, c := range resp.Items {
+		ids = append(ids, c.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"computers", "laptops", "phones"}) {
+		t.Fatalf("unexpected descendants %v", ids)
+	}
+
+	resp, _ = q.Execute(context.Background(), &listsubcategories.ListSubcategoriesRequest{CategoryID: "laptops"})
+	if len(resp.Items) != 0 {
+		t.Fatalf("expected a leaf to have no descendants, got %d", len(resp.Items))
+	}
+}
+
+func TestListSubcategories_UnknownCategory(t *testing.T) {
+	q := listsubcategories.NewListSubcategoriesQuery(newTestTaxonomy(t))
+
+	_, err := q.Execute(context.Background(), &listsubcategories.ListSubcategoriesRequest{CategoryID: "garden"})
+
+	if !errors.Is(err, domain.ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestNewCategory_RejectsSelfParent(t *testing.T) {
+	if _, err := domain.NewCategory("loop", "Loop", "loop"); !errors.Is(err, domain.ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle, got %v", err)
+	}
+}
+
+func TestListProducts_IncludeSubcategories(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Gadget", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Ultrabook", "laptops")
+	createOne(t, repo, eventRepo, committer, ticker, "Smartphone", "phones")
+	createOne(t, repo, eventRepo, committer, ticker, "Sofa", "home")
+
+	q := listproducts.NewListProductsQuery(repo, newTestTaxonomy(t), pricing, ticker, listproducts.DefaultConfig())
+	cat := "computers"
+
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Category: &cat})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 0 {
+		t.Fatalf("expected exact match on computers to find nothing, got %d", len(resp.Items))
+	}
+
+	cat = "electronics"
+	resp, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Category: &cat, IncludeSubcategories: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var names []string
+	for _, item := range resp.Items {
+		names = append(names, item.Name)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"Gadget", "Smartphone", "Ultrabook"}) {
+		t.Fatalf("unexpected subtree products %v", names)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// DeactivateProduct
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestDeactivateProduct_Success(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.store[id].Status() != domain.ProductStatusInactive {
+		t.Fatal("expected product to be inactive after deactivation")
+	}
+}
+
+func TestDeactivateProduct_Idempotent(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	_ = repo.store[id].Deactivate(baseTime, false) // already inactive
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error for idempotent deactivate, got %v", err)
+	}
+	if repo.store[id].Status() != domain.ProductStatusInactive {
+		t.Fatal("expected product to remain inactive")
+	}
+}
+
+func TestDeactivateProduct_RemovesDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	// Apply a discount first
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "15",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	})
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.store[id].Discount() != nil {
+		t.Fatal("expected discount to be cleared on deactivation")
+	}
+}
+
+func TestDeactivateProduct_AbortsWhenEventCannotBeStored(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	calls := committer.calls
+
+	wantErr := errors.New("marshal failed")
+	eventRepo.err = wantErr
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the event error, got %v", err)
+	}
+	if committer.calls != calls {
+		t.Fatal("expected nothing to be committed without the event")
+	}
+}
+
+func TestDeactivateProduct_SequencesEvents(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	d, err := domain.NewDiscount("15", baseTime.Add(-time.Hour), baseTime.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-discounted",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(100, "USD"),
+		Discount:  d,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: p.ID()}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(eventRepo.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(eventRepo.events))
+	}
+	for i, want := range []string{"product.discount_removed", "product.deactivated"} {
+		e := eventRepo.events[i]
+		if e.EventName() != want || e.Sequence() != int64(i+1) {
+			t.Fatalf("event %d: expected %s with sequence %d, got %s with sequence %d", i, want, i+1, e.EventName(), e.Sequence())
+		}
+	}
+}
+
+// seedScheduledDiscount stores an active product whose discount starts tomorrow.
+func seedScheduledDiscount(t *testing.T, repo *inMemoryProductRepo) string {
+	t.Helper()
+	d, err := domain.NewDiscount("15", baseTime.Add(24*time.Hour), baseTime.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-scheduled",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(100, "USD"),
+		Discount:  d,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+	return p.ID()
+}
+
+func TestDeactivateProduct_BlockedByScheduledDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := seedScheduledDiscount(t, repo)
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id})
+
+	if !errors.Is(err, domain.ErrScheduledDiscountPending) {
+		t.Fatalf("expected ErrScheduledDiscountPending, got %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit when deactivation is blocked")
+	}
+	if repo.store[id].Status() != domain.ProductStatusActive || repo.store[id].Discount() == nil {
+		t.Fatal("expected product to stay active with its scheduled discount")
+	}
+}
+
+func TestDeactivateProduct_ForceDropsScheduledDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := seedScheduledDiscount(t, repo)
+
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id, Force: true})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.store[id].Status() != domain.ProductStatusInactive {
+		t.Fatal("expected product to be inactive")
+	}
+	if repo.store[id].Discount() != nil {
+		t.Fatal("expected scheduled discount to be dropped")
+	}
+}
+
+func TestDeactivateProduct_NotFound(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+
+	err := it.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: "ghost"})
+
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// RemoveDiscount
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestRemoveDiscount_DoesNotReemitEarlierEvents(t *testing.T) {
+	// The in-memory repository hands back the same aggregate every time, like
+	// a cache would, so stale events would be persisted again.
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	removeIt := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := removeIt.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id}); err != nil {
+		t.Fatalf("remove discount: %v", err)
+	}
+
+	want := []string{"product.created", "product.discount_applied", "product.discount_removed"}
+	if len(eventRepo.events) != len(want) {
+		t.Fatalf("expected %d persisted events, got %d", len(want), len(eventRepo.events))
+	}
+	for i, e := range eventRepo.events {
+		if e.EventName() != want[i] || e.Sequence() != 1 {
+			t.Fatalf("event %d: expected %s with sequence 1, got %s with sequence %d", i, want[i], e.EventName(), e.Sequence())
+		}
+	}
+	if len(repo.store[id].Events()) != 0 {
+		t.Fatal("expected events to be cleared after commit")
+	}
+}
+
+func TestRemoveDiscount_Success(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	// Apply discount first
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "10",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	})
+
+	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.store[id].Discount() != nil {
+		t.Fatal("expected discount to be nil after removal")
+	}
+}
+
+func TestRemoveDiscount_NoActiveDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
+
+	if !errors.Is(err, domain.ErrNoActiveDiscount) {
+		t.Fatalf("expected ErrNoActiveDiscount, got %v", err)
+	}
+}
+
+func TestRemoveDiscount_Idempotent_NoDiscountIsNoOp(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.calls = 0
+	eventCount := len(eventRepo.events)
+
+	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id, Idempotent: true})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatalf("expected no commit, got %d", committer.calls)
+	}
+	if len(eventRepo.events) != eventCount {
+		t.Fatal("expected no discount event")
+	}
+}
+
+func TestRemoveDiscount_NotFound(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+
+	err := it.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: "ghost"})
+
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestRemoveDiscount_ThenGetProduct_PriceEqualsBase(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	// Apply then remove discount
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	_ = applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "30",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	})
+	removeIt := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	_ = removeIt.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id})
+
+	// After discount removal, effective price == base price
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error on get, got %v", err)
+	}
+	if dto.EffectivePrice.Amount != dto.BasePrice.Amount {
+		t.Fatalf("expected effective price %d == base price %d after discount removal",
+			dto.EffectivePrice.Amount, dto.BasePrice.Amount)
+	}
+	if dto.Discount != nil {
+		t.Fatal("expected no discount in DTO after removal")
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// RenameCategory
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestRenameCategory_MovesOnlyMatchingProducts(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	laptop := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	phone := createOne(t, repo, eventRepo, committer, ticker, "Phone", "electronics")
+	chair := createOne(t, repo, eventRepo, committer, ticker, "Chair", "furniture")
+	deactivateIt := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	if err := deactivateIt.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: phone}); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	eventRepo.events = nil
+
+	it := renamecategory.NewRenameCategoryInteractor(committer, repo, eventRepo, ticker)
+	renamed, err := it.Execute(context.Background(), &renamecategory.RenameCategoryRequest{
+		OldName: "electronics",
+		NewName: "consumer-electronics",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if renamed != 2 {
+		t.Fatalf("expected 2 renamed products, got %d", renamed)
+	}
+	for _, id := range []string{laptop, phone} {
+		if got := repo.store[id].Category(); got != "consumer-electronics" {
+			t.Fatalf("product %s: expected consumer-electronics, got %q", id, got)
+		}
+	}
+	if got := repo.store[chair].Category(); got != "furniture" {
+		t.Fatalf("expected furniture product untouched, got %q", got)
+	}
+
+	if len(eventRepo.events) != 2 {
+		t.Fatalf("expected one event per renamed product, got %d", len(eventRepo.events))
+	}
+	for _, event := range eventRepo.events {
+		e, ok := event.(*domain.ProductCategoryChangedEvent)
+		if !ok || e.From() != "electronics" || e.To() != "consumer-electronics" || e.ProductID() == chair {
+			t.Fatalf("unexpected event %T %+v", event, event)
+		}
+	}
+}
+
+func TestRenameCategory_RequiresBothNames(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := renamecategory.NewRenameCategoryInteractor(committer, repo, eventRepo, ticker)
+
+	_, err := it.Execute(context.Background(), &renamecategory.RenameCategoryRequest{OldName: "electronics"})
+	if !errors.Is(err, domain.ErrProductCategoryRequired) {
+		t.Fatalf("expected ErrProductCategoryRequired, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// BulkActivateProducts
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestBulkActivateProducts_ActivatesOnlyInactiveProducts(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	laptop := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	phone := createOne(t, repo, eventRepo, committer, ticker, "Phone", "electronics")
+	tablet := createOne(t, repo, eventRepo, committer, ticker, "Tablet", "electronics")
+	chair := createOne(t, repo, eventRepo, committer, ticker, "Chair", "furniture")
+	deactivateIt := deactivateproduct.NewDeactivateProductInteractor(committer, repo, eventRepo, ticker)
+	for _, id := range []string{phone, tablet, chair} {
+		if err := deactivateIt.Execute(context.Background(), &deactivateproduct.DeactivateProductRequest{ProductID: id}); err != nil {
+			t.Fatalf("deactivate: %v", err)
+		}
+	}
+	archivedAt := baseTime.Add(-time.Hour)
+	archived, err := domain.Reconstitute(domain.ProductState{
+		ID:         "archived-1",
+		Name:       "Old Phone",
+		Category:   "electronics",
+		BasePrice:  domain.MustNewMoney(100, "USD"),
+		Status:     domain.ProductStatusInactive,
+		ArchivedAt: &archivedAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[archived.ID()] = archived
+	eventRepo.events = nil
+
+	it := bulkactivateproducts.NewBulkActivateProductsInteractor(committer, repo, eventRepo, ticker)
+	activated, err := it.Execute(context.Background(), &bulkactivateproducts.BulkActivateProductsRequest{Category: "electronics"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if activated != 2 {
+		t.Fatalf("expected 2 activated products, got %d", activated)
+	}
+	for _, id := range []string{laptop, phone, tablet} {
+		if got := repo.store[id].Status(); got != domain.ProductStatusActive {
+			t.Fatalf("product %s: expected active, got %q", id, got)
+		}
+	}
+	if got := repo.store[chair].Status(); got != domain.ProductStatusInactive {
+		t.Fatalf("expected the furniture product to stay inactive, got %q", got)
+	}
+	if got := repo.store[archived.ID()].Status(); got != domain.ProductStatusInactive {
+		t.Fatalf("expected the archived product to stay inactive, got %q", got)
+	}
+
+	if len(eventRepo.events) != 2 {
+		t.Fatalf("expected one event per activated product, got %d", len(eventRepo.events))
+	}
+	for _, event := range eventRepo.events {
+		e, ok := event.(*domain.ProductActivatedEvent)
+		if !ok || (e.ProductID() != phone && e.ProductID() != tablet) {
+			t.Fatalf("unexpected event %T %+v", event, event)
+		}
+	}
+}
+
+func TestBulkActivateProducts_NothingToActivate(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	calls := committer.calls
+
+	it := bulkactivateproducts.NewBulkActivateProductsInteractor(committer, repo, eventRepo, ticker)
+	activated, err := it.Execute(context.Background(), &bulkactivateproducts.BulkActivateProductsRequest{Category: "electronics"})
+
+	if err != nil || activated != 0 {
+		t.Fatalf("expected 0 activated without error, got %d, %v", activated, err)
+	}
+	if committer.calls != calls {
+		t.Fatal("expected no commit when every product is already active")
+	}
+}
+
+func TestBulkActivateProducts_RequiresCategory(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := bulkactivateproducts.NewBulkActivateProductsInteractor(committer, repo, eventRepo, ticker)
+
+	_, err := it.Execute(context.Background(), &bulkactivateproducts.BulkActivateProductsRequest{})
+	if !errors.Is(err, domain.ErrProductCategoryRequired) {
+		t.Fatalf("expected ErrProductCategoryRequired, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// ForceClearDiscount
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestForceClearDiscount_ClearsUnloadableDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	repo.corrupt = map[string]bool{id: true}
+
+	removeIt := removediscount.NewRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := removeIt.Execute(context.Background(), &removediscount.RemoveDiscountRequest{ProductID: id}); err == nil {
+		t.Fatal("expected the regular remove path to fail on a malformed discount")
+	}
+
+	it := forcecleardiscount.NewForceClearDiscountInteractor(committer, repo, repo, eventRepo, ticker)
+	if err := it.Execute(context.Background(), &forcecleardiscount.ForceClearDiscountRequest{ProductID: id}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := repo.GetByID(context.Background(), id); err != nil {
+		t.Fatalf("expected the product to load after force-clear, got %v", err)
+	}
+	last := eventRepo.events[len(eventRepo.events)-1]
+	if e, ok := last.(*domain.DiscountRemovedEvent); !ok || e.ProductID() != id {
+		t.Fatalf("expected DiscountRemovedEvent for %s, got %T", id, last)
+	}
+}
+
+func TestForceClearDiscount_ProductNotFound(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	committer.calls = 0
+
+	it := forcecleardiscount.NewForceClearDiscountInteractor(committer, repo, repo, eventRepo, ticker)
+	err := it.Execute(context.Background(), &forcecleardiscount.ForceClearDiscountRequest{ProductID: "missing"})
+
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit for an unknown product")
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// BulkRemoveDiscount
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestBulkRemoveDiscount_RemovesCampaignInOneCommit(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	apply := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	discounted := func(name, category string) string {
+		id := createOne(t, repo, eventRepo, committer, ticker, name, category)
+		if err := apply.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+			ProductID:  id,
+			Percentage: "15",
+			StartsAt:   baseTime.Add(-time.Hour),
+			EndsAt:     baseTime.Add(24 * time.Hour),
+		}); err != nil {
+			t.Fatalf("apply discount: %v", err)
+		}
+		return id
+	}
+
+	a := discounted("Shirt", "apparel")
+	b := discounted("Hat", "apparel")
+	plain := createOne(t, repo, eventRepo, committer, ticker, "Socks", "apparel")
+	other := discounted("Lamp", "home")
+	committer.calls = 0
+	eventRepo.events = nil
+
+	it := bulkremovediscount.NewBulkRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	res, err := it.Execute(context.Background(), &bulkremovediscount.BulkRemoveDiscountRequest{Category: "apparel"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Removed != 2 || res.Skipped != 1 {
+		t.Fatalf("expected 2 removals and 1 skip, got %+v", res)
+	}
+	want := []bulkremovediscount.ProductOutcome{
+		{ProductID: a, Outcome: bulkremovediscount.OutcomeRemoved},
+		{ProductID: b, Outcome: bulkremovediscount.OutcomeRemoved},
+		{ProductID: plain, Outcome: bulkremovediscount.OutcomeSkipped},
+	}
+	slices.SortFunc(want, func(x, y bulkremovediscount.ProductOutcome) int { return strings.Compare(x.ProductID, y.ProductID) })
+	if !slices.Equal(res.Products, want) {
+		t.Fatalf("expected per-product outcomes %+v, got %+v", want, res.Products)
+	}
+	if committer.calls != 1 {
+		t.Fatalf("expected a single commit, got %d", committer.calls)
+	}
+	removals := 0
+	for _, e := range eventRepo.events {
+		if _, ok := e.(*domain.DiscountRemovedEvent); ok {
+			removals++
+		}
+	}
+	if removals != 2 {
+		t.Fatalf("expected 2 removal events, got %d", removals)
+	}
+	for _, id := range []string{a, b, plain} {
+		if repo.store[id].Discount() != nil {
+			t.Fatalf("expected no discount on %s", id)
+		}
+	}
+	if repo.store[other].Discount() == nil {
+		t.Fatal("discount in another category must be untouched")
+	}
+}
+
+func TestBulkRemoveDiscount_NothingToRemove(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Socks", "apparel")
+	committer.calls = 0
+
+	it := bulkremovediscount.NewBulkRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	res, err := it.Execute(context.Background(), &bulkremovediscount.BulkRemoveDiscountRequest{Category: "apparel"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res.Removed != 0 || committer.calls != 0 {
+		t.Fatalf("expected no removals and no commit, got %d removals and %d commits", res.Removed, committer.calls)
+	}
+	if len(res.Products) != 1 || res.Products[0].Outcome != bulkremovediscount.OutcomeSkipped {
+		t.Fatalf("expected the product reported as skipped, got %+v", res.Products)
+	}
+}
+
+func TestBulkRemoveDiscount_RerunSkipsEverything(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Shirt", "apparel")
+	if err := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker).Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "15",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	}); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	it := bulkremovediscount.NewBulkRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+
+	first, err := it.Execute(context.Background(), &bulkremovediscount.BulkRemoveDiscountRequest{Category: "apparel"})
+	if err != nil || first.Removed != 1 {
+		t.Fatalf("expected the discount removed, got %+v (err=%v)", first, err)
+	}
+	committer.calls = 0
+	second, err := it.Execute(context.Background(), &bulkremovediscount.BulkRemoveDiscountRequest{Category: "apparel"})
+	if err != nil || second.Removed != 0 || second.Skipped != 1 || committer.calls != 0 {
+		t.Fatalf("expected a rerun to skip the product without committing, got %+v, %d commits (err=%v)", second, committer.calls, err)
+	}
+}
+
+func TestBulkRemoveDiscount_CategoryRequired(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+
+	it := bulkremovediscount.NewBulkRemoveDiscountInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &bulkremovediscount.BulkRemoveDiscountRequest{})
+
+	if !errors.Is(err, domain.ErrProductCategoryRequired) {
+		t.Fatalf("expected ErrProductCategoryRequired, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// ApplyDiscountSchedule
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestApplyDiscountSchedule_PartialResults(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	laptop := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	mouse := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	retired := createOne(t, repo, eventRepo, committer, ticker, "Fax", "electronics")
+	_ = repo.store[retired].Deactivate(baseTime, false)
+	monitor := createOne(t, repo, eventRepo, committer, ticker, "Monitor", "electronics")
+	cable := createOne(t, repo, eventRepo, committer, ticker, "Cable", "electronics")
+	day := 24 * time.Hour
+	onSale, _ := domain.NewDiscount("10", baseTime.Add(-day), baseTime.Add(2*day))
+	_ = repo.store[monitor].ApplyDiscount(onSale, baseTime)
+	committer.calls = 0
+
+	it := applydiscountschedule.NewApplyDiscountScheduleInteractor(committer, repo, eventRepo, ticker)
+	results, err := it.Execute(context.Background(), &applydiscountschedule.ApplyDiscountScheduleRequest{
+		Entries: []applydiscountschedule.ScheduleEntry{
+			{ProductID: laptop, Percentage: "20", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: mouse, Percentage: "150", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: "ghost", Percentage: "10", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: retired, Percentage: "10", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: monitor, Percentage: "30", StartsAt: baseTime.Add(36 * time.Hour), EndsAt: baseTime.Add(3 * day)},
+			{ProductID: cable, Percentage: "10", StartsAt: baseTime.Add(-2 * day), EndsAt: baseTime.Add(-day)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []error{
+		nil,
+		domain.ErrDiscountInvalidPercentage,
+		domain.ErrProductNotFound,
+		domain.ErrProductNotActive,
+		domain.ErrDiscountOverlap,
+		domain.ErrInvalidDiscountPeriod,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(results))
+	}
+	for i, w := range want {
+		if !errors.Is(results[i].Err, w) {
+			t.Fatalf("entry %d: expected %v, got %v", i, w, results[i].Err)
+		}
+	}
+	if committer.calls != 1 {
+		t.Fatalf("expected a single commit, got %d", committer.calls)
+	}
+	d := repo.store[laptop].Discount()
+	if d == nil || d.Percentage() != "20.0" || d.StateAt(baseTime) != domain.DiscountStateUpcoming {
+		t.Fatalf("expected upcoming 20%% discount on laptop, got %+v", d)
+	}
+	if repo.store[mouse].Discount() != nil {
+		t.Fatal("expected mouse to have no discount")
+	}
+}
+
+func TestApplyDiscountSchedule_RejectsRepeatedProduct(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	laptop := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	mouse := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	committer.calls = 0
+
+	day := 24 * time.Hour
+	it := applydiscountschedule.NewApplyDiscountScheduleInteractor(committer, repo, eventRepo, ticker)
+	_, err := it.Execute(context.Background(), &applydiscountschedule.ApplyDiscountScheduleRequest{
+		Entries: []applydiscountschedule.ScheduleEntry{
+			{ProductID: laptop, Percentage: "20", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: mouse, Percentage: "10", StartsAt: baseTime.Add(day), EndsAt: baseTime.Add(2 * day)},
+			{ProductID: laptop, Percentage: "30", StartsAt: baseTime.Add(3 * day), EndsAt: baseTime.Add(4 * day)},
+		},
+	})
+
+	if !errors.Is(err, domain.ErrDuplicateIDInBatch) || !strings.Contains(err.Error(), laptop) {
+		t.Fatalf("expected ErrDuplicateIDInBatch naming %s, got %v", laptop, err)
+	}
+	if committer.calls != 0 {
+		t.Fatal("expected no commit for a schedule with a repeated product")
+	}
+	if repo.store[laptop].Discount() != nil || repo.store[mouse].Discount() != nil {
+		t.Fatal("expected no discount applied")
+	}
+}
+
+func TestApplyDiscountSchedule_CommitsInBatches(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	entries := make([]applydiscountschedule.ScheduleEntry, applydiscountschedule.BatchSize+1)
+	for i := range entries {
+		id := createOne(t, repo, eventRepo, committer, ticker, fmt.Sprintf("Product %d", i), "electronics")
+		entries[i] = applydiscountschedule.ScheduleEntry{
+			ProductID: id, Percentage: "5", StartsAt: baseTime.Add(time.Hour), EndsAt: baseTime.Add(2 * time.Hour),
+		}
+	}
+	committer.calls = 0
+
+	it := applydiscountschedule.NewApplyDiscountScheduleInteractor(committer, repo, eventRepo, ticker)
+	results, err := it.Execute(context.Background(), &applydiscountschedule.ApplyDiscountScheduleRequest{Entries: entries})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if committer.calls != 2 {
+		t.Fatalf("expected 2 batched commits, got %d", committer.calls)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Fatalf("entry %d: unexpected error %v", i, r.Err)
+		}
+	}
+}
+
+func TestApplyDiscountSchedule_CommitFailureFailsBatch(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	committer.err = errors.New("spanner unavailable")
+
+	it := applydiscountschedule.NewApplyDiscountScheduleInteractor(committer, repo, eventRepo, ticker)
+	results, err := it.Execute(context.Background(), &applydiscountschedule.ApplyDiscountScheduleRequest{
+		Entries: []applydiscountschedule.ScheduleEntry{
+			{ProductID: id, Percentage: "20", StartsAt: baseTime.Add(time.Hour), EndsAt: baseTime.Add(2 * time.Hour)},
+			{ProductID: "ghost", Percentage: "20", StartsAt: baseTime.Add(time.Hour), EndsAt: baseTime.Add(2 * time.Hour)},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !errors.Is(results[0].Err, committer.err) {
+		t.Fatalf("expected commit error on applied entry, got %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", results[1].Err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Snapshot
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestSnapshot_UnaffectedByLaterMutation(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(1000, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	snap := p.Snapshot()
+	if len(snap.Events()) != 0 {
+		t.Fatal("snapshot must not carry pending events")
+	}
+
+	if err := p.SetName("Renamed"); err != nil {
+		t.Fatalf("set name: %v", err)
+	}
+	d, err := domain.NewDiscount("10", baseTime, baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	if err := p.ApplyDiscount(d, baseTime); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	if err := p.Deactivate(baseTime, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+
+	if snap.Name() != "Laptop" || snap.Discount() != nil || !snap.IsActive() {
+		t.Fatalf("snapshot changed: name=%q discount=%v status=%s", snap.Name(), snap.Discount(), snap.Status())
+	}
+	if snap.Changes().Dirty(domain.FieldName) {
+		t.Fatal("snapshot must not share dirty tracking with the original")
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Restore
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestRestore_NotArchived_NoEvent(t *testing.T) {
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-1",
+		Name:      "Laptop",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(100, "USD"),
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+
+	if err := p.Restore(baseTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(p.Events()) != 0 {
+		t.Fatalf("expected no events, got %d", len(p.Events()))
+	}
+	if p.Changes().Dirty(domain.FieldArchivedAt) {
+		t.Fatal("expected archived_at to stay clean")
+	}
+}
+
+func TestRestore_Archived_RaisesEvent(t *testing.T) {
+	archivedAt := baseTime.Add(-time.Hour)
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:         "p-1",
+		Name:       "Laptop",
+		Category:   "electronics",
+		BasePrice:  domain.MustNewMoney(100, "USD"),
+		Status:     domain.ProductStatusActive,
+		ArchivedAt: &archivedAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+
+	if err := p.Restore(baseTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.IsArchived() {
+		t.Fatal("expected product to no longer be archived")
+	}
+	if len(p.Events()) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(p.Events()))
+	}
+	if _, ok := p.Events()[0].(*domain.ProductRestoredEvent); !ok {
+		t.Fatalf("expected ProductRestoredEvent, got %T", p.Events()[0])
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Pricing rounding
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestPricingCalculator_RoundingModes(t *testing.T) {
+	discount, err := domain.NewDiscount("50", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+
+	cases := []struct {
+		mode       domain.RoundingMode
+		base       int64
+		wantAmount int64
+	}{
+		// 3 cents at 50% = 1.5 cents
+		{domain.RoundHalfUp, 3, 2},
+		{domain.RoundHalfEven, 3, 2},
+		{domain.RoundFloor, 3, 1},
+		// 5 cents at 50% = 2.5 cents
+		{domain.RoundHalfUp, 5, 3},
+		{domain.RoundHalfEven, 5, 2},
+		{domain.RoundFloor, 5, 2},
+	}
+
+	for _, tc := range cases {
+		pc := services.NewPricingCalculator(services.WithRoundingMode(tc.mode))
+		got, err := pc.EffectivePrice(domain.MustNewMoney(tc.base, "USD"), discount, baseTime)
+		if err != nil {
+			t.Fatalf("mode %d base %d: %v", tc.mode, tc.base, err)
+		}
+		if got.Amount() != tc.wantAmount {
+			t.Fatalf("mode %d base %d: expected %d, got %d", tc.mode, tc.base, tc.wantAmount, got.Amount())
+		}
+	}
+}
+
+func TestPricingCalculator_DefaultIsHalfUp(t *testing.T) {
+	discount, _ := domain.NewDiscount("50", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+
+	got, err := services.NewPricingCalculator().EffectivePrice(domain.MustNewMoney(5, "USD"), discount, baseTime)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Amount() != 3 {
+		t.Fatalf("expected default half-up rounding to give 3, got %d", got.Amount())
+	}
+}
+
+func TestPricingCalculator_DiscountsDisabledServesBasePrice(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	err := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker).Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "20",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+
+	disabled := true
+	pc := services.NewPricingCalculator(services.WithDiscountsDisabled(func() bool { return disabled }))
+	q := getproduct.NewGetProductQuery(repo, pc, ticker)
+
+	dto, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.EffectivePrice.Amount != dto.BasePrice.Amount {
+		t.Fatalf("expected base price while disabled, got %d vs %d", dto.EffectivePrice.Amount, dto.BasePrice.Amount)
+	}
+	if dto.Discount == nil || dto.Discount.IsActive {
+		t.Fatal("expected the stored discount to be reported but not active while disabled")
+	}
+	if pc.IsDiscounted(repo.store[id].Discount(), baseTime) {
+		t.Fatal("expected IsDiscounted to be false while disabled")
+	}
+	if repo.store[id].Discount() == nil {
+		t.Fatal("disabling discounts must not remove the stored discount")
+	}
+
+	disabled = false
+	dto, err = q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.EffectivePrice.Amount >= dto.BasePrice.Amount || !dto.Discount.IsActive {
+		t.Fatal("expected the discount to apply again once re-enabled")
+	}
+}
+
+func TestMoney_DiscountStaysWithinBaseForSmallestAmounts(t *testing.T) {
+	modes := []domain.RoundingMode{domain.RoundHalfUp, domain.RoundHalfEven, domain.RoundFloor}
+	percentages := []float64{0, 1, 33.33, 50, 99.9, 100}
+
+	for _, base := range []int64{1, 2, 3} {
+		for _, mode := range modes {
+			for _, pct := range percentages {
+				got, err := domain.MustNewMoney(base, "USD").ApplyPercentageDiscountRounded(pct, mode)
+				if err != nil {
+					t.Fatalf("base %d mode %d pct %v: %v", base, mode, pct, err)
+				}
+				if got.Amount() < 0 || got.Amount() > base {
+					t.Fatalf("base %d mode %d pct %v: expected amount in [0, %d], got %d", base, mode, pct, base, got.Amount())
+				}
+			}
+		}
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Money JSON
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	m := domain.MustNewMoney(1999, "USD")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(b) != `{"amount":1999,"currency":"USD"}` {
+		t.Fatalf("unexpected JSON %s", b)
+	}
+
+	var got domain.Money
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !got.Equals(m) {
+		t.Fatalf("expected %s, got %s", m, &got)
+	}
+}
+
+func TestMoney_UnmarshalJSON_InvalidCurrency(t *testing.T) {
+	var m domain.Money
+	err := json.Unmarshal([]byte(`{"amount":100,"currency":"US"}`), &m)
+
+	if !errors.Is(err, domain.ErrInvalidCurrency) {
+		t.Fatalf("expected ErrInvalidCurrency, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Money arithmetic overflow
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestMoney_AddOverflow(t *testing.T) {
+	big := domain.MustNewMoney(math.MaxInt64-1, "USD")
+
+	got, err := big.Add(domain.MustNewMoney(1, "USD"))
+	if err != nil || got.Amount() != math.MaxInt64 {
+		t.Fatalf("expected MaxInt64 without error, got %v, %v", got, err)
+	}
+	if _, err := big.Add(domain.MustNewMoney(2, "USD")); !errors.Is(err, domain.ErrAmountOverflow) {
+		t.Fatalf("expected ErrAmountOverflow, got %v", err)
+	}
+}
+
+func TestMoney_MultiplyOverflow(t *testing.T) {
+	m := domain.MustNewMoney(100_000_000, "USD")
+
+	got, err := m.Multiply(1e9)
+	if err != nil || got.Amount() != 100_000_000*1e9 {
+		t.Fatalf("expected large product without error, got %v, %v", got, err)
+	}
+	for _, factor := range []float64{1e12, math.Inf(1), math.NaN()} {
+		if _, err := m.Multiply(factor); !errors.Is(err, domain.ErrAmountOverflow) {
+			t.Fatalf("factor %v: expected ErrAmountOverflow, got %v", factor, err)
+		}
+	}
+	if _, err := domain.MustNewMoney(math.MaxInt64, "USD").Multiply(2); !errors.Is(err, domain.ErrAmountOverflow) {
+		t.Fatalf("expected ErrAmountOverflow near MaxInt64, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Money from decimal strings
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestNewMoneyFromDecimalString_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		in, currency string
+		want         int64
+	}{
+		{"19.99", "USD", 1999},
+		{"19.9", "USD", 1990},
+		{"19", "USD", 1900},
+		{"0.05", "EUR", 5},
+		{"25000", "VND", 25000},
+		{"1.250", "KWD", 1250},
+	} {
+		m, err := domain.NewMoneyFromDecimalString(tc.in, tc.currency)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error %v", tc.in, tc.currency, err)
+		}
+		if m.Amount() != tc.want || m.Currency() != tc.currency {
+			t.Fatalf("%s %s: expected %d, got %d %s", tc.in, tc.currency, tc.want, m.Amount(), m.Currency())
+		}
+	}
+}
+
+func TestNewMoneyFromDecimalString_OverPrecise(t *testing.T) {
+	for _, tc := range [][2]string{{"19.999", "USD"}, {"10.5", "VND"}, {"1.0", "JPY"}} {
+		if _, err := domain.NewMoneyFromDecimalString(tc[0], tc[1]); !errors.Is(err, domain.ErrAmountTooPrecise) {
+			t.Fatalf("%s %s: expected ErrAmountTooPrecise, got %v", tc[0], tc[1], err)
+		}
+	}
+}
+
+func TestNewMoneyFromDecimalString_Malformed(t *testing.T) {
+	for _, in := range []string{"", "abc", "1.", ".5", "1e3", "1,50", "19.99.1"} {
+		if _, err := domain.NewMoneyFromDecimalString(in, "USD"); !errors.Is(err, domain.ErrInvalidDecimalAmount) {
+			t.Fatalf("%q: expected ErrInvalidDecimalAmount, got %v", in, err)
+		}
+	}
+	if _, err := domain.NewMoneyFromDecimalString("-1.00", "USD"); !errors.Is(err, domain.ErrNegativeAmount) {
+		t.Fatalf("expected ErrNegativeAmount, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// PriceAlerting
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestPriceAlerting_DownwardCrossing(t *testing.T) {
+	pa := services.NewPriceAlerting()
+	thresholds := []*domain.Money{domain.MustNewMoney(5000, "USD"), domain.MustNewMoney(8000, "USD"), domain.MustNewMoney(10000, "USD")}
+
+	got, err := pa.Crossings(domain.MustNewMoney(10000, "USD"), domain.MustNewMoney(7000, "USD"), thresholds)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 crossings, got %+v", got)
+	}
+	for i, want := range []int64{8000, 10000} {
+		if got[i].Threshold.Amount() != want || got[i].Direction != services.CrossedBelow {
+			t.Fatalf("crossing %d: expected below %d, got %+v", i, want, got[i])
+		}
+	}
+}
+
+func TestPriceAlerting_UpwardCrossing(t *testing.T) {
+	pa := services.NewPriceAlerting()
+	thresholds := []*domain.Money{domain.MustNewMoney(8000, "USD")}
+
+	// Landing exactly on the threshold counts as back above it.
+	got, err := pa.Crossings(domain.MustNewMoney(7000, "USD"), domain.MustNewMoney(8000, "USD"), thresholds)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 1 || got[0].Direction != services.CrossedAbove {
+		t.Fatalf("expected one upward crossing, got %+v", got)
+	}
+}
+
+func TestPriceAlerting_NoCrossing(t *testing.T) {
+	pa := services.NewPriceAlerting()
+	thresholds := []*domain.Money{domain.MustNewMoney(5000, "USD"), domain.MustNewMoney(10000, "USD")}
+
+	for _, tc := range []struct{ before, after int64 }{
+		{9000, 6000},   // moves between thresholds
+		{4000, 3000},   // stays below both
+		{10000, 12000}, // stays at or above both
+		{8000, 8000},   // unchanged
+	} {
+		got, err := pa.Crossings(domain.MustNewMoney(tc.before, "USD"), domain.MustNewMoney(tc.after, "USD"), thresholds)
+		if err != nil || len(got) != 0 {
+			t.Fatalf("%d -> %d: expected no crossings, got %+v, %v", tc.before, tc.after, got, err)
+		}
+	}
+}
+
+func TestPriceAlerting_CurrencyMismatch(t *testing.T) {
+	pa := services.NewPriceAlerting()
+
+	_, err := pa.Crossings(domain.MustNewMoney(10000, "USD"), domain.MustNewMoney(7000, "USD"),
+		[]*domain.Money{domain.MustNewMoney(8000, "EUR")})
+	if !errors.Is(err, domain.ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Accept-Currency conversion
+// ────────────────────────────────────────────────────────────────────────────
+
+// fixedRates serves rates keyed "FROM:TO".
+type fixedRates map[string]float64
+
+func (r fixedRates) Rate(_ context.Context, from, to string) (float64, error) {
+	if rate, ok := r[from+":"+to]; ok {
+		return rate, nil
+	}
+	return 0, domain.ErrUnsupportedCurrency
+}
+
+func TestGetProduct_ConvertsToRequestedCurrency(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker, getproduct.WithExchangeRates(fixedRates{"USD:VND": 25000}))
+
+	stored, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := q.Execute(common.WithCurrency(context.Background(), "VND"), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// USD has 2 decimal places and VND none: 1 cent is 250 VND.
+	want := getproduct.MoneyDTO{Amount: stored.BasePrice.Amount * 250, Currency: "VND"}
+	if got.BasePrice != want || got.EffectivePrice != want {
+		t.Fatalf("expected %+v, got base %+v, effective %+v", want, got.BasePrice, got.EffectivePrice)
+	}
+	if got.ConvertedCurrency != "VND" {
+		t.Fatalf("expected ConvertedCurrency VND, got %q", got.ConvertedCurrency)
+	}
+}
+
+func TestGetProduct_UnsupportedCurrencyFallsBack(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker, getproduct.WithExchangeRates(fixedRates{"USD:VND": 25000}))
+
+	got, err := q.Execute(common.WithCurrency(context.Background(), "XAU"), &getproduct.GetProductRequest{ProductID: id})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.BasePrice.Currency != "USD" || got.EffectivePrice.Currency != "USD" || got.ConvertedCurrency != "" {
+		t.Fatalf("expected prices in the stored USD, got %+v", got)
+	}
+}
+
+func TestBatchGetProducts_OrderedFollowsRequestOrder(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	a := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	b := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	c := createOne(t, repo, eventRepo, committer, ticker, "Monitor", "electronics")
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	ids := []string{c, a, "missing", b, a}
+	got, err := q.ExecuteBatchOrdered(context.Background(), &getproduct.BatchGetProductsRequest{ProductIDs: ids})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(got))
+	}
+	for i, id := range ids {
+		switch {
+		case id == "missing" && got[i] != nil:
+			t.Fatalf("result %d: expected nil for the missing id, got %q", i, got[i].ID)
+		case id != "missing" && (got[i] == nil || got[i].ID != id):
+			t.Fatalf("result %d: expected %q, got %+v", i, id, got[i])
+		}
+	}
+}
+
+func TestBatchGetProducts_LoadsRepeatedIDOnce(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	a := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	b := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "electronics")
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	ids := []string{a, b, a}
+	got, err := q.ExecuteBatchOrdered(context.Background(), &getproduct.BatchGetProductsRequest{ProductIDs: ids})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(repo.batchIDs, []string{a, b}) {
+		t.Fatalf("expected each id loaded once, got %v", repo.batchIDs)
+	}
+	if len(got) != 3 || got[0] == nil || got[0].ID != a || got[1] == nil || got[1].ID != b || got[2] == nil || got[2].ID != a {
+		t.Fatalf("expected the repeated id at both positions, got %+v", got)
+	}
+}
+
+func TestBatchGetProducts_MapSkipsMissingAndArchived(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	archivedAt := baseTime
+	archived, err := domain.Reconstitute(domain.ProductState{
+		ID:         "p-archived",
+		Name:       "Old",
+		Category:   "electronics",
+		BasePrice:  domain.MustNewMoney(1000, "USD"),
+		Status:     domain.ProductStatusInactive,
+		ArchivedAt: &archivedAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[archived.ID()] = archived
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	got, err := q.ExecuteBatch(context.Background(), &getproduct.BatchGetProductsRequest{
+		ProductIDs: []string{"missing", archived.ID(), id, ""},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got) != 1 || got[id] == nil || got[id].Name != "Keyboard" {
+		t.Fatalf("expected only %q, got %+v", id, got)
+	}
+}
+
+func TestBatchGetProducts_PartialRejectsUnknownDiscountState(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	_, err := q.ExecuteBatchPartial(context.Background(), &getproduct.BatchGetProductsRequest{
+		ProductIDs:            []string{"not-an-id"},
+		IncludeDiscountStates: []string{"bogus"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unknown discount state, got nil")
+	}
+}
+
+func TestGetProduct_RoundingAuditReportsDelta(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	d, err := domain.NewDiscount("15", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	// 15% off 999 cents is exactly 849.15 cents, rounded to 849.
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:        "p-audit",
+		Name:      "Mouse",
+		Category:  "electronics",
+		BasePrice: domain.MustNewMoney(999, "USD"),
+		Discount:  d,
+		Status:    domain.ProductStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+
+	plain, err := getproduct.NewGetProductQuery(repo, pricing, ticker).Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID()})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if plain.RoundingDelta != nil {
+		t.Fatalf("expected no rounding delta without the audit, got %v", *plain.RoundingDelta)
+	}
+
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker, getproduct.WithRoundingAudit())
+	got, err := q.Execute(context.Background(), &getproduct.GetProductRequest{ProductID: p.ID()})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.EffectivePrice.Amount != 849 {
+		t.Fatalf("expected effective price 849, got %d", got.EffectivePrice.Amount)
+	}
+	if got.RoundingDelta == nil || math.Abs(*got.RoundingDelta-0.15) > 1e-9 {
+		t.Fatalf("expected rounding delta 0.15, got %v", got.RoundingDelta)
+	}
+}
+
+func TestListProducts_ConvertsToRequestedCurrency(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig(),
+		listproducts.WithExchangeRates(fixedRates{"USD:VND": 25000}))
+
+	stored, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{})
+	if err != nil || len(stored.Items) != 1 {
+		t.Fatalf("expected one item, got %+v, %v", stored, err)
+	}
+	usd := stored.Items[0].BasePrice.Amount
+
+	resp, err := q.Execute(common.WithCurrency(context.Background(), "VND"), &listproducts.ListProductsRequest{})
+	if err != nil || len(resp.Items) != 1 {
+		t.Fatalf("expected one item, got %+v, %v", resp, err)
+	}
+	item := resp.Items[0]
+	if item.BasePrice != (listproducts.MoneyDTO{Amount: usd * 250, Currency: "VND"}) || item.ConvertedCurrency != "VND" {
+		t.Fatalf("expected a VND price, got %+v", item)
+	}
+
+	resp, err = q.Execute(common.WithCurrency(context.Background(), "XAU"), &listproducts.ListProductsRequest{})
+	if err != nil || len(resp.Items) != 1 {
+		t.Fatalf("expected one item, got %+v, %v", resp, err)
+	}
+	if item := resp.Items[0]; item.BasePrice.Currency != "USD" || item.ConvertedCurrency != "" {
+		t.Fatalf("expected an unsupported currency to fall back to USD, got %+v", item)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Product media
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestProductSetMedia_RejectsBadURLs(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	for _, bad := range []string{
+		"",
+		"cdn.example.com/a.jpg",       // no scheme
+		"/images/a.jpg",               // relative
+		"ftp://cdn.example.com/a.jpg", // unsupported scheme
+		"https://",                    // no host
+		"https://cdn.example.com/%zz", // malformed escape
+		"https://cdn.example.com/" + strings.Repeat("a", domain.MaxMediaURLLength),
+	} {
+		if err := p.SetMedia([]string{"https://cdn.example.com/ok.jpg", bad}, baseTime); !errors.Is(err, domain.ErrInvalidMediaURL) {
+			t.Fatalf("%q: expected ErrInvalidMediaURL, got %v", bad, err)
+		}
+	}
+	if len(p.MediaURLs()) != 0 || p.Changes().Dirty(domain.FieldMedia) {
+		t.Fatalf("expected rejected media to leave the product untouched, got %v", p.MediaURLs())
+	}
+}
+
+func TestProductSetMedia_CountCap(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	urls := make([]string, domain.MaxMediaURLs+1)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://cdn.example.com/%d.jpg", i)
+	}
+	if err := p.SetMedia(urls, baseTime); !errors.Is(err, domain.ErrTooManyMediaURLs) {
+		t.Fatalf("expected ErrTooManyMediaURLs, got %v", err)
+	}
+	if err := p.SetMedia(urls[:domain.MaxMediaURLs], baseTime); err != nil {
+		t.Fatalf("expected %d URLs to be accepted, got %v", domain.MaxMediaURLs, err)
+	}
+	if !slices.Equal(p.MediaURLs(), urls[:domain.MaxMediaURLs]) || !p.Changes().Dirty(domain.FieldMedia) {
+		t.Fatalf("expected media set and marked dirty, got %v", p.MediaURLs())
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Product slugs
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestSlugify(t *testing.T) {
+	for name, want := range map[string]string{
+		"Wireless Keyboard":           "wireless-keyboard",
+		"  USB-C   Hub (4 ports)!  ":  "usb-c-hub-4-ports",
+		"Bàn phím không dây Đen":      "ban-phim-khong-day-den",
+		"Café Crème":                  "cafe-creme",
+		"★★★":                         "product",
+		strings.Repeat("ab-", 60):     strings.Repeat("ab-", 33) + "a", // cut at MaxSlugLength
+		"Model 3000 / 2nd Generation": "model-3000-2nd-generation",
+	} {
+		if got := domain.Slugify(name); got != want {
+			t.Fatalf("Slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUniqueSlug_SuffixesCollisions(t *testing.T) {
+	if got := domain.UniqueSlug("keyboard", []string{"keyboard-2"}); got != "keyboard" {
+		t.Fatalf("expected a free base to be kept, got %q", got)
+	}
+	if got := domain.UniqueSlug("keyboard", []string{"keyboard", "keyboard-2", "keyboard-4"}); got != "keyboard-3" {
+		t.Fatalf("expected the lowest free suffix, got %q", got)
+	}
+	long := strings.Repeat("a", domain.MaxSlugLength)
+	if got := domain.UniqueSlug(long, []string{long}); len(got) > domain.MaxSlugLength || !strings.HasSuffix(got, "-2") {
+		t.Fatalf("expected a suffixed slug within %d bytes, got %q", domain.MaxSlugLength, got)
+	}
+}
+
+func TestCreateProduct_SlugCollisionGetsSuffix(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	first := createOne(t, repo, eventRepo, committer, ticker, "Wireless Keyboard", "electronics")
+	second := createOne(t, repo, eventRepo, committer, ticker, "Wireless  keyboard!", "electronics")
+	third := createOne(t, repo, eventRepo, committer, ticker, "Wireless Keyboard", "electronics")
+
+	for id, want := range map[string]string{first: "wireless-keyboard", second: "wireless-keyboard-2", third: "wireless-keyboard-3"} {
+		if got := repo.store[id].Slug(); got != want {
+			t.Fatalf("product %s: expected slug %q, got %q", id, want, got)
+		}
+	}
+}
+
+func TestCreateProduct_SlugConflictAtCommit(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	committer.err = status.Error(codes.AlreadyExists, "unique index violation on products_by_slug")
+
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{Name: "Keyboard", Category: "electronics", Amount: amount(100)})
+
+	if !errors.Is(err, domain.ErrSlugConflict) {
+		t.Fatalf("expected ErrSlugConflict, got %v", err)
+	}
+}
+
+func TestGetProductBySlug(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Wireless Keyboard", "electronics")
+	q := getproduct.NewGetProductQuery(repo, pricing, ticker)
+
+	dto, err := q.ExecuteBySlug(context.Background(), &getproduct.GetProductBySlugRequest{Slug: "wireless-keyboard"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.ID != id || dto.Slug != "wireless-keyboard" {
+		t.Fatalf("expected product %s by slug, got %+v", id, dto)
+	}
+
+	_, err = q.ExecuteBySlug(context.Background(), &getproduct.GetProductBySlugRequest{Slug: "no-such-product"})
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestUpdateProduct_RenameSlugIsConfigurable(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Mechanical Keyboard", "electronics")
+	name := "Mechanical Keyboard"
+
+	stable := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	if _, err := stable.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Name: &name}); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if got := repo.store[id].Slug(); got != "keyboard" {
+		t.Fatalf("expected the slug to stay stable by default, got %q", got)
+	}
+
+	name = "Mechanical  Keyboard!"
+	following := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, updateproduct.WithSlugFollowsName())
+	res, err := following.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Name: &name})
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if got := repo.store[id].Slug(); got != "mechanical-keyboard-2" {
+		t.Fatalf("expected a regenerated, suffixed slug, got %q", got)
+	}
+	if !slices.Contains(res.ChangedFields, domain.FieldSlug) {
+		t.Fatalf("expected slug in changed fields, got %v", res.ChangedFields)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Product stock
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestProductSetStock_RejectsNegative(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	if err := p.SetStock(-1, baseTime); !errors.Is(err, domain.ErrNegativeStock) {
+		t.Fatalf("expected ErrNegativeStock, got %v", err)
+	}
+	if p.StockQuantity() != 0 || p.Changes().Dirty(domain.FieldStock) {
+		t.Fatalf("expected rejected stock to leave the product untouched, got %d", p.StockQuantity())
+	}
+
+	p.ClearEvents()
+	if err := p.SetStock(5, baseTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.StockQuantity() != 5 || !p.Changes().Dirty(domain.FieldStock) {
+		t.Fatalf("expected stock 5 marked dirty, got %d", p.StockQuantity())
+	}
+	if len(p.Events()) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(p.Events()))
+	}
+	if e, ok := p.Events()[0].(*domain.ProductStockChangedEvent); !ok || e.Quantity() != 5 {
+		t.Fatalf("expected ProductStockChangedEvent with quantity 5, got %+v", p.Events()[0])
+	}
+
+	p.ClearEvents()
+	if err := p.SetStock(5, baseTime); err != nil || len(p.Events()) != 0 {
+		t.Fatalf("expected setting the same quantity to be a no-op, got err=%v events=%d", err, len(p.Events()))
+	}
+}
+
+func TestProductAdjustStock_GuardsAgainstNegative(t *testing.T) {
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:            "p-1",
+		Name:          "Laptop",
+		Category:      "electronics",
+		BasePrice:     domain.MustNewMoney(100, "USD"),
+		Status:        domain.ProductStatusActive,
+		StockQuantity: 3,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+
+	if err := p.AdjustStock(-4, baseTime); !errors.Is(err, domain.ErrNegativeStock) {
+		t.Fatalf("expected ErrNegativeStock, got %v", err)
+	}
+	if p.StockQuantity() != 3 || len(p.Events()) != 0 {
+		t.Fatalf("expected stock to stay at 3 with no events, got %d", p.StockQuantity())
+	}
+
+	if err := p.AdjustStock(-3, baseTime); err != nil {
+		t.Fatalf("expected selling the last units to succeed, got %v", err)
+	}
+	if p.StockQuantity() != 0 || p.InStock() {
+		t.Fatalf("expected product out of stock, got %d", p.StockQuantity())
+	}
+	if err := p.AdjustStock(7, baseTime); err != nil || p.StockQuantity() != 7 {
+		t.Fatalf("expected restock to 7, got %d (err=%v)", p.StockQuantity(), err)
+	}
+	if err := p.AdjustStock(math.MaxInt64, baseTime); !errors.Is(err, domain.ErrStockOverflow) {
+		t.Fatalf("expected ErrStockOverflow, got %v", err)
+	}
+}
+
+func TestListProducts_InStockOnly(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	inStock := createOne(t, repo, eventRepo, committer, ticker, "Keyboard", "misc")
+	soldOut := createOne(t, repo, eventRepo, committer, ticker, "Mouse", "misc")
+	if err := repo.store[inStock].SetStock(2, baseTime); err != nil {
+		t.Fatalf("set stock: %v", err)
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, InStockOnly: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != inStock || resp.Items[0].StockQuantity != 2 {
+		t.Fatalf("expected only %s with stock 2, got %+v", inStock, resp.Items)
+	}
+
+	resp, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 2 {
+		t.Fatalf("expected both products without the filter, got %d (sold out %s)", len(resp.Items), soldOut)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Quantity tiers
+// ────────────────────────────────────────────────────────────────────────────
+
+func mustTier(t *testing.T, minQuantity int64, percentage string) domain.QuantityTier {
+	t.Helper()
+	tier, err := domain.NewQuantityTier(minQuantity, percentage)
+	if err != nil {
+		t.Fatalf("new quantity tier: %v", err)
+	}
+	return tier
+}
+
+func TestEffectiveUnitPrice_TierBoundaries(t *testing.T) {
+	base := domain.MustNewMoney(10000, "USD")
+	// Deliberately unordered: the best applicable tier wins regardless of order.
+	tiers := []domain.QuantityTier{mustTier(t, 50, "10"), mustTier(t, 10, "5")}
+
+	for quantity, want := range map[int64]int64{
+		1:  10000,
+		9:  10000,
+		10: 9500,
+		49: 9500,
+		50: 9000,
+		51: 9000,
+	} {
+		got, err := pricing.EffectiveUnitPrice(base, tiers, quantity)
+		if err != nil {
+			t.Fatalf("quantity %d: expected no error, got %v", quantity, err)
+		}
+		if got.Amount() != want {
+			t.Fatalf("quantity %d: expected unit price %d, got %d", quantity, want, got.Amount())
+		}
+	}
+
+	if _, err := pricing.EffectiveUnitPrice(base, tiers, 0); !errors.Is(err, domain.ErrInvalidQuantity) {
+		t.Fatalf("expected ErrInvalidQuantity, got %v", err)
+	}
+}
+
+func TestEffectiveUnitPrice_IgnoredWhenDiscountsDisabled(t *testing.T) {
+	disabled := services.NewPricingCalculator(services.WithDiscountsDisabled(func() bool { return true }))
+	got, err := disabled.EffectiveUnitPrice(domain.MustNewMoney(10000, "USD"), []domain.QuantityTier{mustTier(t, 10, "5")}, 10)
+	if err != nil || got.Amount() != 10000 {
+		t.Fatalf("expected base price with discounts disabled, got %v (err=%v)", got, err)
+	}
+}
+
+func TestProductSetQuantityTiers(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	p.ClearEvents()
+
+	if err := p.SetQuantityTiers([]domain.QuantityTier{mustTier(t, 10, "5"), mustTier(t, 10, "7")}, baseTime); !errors.Is(err, domain.ErrDuplicateQuantityTier) {
+		t.Fatalf("expected ErrDuplicateQuantityTier, got %v", err)
+	}
+	if _, err := domain.NewQuantityTier(0, "5"); !errors.Is(err, domain.ErrInvalidQuantityTier) {
+		t.Fatalf("expected ErrInvalidQuantityTier, got %v", err)
+	}
+
+	if err := p.SetQuantityTiers([]domain.QuantityTier{mustTier(t, 50, "10"), mustTier(t, 10, "5")}, baseTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	tiers := p.QuantityTiers()
+	if len(tiers) != 2 || tiers[0].MinQuantity() != 10 || tiers[1].MinQuantity() != 50 {
+		t.Fatalf("expected tiers ordered by minimum quantity, got %+v", tiers)
+	}
+	if !p.Changes().Dirty(domain.FieldQuantityTiers) || len(p.Events()) != 1 {
+		t.Fatalf("expected tiers marked dirty with one event, got %d events", len(p.Events()))
+	}
+	if _, ok := p.Events()[0].(*domain.ProductQuantityTiersChangedEvent); !ok {
+		t.Fatalf("expected ProductQuantityTiersChangedEvent, got %T", p.Events()[0])
+	}
+
+	p.ClearEvents()
+	if err := p.SetQuantityTiers([]domain.QuantityTier{mustTier(t, 10, "5.0"), mustTier(t, 50, "10.00")}, baseTime); err != nil || len(p.Events()) != 0 {
+		t.Fatalf("expected setting the same tiers to be a no-op, got err=%v events=%d", err, len(p.Events()))
+	}
+}
+
+func TestPricePreview_BetterOfDiscountAndTier(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	p := repo.store[id]
+	if err := p.SetBasePrice(domain.MustNewMoney(10000, "USD")); err != nil {
+		t.Fatalf("set base price: %v", err)
+	}
+	if err := p.SetQuantityTiers([]domain.QuantityTier{mustTier(t, 10, "5"), mustTier(t, 50, "20")}, baseTime); err != nil {
+		t.Fatalf("set tiers: %v", err)
+	}
+	discount, err := domain.NewDiscount("10", baseTime.Add(-time.Hour), baseTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("new discount: %v", err)
+	}
+	if err := p.ApplyDiscount(discount, baseTime); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	const unit = 10000
+
+	q := pricepreview.NewPricePreviewQuery(repo, pricing, ticker)
+	for _, tc := range []struct {
+		quantity   int64
+		wantUnit   int64
+		wantTier   int64 // 0 = no tier applied
+		discounted bool
+	}{
+		{quantity: 9, wantUnit: unit * 90 / 100, discounted: true},
+		{quantity: 10, wantUnit: unit * 90 / 100, discounted: true}, // 10% window beats 5% tier
+		{quantity: 49, wantUnit: unit * 90 / 100, discounted: true},
+		{quantity: 50, wantUnit: unit * 80 / 100, wantTier: 50},
+	} {
+		dto, err := q.Execute(context.Background(), &pricepreview.PricePreviewRequest{ProductID: id, Quantity: tc.quantity})
+		if err != nil {
+			t.Fatalf("quantity %d: expected no error, got %v", tc.quantity, err)
+		}
+		if dto.UnitPrice.Amount != tc.wantUnit || dto.TotalPrice.Amount != tc.wantUnit*tc.quantity || dto.Discounted != tc.discounted {
+			t.Fatalf("quantity %d: unexpected preview %+v", tc.quantity, dto)
+		}
+		if (tc.wantTier == 0) != (dto.AppliedTier == nil) || (dto.AppliedTier != nil && dto.AppliedTier.MinQuantity != tc.wantTier) {
+			t.Fatalf("quantity %d: expected tier %d, got %+v", tc.quantity, tc.wantTier, dto.AppliedTier)
+		}
+	}
+
+	if _, err := q.Execute(context.Background(), &pricepreview.PricePreviewRequest{ProductID: id}); !errors.Is(err, domain.ErrInvalidQuantity) {
+		t.Fatalf("expected ErrInvalidQuantity, got %v", err)
+	}
+}
+
+func TestDiscountPreview_PricesWithoutApplying(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	if err := repo.store[id].SetBasePrice(domain.MustNewMoney(999, "USD")); err != nil {
+		t.Fatalf("set base price: %v", err)
+	}
+	calls := committer.calls
+
+	q := discountpreview.NewDiscountPreviewQuery(repo, pricing, ticker)
+	at := baseTime.Add(48 * time.Hour)
+	dto, err := q.Execute(context.Background(), &discountpreview.DiscountPreviewRequest{ProductID: id, Percentage: "15", At: at})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if dto.Percentage != "15.0" || !dto.At.Equal(at) || dto.BasePrice.Amount != 999 || dto.EffectivePrice.Amount != 849 || dto.Saved.Amount != 150 {
+		t.Fatalf("unexpected preview %+v", dto)
+	}
+	if repo.store[id].Discount() != nil || committer.calls != calls {
+		t.Fatal("expected the preview to leave the product untouched")
+	}
+
+	if _, err := q.Execute(context.Background(), &discountpreview.DiscountPreviewRequest{ProductID: id, Percentage: "150"}); !errors.Is(err, domain.ErrDiscountInvalidPercentage) {
+		t.Fatalf("expected ErrDiscountInvalidPercentage, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Additional categories
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestProductAddCategory_CapAndDuplicates(t *testing.T) {
+	p, err := domain.NewProduct("Laptop", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	p.ClearEvents()
+
+	for _, c := range []string{"laptops", "office"} {
+		if err := p.AddCategory(c, 3, baseTime); err != nil {
+			t.Fatalf("add %s: %v", c, err)
+		}
+	}
+	if !slices.Equal(p.Categories(), []string{"electronics", "laptops", "office"}) || !p.Changes().Dirty(domain.FieldCategories) {
+		t.Fatalf("expected primary then additional categories, got %v", p.Categories())
+	}
+	e, ok := p.Events()[1].(*domain.ProductCategoriesChangedEvent)
+	if len(p.Events()) != 2 || !ok || !slices.Equal(e.Categories(), p.Categories()) {
+		t.Fatalf("expected a ProductCategoriesChangedEvent per addition, got %+v", p.Events())
+	}
+
+	if err := p.AddCategory("gaming", 3, baseTime); !errors.Is(err, domain.ErrTooManyCategories) {
+		t.Fatalf("expected ErrTooManyCategories, got %v", err)
+	}
+	for _, dup := range []string{"electronics", "laptops"} {
+		if err := p.AddCategory(dup, 10, baseTime); !errors.Is(err, domain.ErrDuplicateCategory) {
+			t.Fatalf("%s: expected ErrDuplicateCategory, got %v", dup, err)
+		}
+	}
+	if err := p.SetCategory("office"); !errors.Is(err, domain.ErrDuplicateCategory) {
+		t.Fatalf("expected an additional category to be refused as primary, got %v", err)
+	}
+	if len(p.Categories()) != 3 || len(p.Events()) != 2 {
+		t.Fatalf("expected rejected changes to leave the product untouched, got %v", p.Categories())
+	}
+
+	if err := p.RemoveCategory("electronics", baseTime); !errors.Is(err, domain.ErrPrimaryCategoryRemoval) {
+		t.Fatalf("expected ErrPrimaryCategoryRemoval, got %v", err)
+	}
+	if err := p.RemoveCategory("laptops", baseTime); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := p.AddCategory("gaming", 3, baseTime); err != nil {
+		t.Fatalf("expected room after a removal, got %v", err)
+	}
+	if !slices.Equal(p.AdditionalCategories(), []string{"office", "gaming"}) {
+		t.Fatalf("unexpected additional categories %v", p.AdditionalCategories())
+	}
+}
+
+func TestProductRenameCategory_AdditionalCategories(t *testing.T) {
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:                   "p-1",
+		Name:                 "Laptop",
+		Category:             "electronics",
+		BasePrice:            domain.MustNewMoney(100, "USD"),
+		Status:               domain.ProductStatusActive,
+		AdditionalCategories: []string{"laptops", "office"},
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+
+	if err := p.RenameCategory("laptops", "notebooks", baseTime); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if !slices.Equal(p.Categories(), []string{"electronics", "notebooks", "office"}) {
+		t.Fatalf("expected additional category renamed in place, got %v", p.Categories())
+	}
+
+	// Renaming the primary onto an additional category merges the two.
+	if err := p.RenameCategory("electronics", "office", baseTime); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if !slices.Equal(p.Categories(), []string{"office", "notebooks"}) {
+		t.Fatalf("expected categories merged, got %v", p.Categories())
+	}
+}
+
+func TestListProducts_CategoryMatchesAnyListedCategory(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	laptop := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	createOne(t, repo, eventRepo, committer, ticker, "Desk", "furniture")
+	chair := createOne(t, repo, eventRepo, committer, ticker, "Chair", "furniture")
+	if err := repo.store[laptop].AddCategory("office", domain.DefaultMaxCategories, baseTime); err != nil {
+		t.Fatalf("add category: %v", err)
+	}
+	if err := repo.store[chair].AddCategory("office", domain.DefaultMaxCategories, baseTime); err != nil {
+		t.Fatalf("add category: %v", err)
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	office := "office"
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, Category: &office})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var ids []string
+	for _, item := range resp.Items {
+		ids = append(ids, item.ID)
+	}
+	slices.Sort(ids)
+	want := []string{laptop, chair}
+	slices.Sort(want)
+	if !slices.Equal(ids, want) {
+		t.Fatalf("expected products listed in office through an additional category, got %v", ids)
+	}
+
+	electronics := "electronics"
+	resp, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, Category: &electronics})
+	if err != nil || len(resp.Items) != 1 || resp.Items[0].ID != laptop {
+		t.Fatalf("expected the primary category to keep matching, got %+v (err=%v)", resp.Items, err)
+	}
+}
+
+func TestUpdateProduct_CategoryCapIsConfigurable(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker, updateproduct.WithMaxCategories(2))
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, AddCategories: []string{"office"}})
+	if err != nil || !slices.Contains(res.ChangedFields, domain.FieldCategories) {
+		t.Fatalf("expected office added, got %+v (err=%v)", res, err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, AddCategories: []string{"gaming"}})
+	if !errors.Is(err, domain.ErrTooManyCategories) {
+		t.Fatalf("expected ErrTooManyCategories, got %v", err)
+	}
+
+	// Removals are applied first, so swapping a category fits under the cap.
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:        id,
+		AddCategories:    []string{"gaming"},
+		RemoveCategories: []string{"office"},
+	})
+	if err != nil || !slices.Equal(repo.store[id].Categories(), []string{"electronics", "gaming"}) {
+		t.Fatalf("expected office swapped for gaming, got %v (err=%v)", repo.store[id].Categories(), err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Product attributes
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestProductSetAttribute(t *testing.T) {
+	p, err := domain.NewProduct("T-shirt", "", "apparel", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	p.ClearEvents()
+
+	if err := p.SetAttribute("color", "red", baseTime); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if v, ok := p.Attribute("color"); !ok || v != "red" || !p.Changes().Dirty(domain.FieldAttributes) {
+		t.Fatalf("expected color=red marked dirty, got %q, %v", v, ok)
+	}
+	e, ok := p.Events()[0].(*domain.ProductAttributesChangedEvent)
+	if len(p.Events()) != 1 || !ok || e.Attributes()["color"] != "red" {
+		t.Fatalf("expected a ProductAttributesChangedEvent, got %+v", p.Events())
+	}
+
+	if err := p.SetAttribute("color", "red", baseTime); err != nil || len(p.Events()) != 1 {
+		t.Fatalf("expected setting the current value to be a no-op, got %d events (err=%v)", len(p.Events()), err)
+	}
+
+	// The accessor hands out a copy.
+	p.Attributes()["color"] = "blue"
+	if v, _ := p.Attribute("color"); v != "red" {
+		t.Fatalf("expected the stored attribute untouched, got %q", v)
+	}
+
+	p.RemoveAttribute("color", baseTime)
+	if p.Attributes() != nil || len(p.Events()) != 2 {
+		t.Fatalf("expected the attribute removed with an event, got %v", p.Attributes())
+	}
+	p.RemoveAttribute("color", baseTime)
+	if len(p.Events()) != 2 {
+		t.Fatal("expected removing an unset attribute to be a no-op")
+	}
+}
+
+func TestProductSetAttribute_Validation(t *testing.T) {
+	p, err := domain.NewProduct("T-shirt", "", "apparel", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+
+	for _, key := range []string{"", "Color", "1size", "screen-size", "size ", strings.Repeat("k", domain.MaxAttributeKeyLength+1)} {
+		if err := p.SetAttribute(key, "x", baseTime); !errors.Is(err, domain.ErrInvalidAttributeKey) {
+			t.Fatalf("%q: expected ErrInvalidAttributeKey, got %v", key, err)
+		}
+	}
+	for _, value := range []string{"", strings.Repeat("v", domain.MaxAttributeValueLength+1)} {
+		if err := p.SetAttribute("material", value, baseTime); !errors.Is(err, domain.ErrInvalidAttributeValue) {
+			t.Fatalf("expected ErrInvalidAttributeValue for a %d-byte value, got %v", len(value), err)
+		}
+	}
+
+	for i := range domain.MaxAttributes {
+		if err := p.SetAttribute(fmt.Sprintf("attr_%d", i), "x", baseTime); err != nil {
+			t.Fatalf("set %d: %v", i, err)
+		}
+	}
+	if err := p.SetAttribute("one_more", "x", baseTime); !errors.Is(err, domain.ErrTooManyAttributes) {
+		t.Fatalf("expected ErrTooManyAttributes, got %v", err)
+	}
+	if err := p.SetAttribute("attr_0", "y", baseTime); err != nil {
+		t.Fatalf("expected existing attributes to stay editable at the cap, got %v", err)
+	}
+}
+
+func TestListProducts_AttributeFilter(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	red := createOne(t, repo, eventRepo, committer, ticker, "Red shirt", "apparel")
+	blue := createOne(t, repo, eventRepo, committer, ticker, "Blue shirt", "apparel")
+	createOne(t, repo, eventRepo, committer, ticker, "Plain shirt", "apparel")
+	if err := repo.store[red].SetAttribute("color", "red", baseTime); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := repo.store[blue].SetAttribute("color", "blue", baseTime); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, AttributeKey: "color", AttributeValue: "red"})
+	if err != nil || len(resp.Items) != 1 || resp.Items[0].ID != red {
+		t.Fatalf("expected only the red shirt, got %+v (err=%v)", resp.Items, err)
+	}
+
+	_, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, AttributeKey: "color"})
+	if !errors.Is(err, domain.ErrIncompleteAttribute) {
+		t.Fatalf("expected ErrIncompleteAttribute, got %v", err)
+	}
+	_, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, AttributeKey: "Color", AttributeValue: "red"})
+	if !errors.Is(err, domain.ErrInvalidAttributeKey) {
+		t.Fatalf("expected ErrInvalidAttributeKey, got %v", err)
+	}
+}
+
+func TestUpdateProduct_Attributes(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createOne(t, repo, eventRepo, committer, ticker, "T-shirt", "apparel")
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:     id,
+		SetAttributes: map[string]string{"color": "red", "size": "m"},
+	})
+	if err != nil || !slices.Contains(res.ChangedFields, domain.FieldAttributes) {
+		t.Fatalf("expected attributes changed, got %+v (err=%v)", res, err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:        id,
+		SetAttributes:    map[string]string{"material": "cotton"},
+		RemoveAttributes: []string{"size"},
+	})
+	want := map[string]string{"color": "red", "material": "cotton"}
+	if err != nil || !maps.Equal(repo.store[id].Attributes(), want) {
+		t.Fatalf("expected %v, got %v (err=%v)", want, repo.store[id].Attributes(), err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:     id,
+		SetAttributes: map[string]string{"Bad-Key": "x"},
+	})
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, domain.ErrInvalidAttributeKey) {
+		t.Fatalf("expected a validation error for the key, got %v", err)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Scheduled publishing
+// ────────────────────────────────────────────────────────────────────────────
+
+// createScheduled creates a product staged to go live at publishAt.
+func createScheduled(t *testing.T, repo *inMemoryProductRepo, eventRepo *inMemoryEventRepo, committer *mockCommitter, ticker common.Ticker, name string, publishAt time.Time) string {
+	t.Helper()
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+	id, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name:      name,
+		Category:  "electronics",
+		Amount:    amount(100),
+		PublishAt: &publishAt,
+	})
+	if err != nil {
+		t.Fatalf("createScheduled: %v", err)
+	}
+	return id
+}
+
+func TestCreateProduct_PublishAtStagesProduct(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	publishAt := baseTime.Add(time.Hour)
+	id := createScheduled(t, repo, eventRepo, committer, ticker, "Phone", publishAt)
+
+	p := repo.store[id]
+	if p.IsActive() || p.PublishAt() == nil || !p.PublishAt().Equal(publishAt) {
+		t.Fatalf("expected an inactive product scheduled for %v, got status %s at %v", publishAt, p.Status(), p.PublishAt())
+	}
+	// The product is born inactive: no deactivation is recorded for it.
+	if len(eventRepo.events) != 2 {
+		t.Fatalf("expected product.created then product.scheduled, got %+v", eventRepo.events)
+	}
+	created, ok := eventRepo.events[0].(*domain.ProductCreatedEvent)
+	if !ok || created.Status() != domain.ProductStatusInactive {
+		t.Fatalf("expected an inactive ProductCreatedEvent first, got %+v", eventRepo.events[0])
+	}
+	scheduled, ok := eventRepo.events[1].(*domain.ProductScheduledEvent)
+	if !ok || !scheduled.PublishAt().Equal(publishAt) {
+		t.Fatalf("expected a ProductScheduledEvent last, got %+v", eventRepo.events[1])
+	}
+
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+	past := baseTime.Add(-time.Minute)
+	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{Name: "Late", Category: "electronics", Amount: amount(100), PublishAt: &past})
+	if !errors.Is(err, domain.ErrPublishAtNotInFuture) {
+		t.Fatalf("expected ErrPublishAtNotInFuture, got %v", err)
+	}
+}
+
+func TestProductSetPublishAt_RequiresInactiveProduct(t *testing.T) {
+	p, err := domain.NewProduct("Phone", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	at := baseTime.Add(time.Hour)
+	if err := p.SetPublishAt(&at, baseTime); !errors.Is(err, domain.ErrProductAlreadyActive) {
+		t.Fatalf("expected ErrProductAlreadyActive, got %v", err)
+	}
+
+	if err := p.Deactivate(baseTime, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if err := p.SetPublishAt(&at, baseTime); err != nil || !p.Changes().Dirty(domain.FieldPublishAt) {
+		t.Fatalf("expected the schedule set and marked dirty, got %v", err)
+	}
+	// Activating by hand supersedes the schedule.
+	if err := p.Activate(baseTime, false); err != nil || p.PublishAt() != nil {
+		t.Fatalf("expected activation to clear the schedule, got %v (err=%v)", p.PublishAt(), err)
+	}
+}
+
+func TestPublishScheduled_ActivatesDueProductsOnly(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	due := createScheduled(t, repo, eventRepo, committer, ticker, "Due", baseTime.Add(time.Hour))
+	later := createScheduled(t, repo, eventRepo, committer, ticker, "Later", baseTime.Add(3*time.Hour))
+	live := createOne(t, repo, eventRepo, committer, ticker, "Live", "electronics")
+	eventRepo.events = nil
+	committer.calls = 0
+
+	it := publishscheduled.NewPublishScheduledInteractor(committer, repo, eventRepo, newTicker(baseTime.Add(2*time.Hour)))
+	n, err := it.Execute(context.Background(), 10)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 product published, got %d (err=%v)", n, err)
+	}
+	if p := repo.store[due]; !p.IsActive() || p.PublishAt() != nil {
+		t.Fatalf("expected the due product live with its schedule cleared, got status %s at %v", p.Status(), p.PublishAt())
+	}
+	if p := repo.store[later]; p.IsActive() || p.PublishAt() == nil {
+		t.Fatalf("expected the not-yet-due product still staged, got status %s", p.Status())
+	}
+	if !repo.store[live].IsActive() {
+		t.Fatal("expected the unscheduled product untouched")
+	}
+	if len(eventRepo.events) != 1 {
+		t.Fatalf("expected one activation event, got %+v", eventRepo.events)
+	}
+	if _, ok := eventRepo.events[0].(*domain.ProductActivatedEvent); !ok {
+		t.Fatalf("expected a ProductActivatedEvent, got %T", eventRepo.events[0])
+	}
+
+	n, err = it.Execute(context.Background(), 10)
+	if err != nil || n != 0 || committer.calls != 1 {
+		t.Fatalf("expected a rerun to publish nothing without committing, got %d, %d commits (err=%v)", n, committer.calls, err)
+	}
+}
+
+func TestPublishScheduled_SkipsArchivedAndHonoursLimit(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	first := createScheduled(t, repo, eventRepo, committer, ticker, "First", baseTime.Add(time.Hour))
+	second := createScheduled(t, repo, eventRepo, committer, ticker, "Second", baseTime.Add(90*time.Minute))
+	archivedAt, publishAt := baseTime.Add(-time.Hour), baseTime.Add(time.Hour)
+	archived, err := domain.Reconstitute(domain.ProductState{
+		ID:         "archived-1",
+		Name:       "Archived",
+		Category:   "electronics",
+		BasePrice:  domain.MustNewMoney(100, "USD"),
+		Status:     domain.ProductStatusInactive,
+		ArchivedAt: &archivedAt,
+		PublishAt:  &publishAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[archived.ID()] = archived
+
+	it := publishscheduled.NewPublishScheduledInteractor(committer, repo, eventRepo, newTicker(baseTime.Add(2*time.Hour)))
+	if n, err := it.Execute(context.Background(), 1); err != nil || n != 1 || !repo.store[first].IsActive() || repo.store[second].IsActive() {
+		t.Fatalf("expected only the earliest due product published, got %d (err=%v)", n, err)
+	}
+	if n, err := it.Execute(context.Background(), 1); err != nil || n != 1 || !repo.store[second].IsActive() {
+		t.Fatalf("expected the next run to publish the second product, got %d (err=%v)", n, err)
+	}
+	if n, err := it.Execute(context.Background(), 1); err != nil || n != 0 || archived.IsActive() {
+		t.Fatalf("expected the archived product skipped, got %d (err=%v)", n, err)
+	}
+}
+
+func TestUpdateProduct_PublishAt(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createScheduled(t, repo, eventRepo, committer, ticker, "Phone", baseTime.Add(time.Hour))
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		PublishAt: &updateproduct.ScheduleUpdate{At: baseTime.Add(2 * time.Hour)},
+	})
+	if err != nil || !slices.Contains(res.ChangedFields, domain.FieldPublishAt) || !repo.store[id].PublishAt().Equal(baseTime.Add(2*time.Hour)) {
+		t.Fatalf("expected the launch rescheduled, got %+v (err=%v)", res, err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID: id,
+		PublishAt: &updateproduct.ScheduleUpdate{Clear: true},
+	})
+	if err != nil || repo.store[id].PublishAt() != nil {
+		t.Fatalf("expected the launch cancelled, got %v (err=%v)", repo.store[id].PublishAt(), err)
+	}
+	last, ok := eventRepo.events[len(eventRepo.events)-1].(*domain.ProductScheduledEvent)
+	if !ok || last.PublishAt() != nil {
+		t.Fatalf("expected a cancelling ProductScheduledEvent, got %+v", eventRepo.events)
+	}
+}
+
+func TestCreateProduct_UnpublishAtMustFollowPublishAt(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	it := createproduct.NewCreateProductInteractor(committer, repo, eventRepo, ticker, createproduct.DefaultConfig())
+	publishAt, unpublishAt := baseTime.Add(2*time.Hour), baseTime.Add(time.Hour)
+
+	_, err := it.Execute(context.Background(), &createproduct.CreateProductRequest{
+		Name: "Phone", Category: "electronics", Amount: amount(100), PublishAt: &publishAt, UnpublishAt: &unpublishAt,
+	})
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, domain.ErrUnpublishBeforePublish) {
+		t.Fatalf("expected a validation error for unpublish_at, got %v", err)
+	}
+	if len(repo.store) != 0 {
+		t.Fatal("expected nothing created")
+	}
+}
+
+func TestUpdateProduct_UnpublishAtOrdering(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	id := createScheduled(t, repo, eventRepo, committer, ticker, "Phone", baseTime.Add(2*time.Hour))
+	it := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+
+	// Checked against the stored launch when only the takedown changes.
+	_, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:   id,
+		UnpublishAt: &updateproduct.ScheduleUpdate{At: baseTime.Add(2 * time.Hour)},
+	})
+	if !errors.Is(err, domain.ErrUnpublishBeforePublish) {
+		t.Fatalf("expected ErrUnpublishBeforePublish, got %v", err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:   id,
+		UnpublishAt: &updateproduct.ScheduleUpdate{At: baseTime.Add(4 * time.Hour)},
+	})
+	if err != nil || !repo.store[id].UnpublishAt().Equal(baseTime.Add(4*time.Hour)) {
+		t.Fatalf("expected the takedown scheduled, got %v (err=%v)", repo.store[id].UnpublishAt(), err)
+	}
+
+	// Moving both past the stored takedown applies the takedown first.
+	res, err := it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:   id,
+		PublishAt:   &updateproduct.ScheduleUpdate{At: baseTime.Add(5 * time.Hour)},
+		UnpublishAt: &updateproduct.ScheduleUpdate{At: baseTime.Add(6 * time.Hour)},
+	})
+	if err != nil || !slices.Contains(res.ChangedFields, domain.FieldPublishAt) || !slices.Contains(res.ChangedFields, domain.FieldUnpublishAt) {
+		t.Fatalf("expected both times moved, got %+v (err=%v)", res, err)
+	}
+
+	_, err = it.Execute(context.Background(), &updateproduct.UpdateProductRequest{
+		ProductID:   id,
+		PublishAt:   &updateproduct.ScheduleUpdate{At: baseTime.Add(8 * time.Hour)},
+		UnpublishAt: &updateproduct.ScheduleUpdate{At: baseTime.Add(7 * time.Hour)},
+	})
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, domain.ErrUnpublishBeforePublish) {
+		t.Fatalf("expected a validation error for unpublish_at, got %v", err)
+	}
+}
+
+func TestUnpublishScheduled_DeactivatesDueProductsOnly(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	later := createOne(t, repo, eventRepo, committer, ticker, "Later", "electronics")
+	laterAt := baseTime.Add(3 * time.Hour)
+	if err := repo.store[later].SetUnpublishAt(&laterAt, baseTime); err != nil {
+		t.Fatalf("set unpublish: %v", err)
+	}
+	// An upcoming discount does not hold back a scheduled takedown.
+	d, err := domain.NewDiscount("10", baseTime.Add(5*time.Hour), baseTime.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("discount: %v", err)
+	}
+	dueAt := baseTime.Add(time.Hour)
+	p, err := domain.Reconstitute(domain.ProductState{
+		ID:          "due-1",
+		Name:        "Due",
+		Category:    "electronics",
+		BasePrice:   domain.MustNewMoney(100, "USD"),
+		Discount:    d,
+		Status:      domain.ProductStatusActive,
+		UnpublishAt: &dueAt,
+	})
+	if err != nil {
+		t.Fatalf("reconstitute: %v", err)
+	}
+	repo.store[p.ID()] = p
+	due := p.ID()
+	eventRepo.events = nil
+
+	it := unpublishscheduled.NewUnpublishScheduledInteractor(committer, repo, eventRepo, newTicker(baseTime.Add(2*time.Hour)))
+	n, err := it.Execute(context.Background(), 10)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 product unpublished, got %d (err=%v)", n, err)
+	}
+	if p := repo.store[due]; p.IsActive() || p.UnpublishAt() != nil {
+		t.Fatalf("expected the due product down with its takedown cleared, got status %s at %v", p.Status(), p.UnpublishAt())
+	}
+	if p := repo.store[later]; !p.IsActive() || p.UnpublishAt() == nil {
+		t.Fatalf("expected the not-yet-due product still live, got status %s", p.Status())
+	}
+	var deactivated *domain.ProductDeactivatedEvent
+	for _, e := range eventRepo.events {
+		if de, ok := e.(*domain.ProductDeactivatedEvent); ok {
+			deactivated = de
+		}
+	}
+	if deactivated == nil || deactivated.Reason() != domain.DeactivationReasonScheduled {
+		t.Fatalf("expected a scheduled ProductDeactivatedEvent, got %+v", eventRepo.events)
+	}
+
+	if n, err := it.Execute(context.Background(), 10); err != nil || n != 0 {
+		t.Fatalf("expected a rerun to unpublish nothing, got %d (err=%v)", n, err)
+	}
+}
+
+func TestDeactivateProduct_ReasonManual(t *testing.T) {
+	p, err := domain.NewProduct("Phone", "", "electronics", domain.MustNewMoney(100, "USD"), baseTime)
+	if err != nil {
+		t.Fatalf("new product: %v", err)
+	}
+	p.ClearEvents()
+	if err := p.Deactivate(baseTime, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	e, ok := p.Events()[0].(*domain.ProductDeactivatedEvent)
+	if !ok || e.Reason() != domain.DeactivationReasonManual {
+		t.Fatalf("expected a manual ProductDeactivatedEvent, got %+v", p.Events())
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// On-sale listings
+// ────────────────────────────────────────────────────────────────────────────
+
+// seedDiscountStates stores one active product per discount state, plus one
+// without a discount, and returns their IDs by state.
+func seedDiscountStates(t *testing.T, repo *inMemoryProductRepo) map[string]string {
+	t.Helper()
+	periods := map[string][2]time.Time{
+		"active":   {baseTime.Add(-time.Hour), baseTime.Add(time.Hour)},
+		"upcoming": {baseTime.Add(time.Hour), baseTime.Add(2 * time.Hour)},
+		"expired":  {baseTime.Add(-2 * time.Hour), baseTime.Add(-time.Hour)},
+		"ends-now": {baseTime.Add(-time.Hour), baseTime}, // the end is exclusive
+		"none":     {},
+	}
+	ids := make(map[string]string, len(periods))
+	for state, period := range periods {
+		var d *domain.Discount
+		if !period[0].IsZero() {
+			var err error
+			if d, err = domain.NewDiscount("20", period[0], period[1]); err != nil {
+				t.Fatalf("new discount %s: %v", state, err)
+			}
+		}
+		p, err := domain.Reconstitute(domain.ProductState{
+			ID:        "p-" + state,
+			Name:      state,
+			Category:  "electronics",
+			BasePrice: domain.MustNewMoney(1000, "USD"),
+			Discount:  d,
+			Status:    domain.ProductStatusActive,
+		})
+		if err != nil {
+			t.Fatalf("reconstitute %s: %v", state, err)
+		}
+		repo.store[p.ID()] = p
+		ids[state] = p.ID()
+	}
+	return ids
+}
+
+func TestListProducts_OnSaleExcludesUpcomingAndExpiredDiscounts(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	ids := seedDiscountStates(t, repo)
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig())
+
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, OnSale: true, IncludeTotal: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != ids["active"] || !resp.Items[0].IsDiscounted || resp.TotalCount != 1 {
+		t.Fatalf("expected only the product with a discount valid now, got %+v (total %d)", resp.Items, resp.TotalCount)
+	}
+
+	// The same catalog an hour later: the upcoming discount has started and the active one ended.
+	ctx := common.WithNow(context.Background(), baseTime.Add(time.Hour))
+	resp, err = q.Execute(ctx, &listproducts.ListProductsRequest{Limit: 10, OnSale: true})
+	if err != nil || len(resp.Items) != 1 || resp.Items[0].ID != ids["upcoming"] {
+		t.Fatalf("expected only the started discount an hour later, got %+v (err=%v)", resp.Items, err)
+	}
+
+	resp, err = q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10})
+	if err != nil || len(resp.Items) != len(ids) {
+		t.Fatalf("expected every product without the filter, got %d (err=%v)", len(resp.Items), err)
+	}
+}
+
+func TestListProducts_OnSaleEmptyWhenDiscountsDisabled(t *testing.T) {
+	repo, _, _, ticker := buildDeps(t)
+	seedDiscountStates(t, repo)
+	disabled := services.NewPricingCalculator(services.WithDiscountsDisabled(func() bool { return true }))
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, disabled, ticker, listproducts.DefaultConfig())
+
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{Limit: 10, OnSale: true})
+	if err != nil || len(resp.Items) != 0 {
+		t.Fatalf("expected nothing on sale with discounts disabled, got %+v (err=%v)", resp.Items, err)
 	}
 }
@@ -0,0 +1,260 @@
+package integration_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/product-catalog-service/common"
+	"github.com/product-catalog-service/internal/app/product/contract"
+	"github.com/product-catalog-service/internal/app/product/domain"
+	"github.com/product-catalog-service/internal/app/product/projection"
+	listproducts "github.com/product-catalog-service/internal/app/product/queries/list_products"
+	applydiscount "github.com/product-catalog-service/internal/app/product/usecases/apply_discount"
+	updateproduct "github.com/product-catalog-service/internal/app/product/usecases/update_product"
+	"github.com/product-catalog-service/internal/models/m_outbox"
+)
+
+// ────────────────────────────────────────────────────────────────────────────
+// In-memory read model
+// ────────────────────────────────────────────────────────────────────────────
+
+// inMemoryReadModel is a map-backed implementation of contract.ReadModelRepository.
+type inMemoryReadModel struct {
+	rows map[string]*contract.ProductListing
+}
+
+func newInMemoryReadModel() *inMemoryReadModel {
+	return &inMemoryReadModel{rows: make(map[string]*contract.ProductListing)}
+}
+
+func (m *inMemoryReadModel) ListListings(_ context.Context, filter contract.ListProductsFilter, page contract.Page) ([]*contract.ProductListing, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []domain.ProductStatus{domain.ProductStatusActive}
+	}
+
+	var result []*contract.ProductListing
+	for _, l := range m.rows {
+		if !slices.Contains(statuses, l.Status) {
+			continue
+		}
+		if filter.Category != nil && l.Category != *filter.Category && !slices.Contains(l.AdditionalCategories, *filter.Category) {
+			continue
+		}
+		if filter.Featured != nil && l.Featured != *filter.Featured {
+			continue
+		}
+		if filter.InStockOnly && l.StockQuantity <= 0 {
+			continue
+		}
+		if a := filter.Attribute; a != nil && l.Attributes[a.Key] != a.Value {
+			continue
+		}
+		result = append(result, l)
+	}
+	slices.SortFunc(result, func(a, b *contract.ProductListing) int {
+		if a.Featured != b.Featured {
+			if a.Featured {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ProductID, b.ProductID)
+	})
+	if page.Offset >= len(result) {
+		return nil, nil
+	}
+	return result[page.Offset:min(page.Offset+page.Limit, len(result))], nil
+}
+
+func (m *inMemoryReadModel) Upsert(_ context.Context, l *contract.ProductListing) error {
+	if prev, ok := m.rows[l.ProductID]; ok && l.CreatedAt == nil {
+		l.CreatedAt = prev.CreatedAt
+	}
+	m.rows[l.ProductID] = l
+	return nil
+}
+
+func (m *inMemoryReadModel) ListDue(_ context.Context, now time.Time, limit int) ([]string, error) {
+	var ids []string
+	for id, l := range m.rows {
+		if l.NextPriceChangeAt != nil && !l.NextPriceChangeAt.After(now) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// projectEvents delivers events to p the way the relay would, as outbox rows
+// committed at baseTime.
+func projectEvents(t *testing.T, p *projection.Projector, events []domain.DomainEvent) {
+	t.Helper()
+	for _, e := range events {
+		row := m_outbox.OutboxEventRow{
+			EventType:   e.EventName(),
+			AggregateID: e.(interface{ ProductID() string }).ProductID(),
+			CreatedAt:   baseTime,
+		}
+		if err := p.Publish(context.Background(), row); err != nil {
+			t.Fatalf("project %s: %v", e.EventName(), err)
+		}
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// Projector
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestProjector_ProjectsCreatedProduct(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	readModel := newInMemoryReadModel()
+	p := projection.NewProjector(repo, readModel, pricing, ticker)
+
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	projectEvents(t, p, eventRepo.events)
+
+	row, ok := readModel.rows[id]
+	if !ok {
+		t.Fatal("expected a read model row for the new product")
+	}
+	if row.Name != "Laptop" || row.Category != "electronics" || row.Status != domain.ProductStatusActive {
+		t.Fatalf("unexpected row %+v", row)
+	}
+	if !row.EffectivePrice.Equals(row.BasePrice) || row.IsDiscounted || row.NextPriceChangeAt != nil {
+		t.Fatalf("expected an undiscounted row, got %+v", row)
+	}
+	if row.CreatedAt == nil || !row.CreatedAt.Equal(baseTime) {
+		t.Fatalf("expected created_at from the product.created event, got %v", row.CreatedAt)
+	}
+}
+
+func TestProjector_ProjectsUpdateAndDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	readModel := newInMemoryReadModel()
+	p := projection.NewProjector(repo, readModel, pricing, ticker)
+
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	projectEvents(t, p, eventRepo.events)
+	eventRepo.events = nil
+
+	name := "Gaming Laptop"
+	updateIt := updateproduct.NewUpdateProductInteractor(committer, repo, eventRepo, ticker)
+	if _, err := updateIt.Execute(context.Background(), &updateproduct.UpdateProductRequest{ProductID: id, Name: &name}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	endsAt := baseTime.Add(24 * time.Hour)
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "20",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     endsAt,
+	}); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	projectEvents(t, p, eventRepo.events)
+
+	row := readModel.rows[id]
+	if row.Name != name {
+		t.Fatalf("expected projected name %q, got %q", name, row.Name)
+	}
+	if !row.IsDiscounted || row.EffectivePrice.Amount() != row.BasePrice.Amount()*8/10 {
+		t.Fatalf("expected a 20%% discounted price, got %+v", row)
+	}
+	if row.DiscountEndsAt == nil || !row.DiscountEndsAt.Equal(endsAt) {
+		t.Fatalf("expected discount end %v, got %v", endsAt, row.DiscountEndsAt)
+	}
+	if row.NextPriceChangeAt == nil || !row.NextPriceChangeAt.Equal(endsAt) {
+		t.Fatalf("expected the sweep to be due at %v, got %v", endsAt, row.NextPriceChangeAt)
+	}
+	if row.CreatedAt == nil {
+		t.Fatal("expected later projections to keep created_at")
+	}
+}
+
+func TestProjector_SweepRefreshesExpiredDiscount(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	readModel := newInMemoryReadModel()
+
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	applyIt := applydiscount.NewApplyDiscountInteractor(committer, repo, eventRepo, ticker)
+	if err := applyIt.Execute(context.Background(), &applydiscount.ApplyDiscountRequest{
+		ProductID:  id,
+		Percentage: "20",
+		StartsAt:   baseTime.Add(-time.Hour),
+		EndsAt:     baseTime.Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("apply discount: %v", err)
+	}
+	projectEvents(t, projection.NewProjector(repo, readModel, pricing, ticker), eventRepo.events)
+
+	// Nothing is due while the discount runs.
+	if n, err := projection.NewProjector(repo, readModel, pricing, ticker).Sweep(context.Background(), 10); err != nil || n != 0 {
+		t.Fatalf("expected nothing due yet, got %d, %v", n, err)
+	}
+
+	later := projection.NewProjector(repo, readModel, pricing, newTicker(baseTime.Add(2*time.Hour)))
+	n, err := later.Sweep(context.Background(), 10)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 refreshed row, got %d, %v", n, err)
+	}
+	row := readModel.rows[id]
+	if row.IsDiscounted || !row.EffectivePrice.Equals(row.BasePrice) || row.NextPriceChangeAt != nil {
+		t.Fatalf("expected the expired discount to be dropped, got %+v", row)
+	}
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// ListProducts from the read model
+// ────────────────────────────────────────────────────────────────────────────
+
+func TestListProducts_ServesFromReadModel(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	readModel := newInMemoryReadModel()
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	projectEvents(t, projection.NewProjector(repo, readModel, pricing, ticker), eventRepo.events)
+
+	// Mark the projected row so the test can tell which path served the list.
+	readModel.rows[id].Name = "Projected Laptop"
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig(),
+		listproducts.WithReadModel(readModel))
+
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Name != "Projected Laptop" {
+		t.Fatalf("expected the projected row, got %+v", resp.Items)
+	}
+
+	// A pinned request time needs prices at that time, which the projection cannot give.
+	ctx := common.WithNow(context.Background(), baseTime.Add(time.Hour))
+	resp, err = q.Execute(ctx, &listproducts.ListProductsRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Name != "Laptop" {
+		t.Fatalf("expected the computed listing for a pinned time, got %+v", resp.Items)
+	}
+}
+
+func TestListProducts_OnSaleBypassesReadModel(t *testing.T) {
+	repo, eventRepo, committer, ticker := buildDeps(t)
+	readModel := newInMemoryReadModel()
+	id := createOne(t, repo, eventRepo, committer, ticker, "Laptop", "electronics")
+	projectEvents(t, projection.NewProjector(repo, readModel, pricing, ticker), eventRepo.events)
+
+	// A stale projection claims a discount the product does not have.
+	readModel.rows[id].IsDiscounted = true
+
+	q := listproducts.NewListProductsQuery(repo, &inMemoryCategoryRepo{}, pricing, ticker, listproducts.DefaultConfig(),
+		listproducts.WithReadModel(readModel))
+	resp, err := q.Execute(context.Background(), &listproducts.ListProductsRequest{OnSale: true})
+	if err != nil || len(resp.Items) != 0 {
+		t.Fatalf("expected the on-sale filter checked against the discount period, got %+v (err=%v)", resp.Items, err)
+	}
+}